@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"sync"
 	"time"
+	_ "time/tzdata" // time zones of events on hosts without tzdata
 
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/calendar/grpcserver"
@@ -24,13 +25,15 @@ const serviceName = "Calendar"
 const ctxTimeout = 2 // in second
 
 type Config struct {
-	InMem  bool            `env:"INMEMORY" yaml:"inmemory"`
-	DB     postgresql.Conf `yaml:"db"`
-	HTTP   httpserver.Conf `yaml:"http"`
-	GRPC   grpcserver.Conf `yaml:"grpc"`
-	Logger logger.Conf     `yaml:"logger"`
-	Metric metric.Conf     `yaml:"metrics"`
-	Auth   auth.Conf       `yaml:"auth"`
+	InMem bool `env:"INMEMORY" yaml:"inmemory"`
+	// IgnoreAllDayConflicts lets events overlap all-day events.
+	IgnoreAllDayConflicts bool            `env:"IGNORE_ALL_DAY_CONFLICTS" yaml:"ignore_all_day_conflicts"`
+	DB                    postgresql.Conf `yaml:"db"`
+	HTTP                  httpserver.Conf `yaml:"http"`
+	GRPC                  grpcserver.Conf `yaml:"grpc"`
+	Logger                logger.Conf     `yaml:"logger"`
+	Metric                metric.Conf     `yaml:"metrics"`
+	Auth                  auth.Conf       `yaml:"auth"`
 }
 
 func main() {
@@ -75,14 +78,15 @@ func main() {
 	}
 
 	// init storage
+	storageOpts := []storage.Option{storage.WithIgnoreAllDayConflicts(conf.IgnoreAllDayConflicts)}
 	var storage storage.Storage
 	if conf.InMem {
 		slog.Info("Storage in memory")
-		storage = memory.New()
+		storage = memory.New(storageOpts...)
 	} else {
 		var err error
 		slog.Info("Storage in sql database")
-		storage, err = postgresql.New(ctx, &conf.DB)
+		storage, err = postgresql.New(ctx, &conf.DB, storageOpts...)
 		if err != nil {
 			slog.Error("Failed to init storage: " + err.Error())
 			return
@@ -97,8 +101,8 @@ func main() {
 	}
 
 	auth := auth.New(storage, &conf.Auth)
-	serverHTTP := httpserver.New(&conf.HTTP, storage, auth)
-	serverGRPC, err := grpcserver.New(ctx, &conf.GRPC, storage, auth)
+	serverHTTP := httpserver.New(&conf.HTTP, storage, auth, storageOpts...)
+	serverGRPC, err := grpcserver.New(ctx, &conf.GRPC, storage, auth, storageOpts...)
 	if err != nil {
 		slog.Error("Failed to init gRPC server: " + err.Error())
 		return
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"time"
+	_ "time/tzdata" // time zones of recurrence ids on hosts without tzdata
+
+	"github.com/mrvin/calendar/internal/config"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/replier"
+	"github.com/mrvin/calendar/internal/storage/postgresql"
+)
+
+//nolint:tagliatelle
+type Config struct {
+	DB     postgresql.Conf `yaml:"db"`
+	Logger logger.Conf     `yaml:"logger"`
+	// Maildir is the directory with the replies of the attendees delivered
+	// by the mail server.
+	Maildir string `yaml:"maildir"`
+	// PollPeriod is the period of reading the Maildir in seconds.
+	PollPeriod int `yaml:"poll_period"`
+}
+
+func main() {
+	configFile := flag.String("config", "/etc/calendar/replier.yml", "path to configuration file")
+	once := flag.Bool("once", false, "process the Maildir once and exit")
+	flag.Parse()
+
+	var conf Config
+	if err := config.Parse(*configFile, &conf); err != nil {
+		log.Printf("Parse config: %v", err)
+		return
+	}
+
+	logFile, err := logger.Init(&conf.Logger)
+	if err != nil {
+		log.Printf("Init logger: %v", err)
+		return
+	}
+	slog.Info("Init logger", slog.String("level", conf.Logger.Level))
+	defer func() {
+		if err := logFile.Close(); err != nil {
+			slog.Error("Close log file", slog.String("error", err.Error())) //nolint:gosec
+		}
+	}()
+
+	ctx := context.Background()
+	st, err := postgresql.New(ctx, &conf.DB)
+	if err != nil {
+		slog.Error("Failed to init storage: " + err.Error())
+		return
+	}
+	defer st.Close()
+	slog.Info("Connected to database")
+
+	app := replier.New(st, conf.Maildir)
+	if *once {
+		processed, err := app.ProcessMaildir(ctx)
+		if err != nil {
+			slog.Error("Process maildir", slog.String("error", err.Error()))
+		}
+		slog.Info("Processed replies", slog.Int("messages", processed))
+		return
+	}
+	app.Run(ctx, time.Duration(conf.PollPeriod)*time.Second)
+}
@@ -5,21 +5,33 @@ import (
 	"flag"
 	"log"
 	"log/slog"
+	"os"
+	"strconv"
+	"time"
+	_ "time/tzdata" // time zones of events on hosts without tzdata
 
 	"github.com/mrvin/calendar/internal/config"
 	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/metric"
 	"github.com/mrvin/calendar/internal/queue"
 	"github.com/mrvin/calendar/internal/queue/rabbitmq"
 	"github.com/mrvin/calendar/internal/scheduler/app"
 	"github.com/mrvin/calendar/internal/storage/postgresql"
 )
 
+const serviceName = "Scheduler"
+const ctxTimeout = 2 // in second
+
 //nolint:tagliatelle
 type Config struct {
 	Queue       queue.Conf      `yaml:"queue"`
 	DB          postgresql.Conf `yaml:"db"`
 	Logger      logger.Conf     `yaml:"logger"`
+	Metric      metric.Conf     `yaml:"metrics"`
 	SchedPeriod int             `yaml:"schedule_period"`
+	// RetentionDays are the days the events are kept after they end unless
+	// their user sets otherwise, forever if 0.
+	RetentionDays int `env:"RETENTION_DAYS" yaml:"retention_days"`
 }
 
 func main() {
@@ -46,6 +58,22 @@ func main() {
 	}()
 
 	ctx := context.Background()
+	if conf.Metric.Enable {
+		ctxMetric, cancel := context.WithTimeout(ctx, ctxTimeout*time.Second)
+		defer cancel()
+		mp, err := metric.Init(ctxMetric, &conf.Metric, serviceName)
+		if err != nil {
+			slog.Warn("Failed to init metric: " + err.Error())
+		} else {
+			slog.Info("Init metric")
+			defer func() {
+				if err := mp.Shutdown(ctx); err != nil {
+					slog.Error("Failed to shutdown metric: " + err.Error())
+				}
+			}()
+		}
+	}
+
 	st, err := postgresql.New(ctx, &conf.DB)
 	if err != nil {
 		slog.Error("Failed to init storag: " + err.Error())
@@ -63,8 +91,21 @@ func main() {
 		return
 	}
 	defer qm.Close()
+	var qmInvite rabbitmq.Queue
+	if err := qmInvite.ConnectAndCreate(url, conf.Queue.InvitationName); err != nil {
+		slog.Error("Failed to init invitation queue: " + err.Error())
+		return
+	}
+	defer qmInvite.Close()
 	slog.Info("Connected to queue")
 
-	app := app.New(st, qm, conf.SchedPeriod)
+	// Replicas share the database, one of them dispatches at a time.
+	replica, err := os.Hostname()
+	if err != nil {
+		replica = "scheduler"
+	}
+	replica += "-" + strconv.Itoa(os.Getpid())
+
+	app := app.New(st, &qm, st, &qmInvite, conf.SchedPeriod, st.NewLeader("scheduler"), replica, st, conf.RetentionDays)
 	app.Run(ctx)
 }
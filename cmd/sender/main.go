@@ -7,18 +7,26 @@ import (
 	"log/slog"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // time zones of events on hosts without tzdata
 
 	"github.com/mrvin/calendar/internal/config"
 	"github.com/mrvin/calendar/internal/logger"
 	"github.com/mrvin/calendar/internal/queue"
 	"github.com/mrvin/calendar/internal/queue/rabbitmq"
+	"github.com/mrvin/calendar/internal/sender"
 	"github.com/mrvin/calendar/internal/sender/email"
+	"github.com/mrvin/calendar/internal/sender/webhook"
+	"github.com/mrvin/calendar/internal/storage/postgresql"
 )
 
 type Config struct {
-	Queue  queue.Conf  `yaml:"queue"`
-	Email  email.Conf  `yaml:"email"`
-	Logger logger.Conf `yaml:"logger"`
+	// DB is where the emails of the users are found for the reminders of
+	// version 1 and the delivered reminders are recorded.
+	DB      postgresql.Conf `yaml:"db"`
+	Queue   queue.Conf      `yaml:"queue"`
+	Email   email.Conf      `yaml:"email"`
+	Webhook webhook.Conf    `yaml:"webhook"`
+	Logger  logger.Conf     `yaml:"logger"`
 }
 
 //nolint:cyclop
@@ -46,6 +54,14 @@ func main() {
 		}
 	}()
 
+	st, err := postgresql.New(ctx, &conf.DB)
+	if err != nil {
+		slog.Error("Failed to init storage: " + err.Error())
+		return
+	}
+	defer st.Close()
+	slog.Info("Connected to database")
+
 	var qm rabbitmq.Queue
 
 	url := rabbitmq.QueryBuildAMQP(&conf.Queue)
@@ -63,6 +79,20 @@ func main() {
 		return
 	}
 
+	var qmInvite rabbitmq.Queue
+	if err := qmInvite.ConnectAndCreate(url, conf.Queue.InvitationName); err != nil {
+		slog.Error("New invitation queue connection: " + err.Error())
+		return
+	}
+	defer qmInvite.Close()
+
+	chInvite, err := qmInvite.GetConsumeChan()
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	app := sender.New(st, &conf.Email, &conf.Webhook)
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT /*(Control-C)*/, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 	for {
@@ -71,25 +101,19 @@ func main() {
 			if !ok {
 				return
 			}
-			alertEvent, err := queue.DecodeAlertEvent(msg.Body)
-			if err != nil {
+			if err := app.SendAlert(ctx, msg.ContentType, msg.Body); err != nil {
+				slog.Error(err.Error())
+			}
+		case msg, ok := <-chInvite:
+			if !ok {
+				return
+			}
+			if err := app.SendInvitation(msg.ContentType, msg.Body); err != nil {
 				slog.Error(err.Error())
-				continue
 			}
-			slog.Info("Take alert message from queue", slog.Int64("Event id", alertEvent.EventID))
-			emailMsg := email.Message{To: alertEvent.UserEmail, Subject: alertEvent.Title, Description: alertEvent.Description}
-			sendEvent(&conf.Email, &emailMsg)
 		case <-ctx.Done():
 			slog.Info("Stop sender")
 			return
 		}
 	}
 }
-
-func sendEvent(conf *email.Conf, msg *email.Message) {
-	if err := email.Alert(conf, msg); err != nil {
-		slog.Error(err.Error())
-		return
-	}
-	slog.Info(msg.Subject + "'%s' event notification sent to '%s'\n" + msg.To)
-}
@@ -0,0 +1,185 @@
+// Package booking lets anyone book the slots of a user offered on the public
+// page of a booking link.
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+const (
+	// MaxDuration bounds the length of the booked events.
+	MaxDuration = 24 * time.Hour
+	// MaxBuffer bounds the free time kept around the booked events.
+	MaxBuffer = 24 * time.Hour
+	// MaxLookahead bounds how far ahead the slots of a link may start.
+	MaxLookahead = freebusy.MaxSlotRange
+	// MaxSlots is the number of slots listed at most.
+	MaxSlots = 500
+)
+
+var (
+	ErrInvalidLink = errors.New("invalid booking link")
+	// ErrSlotNotAvailable hides the events of the owner of the link which
+	// make the slot busy.
+	ErrSlotNotAvailable = errors.New("slot is not available")
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,63}$`)
+
+type Booker interface {
+	freebusy.Source
+	Book(ctx context.Context, event *storage.Event, buffer time.Duration) (uuid.UUID, error)
+}
+
+// Customer is the person who books a slot, invited to the booked event.
+type Customer struct {
+	Name  string
+	Email string
+	Note  string
+}
+
+// CheckLink returns ErrInvalidLink if the settings of the link do not allow
+// to book any slot.
+func CheckLink(link *storage.BookingLink) error {
+	switch {
+	case !slugRegexp.MatchString(link.Slug):
+		return fmt.Errorf("%w: slug must be 3 to 64 lowercase letters, digits or dashes", ErrInvalidLink)
+	case link.Duration <= 0 || link.Duration > MaxDuration:
+		return fmt.Errorf("%w: duration must be positive and at most %s", ErrInvalidLink, MaxDuration)
+	case link.Buffer < 0 || link.Buffer > MaxBuffer:
+		return fmt.Errorf("%w: buffer must not be negative or more than %s", ErrInvalidLink, MaxBuffer)
+	case link.Lookahead <= 0 || link.Lookahead > MaxLookahead:
+		return fmt.Errorf("%w: lookahead must be positive and at most %s", ErrInvalidLink, MaxLookahead)
+	}
+	wh, err := workingHours(link)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLink, err)
+	}
+	if wh.Start >= wh.End || wh.End-wh.Start < link.Duration {
+		return fmt.Errorf("%w: working hours must start before they end and fit the duration", ErrInvalidLink)
+	}
+
+	return nil
+}
+
+// Slots returns the start times of up to MaxSlots open slots of the link
+// within [start, end) and the lookahead of the link from now.
+func Slots(
+	ctx context.Context,
+	src freebusy.Source,
+	opts storage.Options,
+	link *storage.BookingLink,
+	start, end, now time.Time,
+) ([]time.Time, error) {
+	return slots(ctx, src, opts, link, start, end, now, MaxSlots)
+}
+
+// Book creates the event of the slot of the link starting at start for the
+// customer, ErrSlotNotAvailable if the slot is not open. The storage makes
+// sure that two customers cannot book overlapping slots.
+func Book(
+	ctx context.Context,
+	booker Booker,
+	opts storage.Options,
+	link *storage.BookingLink,
+	start time.Time,
+	customer *Customer,
+	now time.Time,
+) (*storage.Event, error) {
+	end := start.Add(link.Duration)
+	open, err := slots(ctx, booker, opts, link, start, end, now, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) == 0 || !open[0].Equal(start) {
+		return nil, ErrSlotNotAvailable
+	}
+
+	//nolint:exhaustruct
+	event := storage.Event{
+		Title:       fmt.Sprintf("%s: %s", link.Title, customer.Name),
+		Description: customer.Note,
+		StartTime:   start,
+		EndTime:     end,
+		Username:    link.Username,
+		Attendees:   []storage.Attendee{{Username: "", Email: customer.Email, Status: storage.PartStatNeedsAction, Availability: ""}},
+	}
+	if _, err := booker.Book(ctx, &event, link.Buffer); err != nil {
+		if errors.Is(err, storage.ErrDateBusy) {
+			return nil, ErrSlotNotAvailable
+		}
+		return nil, fmt.Errorf("book: %w", err)
+	}
+
+	return &event, nil
+}
+
+func slots(
+	ctx context.Context,
+	src freebusy.Source,
+	opts storage.Options,
+	link *storage.BookingLink,
+	start, end, now time.Time,
+	limit int,
+) ([]time.Time, error) {
+	if start.Before(now) {
+		start = now
+	}
+	if lookahead := now.Add(link.Lookahead); end.After(lookahead) {
+		end = lookahead
+	}
+	if !start.Before(end) {
+		return []time.Time{}, nil
+	}
+	wh, err := workingHours(link)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLink, err)
+	}
+
+	query := freebusy.SlotQuery{
+		Usernames:    []string{link.Username},
+		Duration:     link.Duration,
+		Start:        start,
+		End:          end,
+		WorkingHours: wh,
+		Buffer:       link.Buffer,
+		Limit:        limit,
+	}
+	result, err := freebusy.FindSlots(ctx, src, opts, link.Username, &query)
+	if err != nil {
+		return nil, fmt.Errorf("find slots: %w", err)
+	}
+
+	return result, nil
+}
+
+func workingHours(link *storage.BookingLink) (*freebusy.WorkingHours, error) {
+	start, err := freebusy.ParseClock(link.DayStart)
+	if err != nil {
+		return nil, fmt.Errorf("day start: %w", err)
+	}
+	end, err := freebusy.ParseClock(link.DayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("day end: %w", err)
+	}
+	weekdays, err := freebusy.ParseWeekdays(link.Weekdays)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	wh := freebusy.WorkingHours{Start: start, End: end, Weekdays: weekdays, Location: nil}
+	if link.TimeZone != "" {
+		if wh.Location, err = storage.LoadLocation(link.TimeZone); err != nil {
+			return nil, fmt.Errorf("time zone: %w", err)
+		}
+	}
+
+	return &wh, nil
+}
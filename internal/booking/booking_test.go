@@ -0,0 +1,173 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestBook(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	opts := storage.NewOptions()
+	// Monday.
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	at := func(days, hours, minutes int) time.Time {
+		return day.AddDate(0, 0, days).Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	}
+	now := at(0, 8, 0)
+
+	// Bookings are rejected on conflict even if the owner allows conflicts.
+	user := storage.User{Name: "alice", ConflictPolicy: storage.ConflictAllow}
+	if err := st.CreateUser(ctx, &user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	event := storage.Event{Title: "Review", Username: "alice", StartTime: at(0, 10, 0), EndTime: at(0, 10, 30)}
+	if _, err := st.CreateEvent(ctx, &event); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	link := storage.BookingLink{
+		Slug:      "alice-intro",
+		Title:     "Intro call",
+		Duration:  30 * time.Minute,
+		Buffer:    15 * time.Minute,
+		DayStart:  "09:00",
+		DayEnd:    "12:00",
+		Weekdays:  []string{"MO", "TU", "WE", "TH", "FR"},
+		TimeZone:  "UTC",
+		Lookahead: 7 * 24 * time.Hour,
+		Username:  "alice",
+	}
+	if err := CheckLink(&link); err != nil {
+		t.Fatalf("CheckLink: %v", err)
+	}
+
+	slots, err := Slots(ctx, st, opts, &link, day, at(0, 12, 0), now)
+	if err != nil {
+		t.Fatalf("Slots: %v", err)
+	}
+	want := []time.Time{at(0, 9, 0), at(0, 9, 15), at(0, 10, 45), at(0, 11, 0), at(0, 11, 15), at(0, 11, 30)}
+	if !slices.Equal(slots, want) {
+		t.Errorf("Slots: expected %v, got %v", want, slots)
+	}
+
+	customer := Customer{Name: "Carol", Email: "carol@example.com", Note: ""}
+	booked, err := Book(ctx, st, opts, &link, at(0, 9, 0), &customer, now)
+	if err != nil {
+		t.Fatalf("Book: %v", err)
+	}
+	if booked.Username != "alice" || booked.Title != "Intro call: Carol" || len(booked.Attendees) != 1 {
+		t.Errorf("Book: unexpected event %+v", booked)
+	}
+
+	for name, start := range map[string]time.Time{
+		"within buffer":     at(0, 9, 15),
+		"not aligned":       at(0, 10, 50),
+		"past":              at(-1, 9, 0),
+		"after lookahead":   at(7, 9, 0),
+		"off weekday":       at(5, 9, 0),
+		"after working day": at(0, 11, 45),
+	} {
+		if _, err := Book(ctx, st, opts, &link, start, &customer, now); !errors.Is(err, ErrSlotNotAvailable) {
+			t.Errorf("Book %s: expected %v, got %v", name, ErrSlotNotAvailable, err)
+		}
+	}
+
+	// Concurrent customers cannot take the same slot.
+	var wg sync.WaitGroup
+	results := make([]error, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, results[i] = Book(ctx, st, opts, &link, at(1, 9, 0), &customer, now)
+		}()
+	}
+	wg.Wait()
+	var bookings int
+	for _, err := range results {
+		switch {
+		case err == nil:
+			bookings++
+		case !errors.Is(err, ErrSlotNotAvailable):
+			t.Errorf("Book concurrently: %v", err)
+		}
+	}
+	if bookings != 1 {
+		t.Errorf("Book concurrently: expected 1 booking, got %d", bookings)
+	}
+}
+
+func TestCheckLink(t *testing.T) {
+	valid := storage.BookingLink{
+		Slug:      "intro",
+		Title:     "Intro call",
+		Duration:  30 * time.Minute,
+		DayStart:  "09:00",
+		DayEnd:    "17:00",
+		Lookahead: 24 * time.Hour,
+	}
+	tests := []struct {
+		name   string
+		change func(link *storage.BookingLink)
+	}{
+		{"slug", func(link *storage.BookingLink) { link.Slug = "Intro Call" }},
+		{"duration", func(link *storage.BookingLink) { link.Duration = 0 }},
+		{"buffer", func(link *storage.BookingLink) { link.Buffer = -time.Minute }},
+		{"lookahead", func(link *storage.BookingLink) { link.Lookahead = MaxLookahead + time.Hour }},
+		{"working hours", func(link *storage.BookingLink) { link.DayEnd = "08:00" }},
+		{"short working hours", func(link *storage.BookingLink) { link.DayEnd = "09:15" }},
+		{"weekday", func(link *storage.BookingLink) { link.Weekdays = []string{"XX"} }},
+		{"time zone", func(link *storage.BookingLink) { link.TimeZone = "Mars/Olympus" }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			link := valid
+			test.change(&link)
+			if err := CheckLink(&link); !errors.Is(err, ErrInvalidLink) {
+				t.Errorf("CheckLink: expected %v, got %v", ErrInvalidLink, err)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	limit := NewRateLimit()
+	now := time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC)
+
+	carol := Customer{Name: "Carol", Email: "carol@example.com", Note: ""}
+	for i := range MaxEmailBookings {
+		if err := limit.Allow(fmt.Sprintf("192.0.2.%d", i), &carol, now); err != nil {
+			t.Fatalf("booking %d for the email: %v", i, err)
+		}
+	}
+	// Emails are counted whatever their case.
+	carol.Email = "Carol@Example.com"
+	if err := limit.Allow("198.51.100.1", &carol, now); !errors.Is(err, ErrTooManyBookings) {
+		t.Errorf("booking over the email limit: expected %v, got %v", ErrTooManyBookings, err)
+	}
+	if err := limit.Allow("198.51.100.1", &carol, now.Add(EmailWindow)); err != nil {
+		t.Errorf("booking for the email in the next window: %v", err)
+	}
+
+	for i := range MaxClientBookings {
+		customer := Customer{Name: "Dave", Email: fmt.Sprintf("dave%d@example.com", i), Note: ""}
+		if err := limit.Allow("203.0.113.1", &customer, now); err != nil {
+			t.Fatalf("booking %d from the client: %v", i, err)
+		}
+	}
+	erin := Customer{Name: "Erin", Email: "erin@example.com", Note: ""}
+	if err := limit.Allow("203.0.113.1", &erin, now); !errors.Is(err, ErrTooManyBookings) {
+		t.Errorf("booking over the client limit: expected %v, got %v", ErrTooManyBookings, err)
+	}
+	if err := limit.Allow("203.0.113.1", &erin, now.Add(ClientWindow)); err != nil {
+		t.Errorf("booking from the client in the next window: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package booking
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxClientBookings is the number of bookings a client address may make
+	// per ClientWindow.
+	MaxClientBookings = 10
+	ClientWindow      = time.Hour
+	// MaxEmailBookings is the number of bookings inviting the same email per
+	// EmailWindow, so that the server cannot be used to send mail to anyone.
+	MaxEmailBookings = 3
+	EmailWindow      = 24 * time.Hour
+)
+
+var ErrTooManyBookings = errors.New("too many bookings, try again later")
+
+// RateLimit bounds the bookings made from a client address and those
+// inviting the same customer email. The attempts are counted whether the
+// slot is booked or not. The counts are kept in memory: each instance of the
+// server limits the bookings it receives.
+type RateLimit struct {
+	clients *window
+	emails  *window
+}
+
+func NewRateLimit() *RateLimit {
+	return &RateLimit{
+		clients: newWindow(MaxClientBookings, ClientWindow),
+		emails:  newWindow(MaxEmailBookings, EmailWindow),
+	}
+}
+
+// Allow counts the booking from the client address for the customer and
+// returns ErrTooManyBookings if one of the limits is exceeded.
+func (l *RateLimit) Allow(client string, customer *Customer, now time.Time) error {
+	if !l.clients.allow(client, now) || !l.emails.allow(strings.ToLower(customer.Email), now) {
+		return ErrTooManyBookings
+	}
+
+	return nil
+}
+
+// window counts the events by key in fixed windows of the period.
+type window struct {
+	mu     sync.Mutex
+	limit  int
+	period time.Duration
+	counts map[string]count
+	// pruned is when the expired windows were last forgotten.
+	pruned time.Time
+}
+
+type count struct {
+	start time.Time
+	n     int
+}
+
+func newWindow(limit int, period time.Duration) *window {
+	return &window{mu: sync.Mutex{}, limit: limit, period: period, counts: make(map[string]count), pruned: time.Time{}}
+}
+
+// allow counts the event with the key at now and reports whether the limit
+// of the window is not exceeded.
+func (w *window) allow(key string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !now.Before(w.pruned.Add(w.period)) {
+		w.prune(now)
+	}
+	c, ok := w.counts[key]
+	if !ok || !now.Before(c.start.Add(w.period)) {
+		c = count{start: now, n: 0}
+	}
+	if c.n >= w.limit {
+		return false
+	}
+	c.n++
+	w.counts[key] = c
+
+	return true
+}
+
+// prune forgets the expired windows. Must be called with mu held.
+func (w *window) prune(now time.Time) {
+	for key, c := range w.counts {
+		if !now.Before(c.start.Add(w.period)) {
+			delete(w.counts, key)
+		}
+	}
+	w.pruned = now
+}
@@ -101,6 +101,30 @@ func (a *Auth) Authorized(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(handler)
 }
 
+// BasicAuthorized is middleware for clients which cannot obtain a token,
+// such as CalDAV clients. The credentials are checked against the user store.
+func (a *Auth) BasicAuthorized(next http.Handler) http.Handler {
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if !ok {
+			res.Header().Set("WWW-Authenticate", `Basic realm="calendar", charset="UTF-8"`)
+			http.Error(res, "header does not contain basic credentials", http.StatusUnauthorized)
+			return
+		}
+		if _, err := a.validCredentials(req.Context(), username, password); err != nil {
+			res.Header().Set("WWW-Authenticate", `Basic realm="calendar", charset="UTF-8"`)
+			http.Error(res, ErrInvalidCredentials.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := logger.WithUsername(req.Context(), username)
+
+		next.ServeHTTP(res, req.WithContext(ctx)) // Pass request to next handler
+	}
+
+	return http.HandlerFunc(handler)
+}
+
 func GetUsernameFromCtx(ctx context.Context) (string, error) {
 	if ctx == nil {
 		return "", errors.New("ctx is nil")
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const maxAttendees = 100
+
+func (s *Server) RespondToEvent(ctx context.Context, req *api.ReqRespondToEvent) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+	partStat, err := storage.ParsePartStat(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	if err := s.storage.UpdateAttendeeStatus(ctx, username, id, partStat); err != nil {
+		err := fmt.Errorf("updating attendee status in storage: %w", err)
+		if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrAttendeeNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toAttendees(pbAttendees []*api.Attendee) ([]storage.Attendee, error) {
+	if len(pbAttendees) > maxAttendees {
+		return nil, fmt.Errorf("more than %d attendees", maxAttendees)
+	}
+	if len(pbAttendees) == 0 {
+		return nil, nil
+	}
+	attendees := make([]storage.Attendee, len(pbAttendees))
+	for i, pbAttendee := range pbAttendees {
+		switch {
+		case pbAttendee.GetUsername() != "" && pbAttendee.GetEmail() != "":
+			return nil, errors.New("attendee must be given either by username or by email")
+		case pbAttendee.GetUsername() != "":
+		case pbAttendee.GetEmail() != "":
+			if _, err := mail.ParseAddress(pbAttendee.GetEmail()); err != nil {
+				return nil, fmt.Errorf("attendee email: %w", err)
+			}
+		default:
+			return nil, errors.New("attendee without username and email")
+		}
+		attendees[i] = storage.Attendee{Username: pbAttendee.GetUsername(), Email: pbAttendee.GetEmail(), Status: "", Availability: ""}
+	}
+
+	return attendees, nil
+}
+
+func toPbAttendees(attendees []storage.Attendee) []*api.Attendee {
+	if attendees == nil {
+		return nil
+	}
+	pbAttendees := make([]*api.Attendee, len(attendees))
+	for i, attendee := range attendees {
+		pbAttendees[i] = &api.Attendee{
+			Username:     attendee.Username,
+			Email:        attendee.Email,
+			Status:       string(attendee.Status),
+			Availability: string(attendee.Availability),
+		}
+	}
+
+	return pbAttendees
+}
@@ -0,0 +1,302 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/booking"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxBookingTitleLen = 128
+	maxCustomerNameLen = 128
+	maxCustomerNoteLen = 1000
+)
+
+func (s *Server) CreateBookingLink(ctx context.Context, req *api.ReqCreateBookingLink) (*api.ResCreateBookingLink, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	link, err := toBookingLink(req.GetSlug(), req.GetTitle(), req.GetDuration(), req.GetBuffer(), req.GetWorkingHours(), req.GetLookahead())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	link.Username = username
+
+	id, err := s.storage.CreateBookingLink(ctx, link)
+	if err != nil {
+		err = fmt.Errorf("saving booking link to storage: %w", err)
+		if errors.Is(err, storage.ErrBookingLinkExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &api.ResCreateBookingLink{Id: id.String()}, nil
+}
+
+func (s *Server) GetBookingLink(ctx context.Context, req *api.ReqGetBookingLink) (*api.ResBookingLink, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	link, err := s.storage.GetBookingLink(ctx, username, id)
+	if err != nil {
+		err := fmt.Errorf("getting booking link from storage: %w", err)
+		if errors.Is(err, storage.ErrBookingLinkNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return toResBookingLink(link), nil
+}
+
+func (s *Server) ListBookingLinks(ctx context.Context, _ *emptypb.Empty) (*api.ResListBookingLinks, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	links, err := s.storage.ListBookingLinks(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting list booking links from storage: %v", err)
+	}
+
+	pbLinks := make([]*api.ResBookingLink, len(links))
+	for i := range links {
+		pbLinks[i] = toResBookingLink(&links[i])
+	}
+
+	return &api.ResListBookingLinks{BookingLinks: pbLinks}, nil
+}
+
+func (s *Server) UpdateBookingLink(ctx context.Context, req *api.ReqUpdateBookingLink) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	link, err := toBookingLink(req.GetSlug(), req.GetTitle(), req.GetDuration(), req.GetBuffer(), req.GetWorkingHours(), req.GetLookahead())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	if err := s.storage.UpdateBookingLink(ctx, username, id, link); err != nil {
+		err = fmt.Errorf("updating booking link in storage: %w", err)
+		if errors.Is(err, storage.ErrBookingLinkNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		if errors.Is(err, storage.ErrBookingLinkExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteBookingLink(ctx context.Context, req *api.ReqDeleteBookingLink) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	if err := s.storage.DeleteBookingLink(ctx, username, id); err != nil {
+		err = fmt.Errorf("deleting booking link from storage: %w", err)
+		if errors.Is(err, storage.ErrBookingLinkNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetBookingPage lists the open slots of the booking link without an account.
+func (s *Server) GetBookingPage(ctx context.Context, req *api.ReqGetBookingPage) (*api.ResBookingPage, error) {
+	link, err := s.getBookingLinkBySlug(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+	ctx = logger.WithUsername(ctx, link.Username)
+
+	now := time.Now()
+	start, end := now, now.Add(link.Lookahead)
+	if req.GetStartTime() != nil {
+		start = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		end = req.GetEndTime().AsTime()
+	}
+	slots, err := booking.Slots(ctx, s.storage, s.opts, link, start, end, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find slots: %v", err)
+	}
+
+	pbSlots := make([]*api.Interval, len(slots))
+	for i, slot := range slots {
+		pbSlots[i] = &api.Interval{StartTime: timestamppb.New(slot), EndTime: timestamppb.New(slot.Add(link.Duration))}
+	}
+
+	return &api.ResBookingPage{
+		Title:    link.Title,
+		Duration: durationpb.New(link.Duration),
+		Slots:    pbSlots,
+	}, nil
+}
+
+// Book books the slot of the booking link for the customer without an
+// account.
+func (s *Server) Book(ctx context.Context, req *api.ReqBook) (*api.Interval, error) {
+	link, err := s.getBookingLinkBySlug(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+	ctx = logger.WithUsername(ctx, link.Username)
+
+	customer, err := toCustomer(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	now := time.Now()
+	var client string
+	if p, ok := peer.FromContext(ctx); ok {
+		client, _, err = net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			client = p.Addr.String()
+		}
+	}
+	if err := s.bookings.Allow(client, customer, now); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Errorf("book slot: %w", err).Error()) //nolint:wrapcheck
+	}
+	event, err := booking.Book(ctx, s.storage, s.opts, link, req.GetStartTime().AsTime(), customer, now)
+	if err != nil {
+		err = fmt.Errorf("book slot: %w", err)
+		if errors.Is(err, booking.ErrSlotNotAvailable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &api.Interval{StartTime: timestamppb.New(event.StartTime), EndTime: timestamppb.New(event.EndTime)}, nil
+}
+
+func (s *Server) getBookingLinkBySlug(ctx context.Context, slug string) (*storage.BookingLink, error) {
+	link, err := s.storage.GetBookingLinkBySlug(ctx, slug)
+	if err != nil {
+		err = fmt.Errorf("getting booking link from storage: %w", err)
+		if errors.Is(err, storage.ErrBookingLinkNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return link, nil
+}
+
+func toBookingLink(
+	slug, title string,
+	duration, buffer *durationpb.Duration,
+	pbWorkingHours *api.WorkingHours,
+	lookahead *durationpb.Duration,
+) (*storage.BookingLink, error) {
+	if title == "" || utf8.RuneCountInString(title) > maxBookingTitleLen {
+		return nil, fmt.Errorf("title must be from 1 to %d characters", maxBookingTitleLen)
+	}
+	if pbWorkingHours == nil {
+		return nil, errors.New("working hours are required")
+	}
+	weekdays := make([]string, len(pbWorkingHours.GetWeekdays()))
+	for i, day := range pbWorkingHours.GetWeekdays() {
+		weekdays[i] = strings.ToUpper(day)
+	}
+
+	//nolint:exhaustruct
+	link := storage.BookingLink{
+		Slug:      slug,
+		Title:     title,
+		Duration:  duration.AsDuration(),
+		Buffer:    buffer.AsDuration(),
+		DayStart:  pbWorkingHours.GetStart(),
+		DayEnd:    pbWorkingHours.GetEnd(),
+		Weekdays:  weekdays,
+		TimeZone:  pbWorkingHours.GetTimeZone(),
+		Lookahead: lookahead.AsDuration(),
+	}
+	if err := booking.CheckLink(&link); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &link, nil
+}
+
+func toResBookingLink(link *storage.BookingLink) *api.ResBookingLink {
+	return &api.ResBookingLink{
+		Id:       link.ID.String(),
+		Slug:     link.Slug,
+		Title:    link.Title,
+		Duration: durationpb.New(link.Duration),
+		Buffer:   durationpb.New(link.Buffer),
+		WorkingHours: &api.WorkingHours{
+			Start:    link.DayStart,
+			End:      link.DayEnd,
+			Weekdays: link.Weekdays,
+			TimeZone: link.TimeZone,
+		},
+		Lookahead: durationpb.New(link.Lookahead),
+		CreatedAt: timestamppb.New(link.CreatedAt),
+	}
+}
+
+func toCustomer(req *api.ReqBook) (*booking.Customer, error) {
+	if req.GetStartTime() == nil {
+		return nil, errors.New("start time is required")
+	}
+	if req.GetName() == "" || utf8.RuneCountInString(req.GetName()) > maxCustomerNameLen {
+		return nil, fmt.Errorf("name must be from 1 to %d characters", maxCustomerNameLen)
+	}
+	if _, err := mail.ParseAddress(req.GetEmail()); err != nil {
+		return nil, fmt.Errorf("email: %w", err)
+	}
+	if utf8.RuneCountInString(req.GetNote()) > maxCustomerNoteLen {
+		return nil, fmt.Errorf("note must be at most %d characters", maxCustomerNoteLen)
+	}
+
+	return &booking.Customer{Name: req.GetName(), Email: req.GetEmail(), Note: req.GetNote()}, nil
+}
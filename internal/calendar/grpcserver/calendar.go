@@ -0,0 +1,179 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxCalendarNameLen = 64
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+func (s *Server) CreateCalendar(ctx context.Context, req *api.ReqCreateCalendar) (*api.ResCreateCalendar, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	calendar, err := toCalendar(req.GetName(), req.GetColor(), req.GetNotifyBefore())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	calendar.Username = username
+
+	id, err := s.storage.CreateCalendar(ctx, calendar)
+	if err != nil {
+		err = fmt.Errorf("saving calendar to storage: %w", err)
+		if errors.Is(err, storage.ErrCalendarExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &api.ResCreateCalendar{Id: id.String()}, nil
+}
+
+func (s *Server) GetCalendar(ctx context.Context, req *api.ReqGetCalendar) (*api.ResCalendar, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	calendar, err := s.storage.GetCalendar(ctx, username, id)
+	if err != nil {
+		err := fmt.Errorf("getting calendar from storage: %w", err)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return toResCalendar(calendar), nil
+}
+
+func (s *Server) ListCalendars(ctx context.Context, _ *emptypb.Empty) (*api.ResListCalendars, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	calendars, err := s.storage.ListCalendars(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting list calendars from storage: %v", err)
+	}
+
+	pbCalendars := make([]*api.ResCalendar, len(calendars))
+	for i := range calendars {
+		pbCalendars[i] = toResCalendar(&calendars[i])
+	}
+
+	return &api.ResListCalendars{Calendars: pbCalendars}, nil
+}
+
+func (s *Server) UpdateCalendar(ctx context.Context, req *api.ReqUpdateCalendar) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	calendar, err := toCalendar(req.GetName(), req.GetColor(), req.GetNotifyBefore())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	if err := s.storage.UpdateCalendar(ctx, username, id, calendar); err != nil {
+		err = fmt.Errorf("updating calendar in storage: %w", err)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		if errors.Is(err, storage.ErrCalendarExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteCalendar(ctx context.Context, req *api.ReqDeleteCalendar) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	if err := s.storage.DeleteCalendar(ctx, username, id); err != nil {
+		err := fmt.Errorf("deleting calendar from storage: %w", err)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// toCalendar validates the calendar as the HTTP API does.
+func toCalendar(name, color string, pbNotifyBefore *durationpb.Duration) (*storage.Calendar, error) {
+	if name == "" || utf8.RuneCountInString(name) > maxCalendarNameLen {
+		return nil, fmt.Errorf("invalid name: %q", name)
+	}
+	if color != "" && !hexColor.MatchString(color) {
+		return nil, fmt.Errorf("invalid color: %q", color)
+	}
+	var notifyBefore *time.Duration
+	if pbNotifyBefore != nil {
+		d := pbNotifyBefore.AsDuration()
+		notifyBefore = &d
+	}
+
+	//nolint:exhaustruct
+	return &storage.Calendar{
+		Name:         name,
+		Color:        color,
+		NotifyBefore: notifyBefore,
+	}, nil
+}
+
+func toResCalendar(calendar *storage.Calendar) *api.ResCalendar {
+	var notifyBefore *durationpb.Duration
+	if calendar.NotifyBefore != nil {
+		notifyBefore = durationpb.New(*calendar.NotifyBefore)
+	}
+
+	return &api.ResCalendar{
+		Id:           calendar.ID.String(),
+		Name:         calendar.Name,
+		Color:        calendar.Color,
+		NotifyBefore: notifyBefore,
+		CreatedAt:    timestamppb.New(calendar.CreatedAt),
+	}
+}
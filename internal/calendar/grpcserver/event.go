@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/sharing"
 	"github.com/mrvin/calendar/internal/storage"
 	"github.com/mrvin/calendar/pkg/api"
+	"github.com/mrvin/calendar/pkg/rrule"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -22,27 +25,83 @@ func (s *Server) CreateEvent(ctx context.Context, req *api.ReqCreateEvent) (*api
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
 	}
-	notifyBefore := req.GetNotifyBefore().AsDuration()
+	rule, err := parseRRule(req.GetRrule())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse rrule: %v", err)
+	}
+	startTime, endTime, err := eventTimes(req.GetAllDay(), req.GetStartTime(), req.GetEndTime(), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	if _, err := storage.LoadLocation(req.GetTimeZone()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	transparency, err := parseTransparency(req.GetTransparency())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	attendees, err := toAttendees(req.GetAttendees())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	calendarID, err := parseCalendarID(req.GetCalendarId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	resources, err := parseResources(req.GetResources())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	owner := username
+	if req.GetOwner() != "" {
+		if err := sharing.Check(ctx, s.storage, username, req.GetOwner(), storage.RoleEditor); err != nil {
+			return nil, accessError(err)
+		}
+		owner = req.GetOwner()
+	}
+	// The events of a calendar get its default reminder unless some are given.
+	var reminders []storage.Reminder
+	if req.GetReminders() != nil {
+		if reminders, err = toReminders(req.GetReminders().GetReminders()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+	}
 	//nolint:exhaustruct
 	event := storage.Event{
 		Title:        req.GetTitle(),
 		Description:  req.GetDescription(),
-		StartTime:    req.GetStartTime().AsTime(),
-		EndTime:      req.GetEndTime().AsTime(),
-		NotifyBefore: &notifyBefore,
-		Username:     username,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		AllDay:       req.GetAllDay(),
+		TimeZone:     req.GetTimeZone(),
+		Transparency: transparency,
+		Reminders:    reminders,
+		RRule:        rule,
+		Username:     owner,
+		Attendees:    attendees,
+		CalendarID:   calendarID,
+		Resources:    resources,
 	}
 
 	id, err := s.storage.CreateEvent(ctx, &event)
 	if err != nil {
 		err = fmt.Errorf("saving event to storage: %w", err)
-		if errors.Is(err, storage.ErrDateBusy) {
+		if errors.Is(err, storage.ErrDateBusy) || errors.Is(err, storage.ErrResourceBusy) {
 			return nil, status.Error(codes.Aborted, err.Error()) //nolint:wrapcheck
 		}
+		if errors.Is(err, storage.ErrUserNotFound) || errors.Is(err, storage.ErrCalendarNotFound) ||
+			errors.Is(err, storage.ErrResourceNotFound) {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
 		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
 	}
 
-	return &api.ResCreateEvent{Id: id.String()}, nil
+	conflicts := make([]string, len(event.Conflicts))
+	for i, conflictID := range event.Conflicts {
+		conflicts[i] = conflictID.String()
+	}
+
+	return &api.ResCreateEvent{Id: id.String(), Conflicts: conflicts}, nil
 }
 
 func (s *Server) GetEvent(ctx context.Context, req *api.ReqGetEvent) (*api.ResEvent, error) {
@@ -56,7 +115,11 @@ func (s *Server) GetEvent(ctx context.Context, req *api.ReqGetEvent) (*api.ResEv
 		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
 	}
 
-	event, err := s.storage.GetEvent(ctx, username, id)
+	owner, err := sharing.EventOwner(ctx, s.storage, username, id, storage.RoleViewer)
+	if err != nil {
+		return nil, accessError(err)
+	}
+	event, err := s.storage.GetEvent(ctx, owner, id)
 	if err != nil {
 		err := fmt.Errorf("getting event from storage: %w", err)
 		if errors.Is(err, storage.ErrEventNotFound) {
@@ -65,14 +128,7 @@ func (s *Server) GetEvent(ctx context.Context, req *api.ReqGetEvent) (*api.ResEv
 		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
 	}
 
-	return &api.ResEvent{
-		Id:           event.ID.String(),
-		Title:        event.Title,
-		Description:  event.Description,
-		StartTime:    timestamppb.New(event.StartTime),
-		EndTime:      timestamppb.New(event.EndTime),
-		NotifyBefore: durationpb.New(*event.NotifyBefore),
-	}, nil
+	return toResEvent(event), nil
 }
 
 func (s *Server) ListEvents(ctx context.Context, req *api.ReqListEvents) (*api.ResListEvents, error) {
@@ -82,22 +138,40 @@ func (s *Server) ListEvents(ctx context.Context, req *api.ReqListEvents) (*api.R
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
 	}
+	start, end := req.GetStartTime().AsTime(), req.GetEndTime().AsTime()
+	if start.After(end) {
+		return nil, status.Error(codes.InvalidArgument, "start_time must be before or equal to end_time")
+	}
+	if end.Sub(start) > storage.MaxListRange {
+		return nil, status.Errorf(codes.InvalidArgument, "time range longer than %s", storage.MaxListRange)
+	}
 
-	events, err := s.storage.ListEvents(ctx, username, req.GetStartTime().AsTime(), req.GetEndTime().AsTime())
+	owner := username
+	if req.GetOwner() != "" {
+		if err := sharing.Check(ctx, s.storage, username, req.GetOwner(), storage.RoleViewer); err != nil {
+			return nil, accessError(err)
+		}
+		owner = req.GetOwner()
+	}
+	var events []storage.Event
+	if len(req.GetCalendarIds()) == 0 {
+		events, err = s.storage.ListEvents(ctx, owner, start, end)
+	} else {
+		calendarIDs := make([]uuid.UUID, len(req.GetCalendarIds()))
+		for i, strID := range req.GetCalendarIds() {
+			if calendarIDs[i], err = uuid.Parse(strID); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "parse calendar_id: %v", err)
+			}
+		}
+		events, err = s.storage.ListCalendarEvents(ctx, owner, calendarIDs, start, end)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "getting list events from storage: %v", err)
 	}
 
 	pbEvents := make([]*api.ResEvent, len(events))
-	for i, event := range events {
-		pbEvents[i] = &api.ResEvent{
-			Id:           event.ID.String(),
-			Title:        event.Title,
-			Description:  event.Description,
-			StartTime:    timestamppb.New(event.StartTime),
-			EndTime:      timestamppb.New(event.EndTime),
-			NotifyBefore: durationpb.New(*event.NotifyBefore),
-		}
+	for i := range events {
+		pbEvents[i] = toResEvent(&events[i])
 	}
 
 	return &api.ResListEvents{Events: pbEvents}, nil
@@ -115,24 +189,88 @@ func (s *Server) UpdateEvent(ctx context.Context, req *api.ReqUpdateEvent) (*emp
 		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
 	}
 
-	notifyBefore := req.GetNotifyBefore().AsDuration()
+	scope, err := parseScope(req.GetScope(), req.GetRecurrenceId() != nil)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	rule, err := parseRRule(req.GetRrule())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse rrule: %v", err)
+	}
+	startTime, endTime, err := eventTimes(req.GetAllDay(), req.GetStartTime(), req.GetEndTime(), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	if _, err := storage.LoadLocation(req.GetTimeZone()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	transparency, err := parseTransparency(req.GetTransparency())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	var attendees []storage.Attendee
+	if req.GetAttendees() != nil {
+		if attendees, err = toAttendees(req.GetAttendees().GetAttendees()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+		if attendees == nil {
+			attendees = []storage.Attendee{}
+		}
+	}
+	calendarID, err := parseCalendarID(req.GetCalendarId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	var resources []uuid.UUID
+	if req.GetResources() != nil {
+		if resources, err = parseResources(req.GetResources().GetIds()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+		if resources == nil {
+			resources = []uuid.UUID{}
+		}
+	}
+	reminders, err := toReminders(req.GetReminders())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
 	//nolint:exhaustruct
 	event := storage.Event{
 		Title:        req.GetTitle(),
 		Description:  req.GetDescription(),
-		StartTime:    req.GetStartTime().AsTime(),
-		EndTime:      req.GetEndTime().AsTime(),
-		NotifyBefore: &notifyBefore,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		AllDay:       req.GetAllDay(),
+		TimeZone:     req.GetTimeZone(),
+		Transparency: transparency,
+		Reminders:    reminders,
+		RRule:        rule,
+		Attendees:    attendees,
+		CalendarID:   calendarID,
+		Resources:    resources,
 	}
 
-	if err := s.storage.UpdateEvent(ctx, username, id, &event); err != nil {
+	owner, err := sharing.EventOwner(ctx, s.storage, username, id, storage.RoleEditor)
+	if err != nil {
+		return nil, accessError(err)
+	}
+	if req.GetRecurrenceId() == nil {
+		err = s.storage.UpdateEvent(ctx, owner, id, &event)
+	} else {
+		err = s.storage.UpdateEventOccurrence(ctx, owner, id, req.GetRecurrenceId().AsTime(), scope, &event)
+	}
+	if err != nil {
 		err = fmt.Errorf("updating event to storage: %w", err)
-		if errors.Is(err, storage.ErrDateBusy) {
+		if errors.Is(err, storage.ErrDateBusy) || errors.Is(err, storage.ErrResourceBusy) {
 			return nil, status.Error(codes.Aborted, err.Error()) //nolint:wrapcheck
 		}
-		if errors.Is(err, storage.ErrEventNotFound) {
+		if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrOccurrenceNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
 		}
+		if errors.Is(err, storage.ErrUserNotFound) || errors.Is(err, storage.ErrCalendarNotFound) ||
+			errors.Is(err, storage.ErrResourceNotFound) {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
 		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
 	}
 
@@ -150,9 +288,23 @@ func (s *Server) DeleteEvent(ctx context.Context, req *api.ReqDeleteEvent) (*emp
 		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
 	}
 
-	if err := s.storage.DeleteEvent(ctx, username, id); err != nil {
+	scope, err := parseScope(req.GetScope(), req.GetRecurrenceId() != nil)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	owner, err := sharing.EventOwner(ctx, s.storage, username, id, storage.RoleEditor)
+	if err != nil {
+		return nil, accessError(err)
+	}
+	if req.GetRecurrenceId() == nil {
+		err = s.storage.DeleteEvent(ctx, owner, id, 0)
+	} else {
+		err = s.storage.DeleteEventOccurrence(ctx, owner, id, req.GetRecurrenceId().AsTime(), scope)
+	}
+	if err != nil {
 		err := fmt.Errorf("deleting event from storage: %w", err)
-		if errors.Is(err, storage.ErrEventNotFound) {
+		if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrOccurrenceNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
 		}
 		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
@@ -160,3 +312,144 @@ func (s *Server) DeleteEvent(ctx context.Context, req *api.ReqDeleteEvent) (*emp
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *Server) ExportCalendar(ctx context.Context, req *api.ReqExportCalendar) (*api.ResExportCalendar, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	start, end := icalendar.DefaultWindow(time.Now())
+	if req.GetStartTime() != nil {
+		start = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		end = req.GetEndTime().AsTime()
+	}
+	if start.After(end) {
+		return nil, status.Error(codes.InvalidArgument, "start_time must be before or equal to end_time") //nolint:wrapcheck
+	}
+
+	cal, err := icalendar.Export(ctx, s.storage, username, start, end)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "export events: %v", err)
+	}
+
+	return &api.ResExportCalendar{Calendar: cal.String()}, nil
+}
+
+func toResEvent(event *storage.Event) *api.ResEvent {
+	resEvent := &api.ResEvent{
+		Id:           event.ID.String(),
+		Title:        event.Title,
+		Description:  event.Description,
+		StartTime:    timestamppb.New(event.StartTime),
+		EndTime:      timestamppb.New(event.EndTime),
+		Reminders:    toPbReminders(event.Reminders),
+		Rrule:        event.RRule,
+		Uid:          event.UID,
+		AllDay:       event.AllDay,
+		TimeZone:     event.TimeZone,
+		Transparency: string(event.Transparency),
+		Attendees:    toPbAttendees(event.Attendees),
+		Organizer:    event.Organizer,
+	}
+	if event.AllDay {
+		resEvent.StartDate = event.StartTime.Format(time.DateOnly)
+		resEvent.EndDate = event.EndTime.AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+	for _, exDate := range event.ExDates {
+		resEvent.Exdates = append(resEvent.Exdates, timestamppb.New(exDate))
+	}
+	if event.ParentID != nil {
+		resEvent.ParentId = event.ParentID.String()
+	}
+	if event.RecurrenceID != nil {
+		resEvent.RecurrenceId = timestamppb.New(*event.RecurrenceID)
+	}
+	if event.CalendarID != nil {
+		resEvent.CalendarId = event.CalendarID.String()
+	}
+	for _, resourceID := range event.Resources {
+		resEvent.Resources = append(resEvent.Resources, resourceID.String())
+	}
+
+	return resEvent
+}
+
+// parseCalendarID returns nil for an event without a calendar.
+func parseCalendarID(strID string) (*uuid.UUID, error) {
+	if strID == "" {
+		return nil, nil //nolint:nilnil
+	}
+	id, err := uuid.Parse(strID)
+	if err != nil {
+		return nil, fmt.Errorf("parse calendar_id: %w", err)
+	}
+
+	return &id, nil
+}
+
+// eventTimes returns the bounds of the event: the midnights in UTC around
+// the dates of an all-day event, end_date being its last day.
+func eventTimes(allDay bool, startTime, endTime *timestamppb.Timestamp, startDate, endDate string) (time.Time, time.Time, error) {
+	if !allDay {
+		return startTime.AsTime(), endTime.AsTime(), nil
+	}
+
+	start, err := time.Parse(time.DateOnly, startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse start_date: %w", err)
+	}
+	end := start
+	if endDate != "" {
+		if end, err = time.Parse(time.DateOnly, endDate); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse end_date: %w", err)
+		}
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, errors.New("start_date must be before or equal to end_date")
+	}
+
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// parseRRule validates the recurrence rule and returns it in normalized form.
+func parseRRule(str string) (string, error) {
+	if str == "" {
+		return "", nil
+	}
+	rule, err := rrule.Parse(str)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return rule.String(), nil
+}
+
+// parseScope returns the scope of a change of a recurring event.
+func parseScope(str string, hasRecurrenceID bool) (storage.Scope, error) {
+	scope := storage.Scope(str)
+	switch {
+	case !hasRecurrenceID && scope != "" && scope != storage.ScopeAll:
+		return "", errors.New("scope requires recurrence_id")
+	case scope == "" && hasRecurrenceID:
+		return storage.ScopeThis, nil
+	case scope == "":
+		return storage.ScopeAll, nil
+	case scope == storage.ScopeThis, scope == storage.ScopeFollowing, scope == storage.ScopeAll:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("invalid scope %q, use this, following or all", scope)
+	}
+}
+
+func parseTransparency(str string) (storage.Transparency, error) {
+	transparency := storage.Transparency(str)
+	switch transparency {
+	case "", storage.TransparencyBusy, storage.TransparencyFree:
+		return transparency, nil
+	default:
+		return "", fmt.Errorf("invalid transparency %q, use busy or free", transparency)
+	}
+}
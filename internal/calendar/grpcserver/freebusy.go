@@ -0,0 +1,154 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxFreeBusyUsers = 50
+
+func (s *Server) FreeBusy(ctx context.Context, req *api.ReqFreeBusy) (*api.ResFreeBusy, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	if err := validateFreeBusy(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	users, err := freebusy.Query(ctx, s.storage, username, req.GetUsernames(), req.GetStartTime().AsTime(), req.GetEndTime().AsTime())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query free/busy: %v", err)
+	}
+
+	resUsers := make([]*api.UserFreeBusy, 0, len(users))
+	for _, user := range users {
+		resUser := &api.UserFreeBusy{Username: user.Username, Busy: toIntervals(user.Busy)}
+		if user.Err != nil {
+			resUser.Error = user.Err.Error()
+		}
+		resUsers = append(resUsers, resUser)
+	}
+
+	return &api.ResFreeBusy{Users: resUsers, Busy: toIntervals(freebusy.MergeUsers(users))}, nil
+}
+
+func validateFreeBusy(req *api.ReqFreeBusy) error {
+	usernames := req.GetUsernames()
+	if len(usernames) == 0 || len(usernames) > maxFreeBusyUsers {
+		return fmt.Errorf("number of usernames must be from 1 to %d", maxFreeBusyUsers)
+	}
+	if slices.Contains(usernames, "") {
+		return errors.New("empty username")
+	}
+	if req.GetStartTime() == nil || req.GetEndTime() == nil {
+		return errors.New("start_time and end_time are required")
+	}
+	start, end := req.GetStartTime().AsTime(), req.GetEndTime().AsTime()
+	if !start.Before(end) {
+		return errors.New("start_time must be before end_time")
+	}
+	if end.Sub(start) > freebusy.MaxRange {
+		return fmt.Errorf("time range longer than %s", freebusy.MaxRange)
+	}
+
+	return nil
+}
+
+func toIntervals(intervals []freebusy.Interval) []*api.Interval {
+	pbIntervals := make([]*api.Interval, len(intervals))
+	for i, interval := range intervals {
+		pbIntervals[i] = &api.Interval{
+			StartTime: timestamppb.New(interval.Start),
+			EndTime:   timestamppb.New(interval.End),
+		}
+	}
+
+	return pbIntervals
+}
+
+func (s *Server) FindSlots(ctx context.Context, req *api.ReqFindSlots) (*api.ResFindSlots, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	query, err := toSlotQuery(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	slots, err := freebusy.FindSlots(ctx, s.storage, s.opts, username, query)
+	if err != nil {
+		err := fmt.Errorf("find slots: %w", err)
+		switch {
+		case errors.Is(err, freebusy.ErrInvalidSlotQuery):
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, freebusy.ErrNotAvailable):
+			return nil, status.Error(codes.PermissionDenied, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	intervals := make([]freebusy.Interval, len(slots))
+	for i, start := range slots {
+		intervals[i] = freebusy.Interval{Start: start, End: start.Add(query.Duration)}
+	}
+
+	return &api.ResFindSlots{Slots: toIntervals(intervals)}, nil
+}
+
+func toSlotQuery(req *api.ReqFindSlots) (*freebusy.SlotQuery, error) {
+	usernames := req.GetUsernames()
+	if len(usernames) == 0 || len(usernames) > maxFreeBusyUsers {
+		return nil, fmt.Errorf("number of usernames must be from 1 to %d", maxFreeBusyUsers)
+	}
+	if req.GetDuration() == nil || req.GetStartTime() == nil || req.GetEndTime() == nil {
+		return nil, errors.New("duration, start_time and end_time are required")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > maxFreeBusyUsers {
+		return nil, fmt.Errorf("limit must be from 1 to %d", maxFreeBusyUsers)
+	}
+	query := freebusy.SlotQuery{
+		Usernames:    usernames,
+		Duration:     req.GetDuration().AsDuration(),
+		Start:        req.GetStartTime().AsTime(),
+		End:          req.GetEndTime().AsTime(),
+		WorkingHours: nil,
+		Buffer:       0,
+		Limit:        int(req.GetLimit()),
+	}
+
+	if pbWorkingHours := req.GetWorkingHours(); pbWorkingHours != nil {
+		start, err := freebusy.ParseClock(pbWorkingHours.GetStart())
+		if err != nil {
+			return nil, fmt.Errorf("working hours start: %w", err)
+		}
+		end, err := freebusy.ParseClock(pbWorkingHours.GetEnd())
+		if err != nil {
+			return nil, fmt.Errorf("working hours end: %w", err)
+		}
+		weekdays, err := freebusy.ParseWeekdays(pbWorkingHours.GetWeekdays())
+		if err != nil {
+			return nil, fmt.Errorf("working hours: %w", err)
+		}
+		wh := freebusy.WorkingHours{Start: start, End: end, Weekdays: weekdays, Location: nil}
+		if pbWorkingHours.GetTimeZone() != "" {
+			if wh.Location, err = storage.LoadLocation(pbWorkingHours.GetTimeZone()); err != nil {
+				return nil, fmt.Errorf("working hours: %w", err)
+			}
+		}
+		query.WorkingHours = &wh
+	}
+
+	return &query, nil
+}
@@ -0,0 +1,118 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/sharing"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) SetGrant(ctx context.Context, req *api.ReqSetGrant) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+	if req.GetGrantee() == "" || req.GetGrantee() == username {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid grantee %q", req.GetGrantee())
+	}
+	role, err := parseRole(req.GetRole())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	//nolint:exhaustruct
+	grant := storage.Grant{
+		Owner:   username,
+		Grantee: req.GetGrantee(),
+		Role:    role,
+	}
+	if err := s.storage.SetGrant(ctx, &grant); err != nil {
+		err = fmt.Errorf("saving grant to storage: %w", err)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListGrants(ctx context.Context, _ *emptypb.Empty) (*api.ResListGrants, error) {
+	return s.listGrants(ctx, s.storage.ListGrants)
+}
+
+func (s *Server) ListSharedGrants(ctx context.Context, _ *emptypb.Empty) (*api.ResListGrants, error) {
+	return s.listGrants(ctx, s.storage.ListGrantsTo)
+}
+
+func (s *Server) listGrants(ctx context.Context, list func(ctx context.Context, username string) ([]storage.Grant, error)) (*api.ResListGrants, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	grants, err := list(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting grants from storage: %v", err)
+	}
+
+	pbGrants := make([]*api.Grant, len(grants))
+	for i, grant := range grants {
+		pbGrants[i] = &api.Grant{
+			Owner:     grant.Owner,
+			Grantee:   grant.Grantee,
+			Role:      string(grant.Role),
+			CreatedAt: timestamppb.New(grant.CreatedAt),
+		}
+	}
+
+	return &api.ResListGrants{Grants: pbGrants}, nil
+}
+
+func (s *Server) DeleteGrant(ctx context.Context, req *api.ReqDeleteGrant) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	if err := s.storage.DeleteGrant(ctx, username, req.GetGrantee()); err != nil {
+		err = fmt.Errorf("deleting grant from storage: %w", err)
+		if errors.Is(err, storage.ErrGrantNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseRole(str string) (storage.Role, error) {
+	role := storage.Role(str)
+	switch role {
+	case storage.RoleOwner, storage.RoleEditor, storage.RoleViewer, storage.RoleFreeBusy:
+		return role, nil
+	default:
+		return "", fmt.Errorf("invalid role %q, use owner, editor, viewer or freebusy", role)
+	}
+}
+
+// accessError returns the status of the error of a user who may not access
+// the events of another one.
+func accessError(err error) error {
+	switch {
+	case errors.Is(err, sharing.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, storage.ErrEventNotFound), errors.Is(err, storage.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+	default:
+		return status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+}
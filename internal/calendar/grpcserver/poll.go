@@ -0,0 +1,334 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/poll"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxPollTitleLen       = 64
+	maxPollDescriptionLen = 512
+	maxPollOptions        = 20
+	maxPollInvitees       = 100
+)
+
+func (s *Server) CreatePoll(ctx context.Context, req *api.ReqCreatePoll) (*api.ResCreatePoll, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	newPoll, err := toPoll(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	newPoll.Username = username
+	if err := poll.Check(newPoll); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	id, err := s.storage.CreatePoll(ctx, newPoll)
+	if err != nil {
+		err = fmt.Errorf("saving poll to storage: %w", err)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &api.ResCreatePoll{Id: id.String()}, nil
+}
+
+func (s *Server) GetPoll(ctx context.Context, req *api.ReqGetPoll) (*api.ResPoll, error) {
+	p, err := s.getPoll(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toResPoll(p), nil
+}
+
+func (s *Server) ListPolls(ctx context.Context, _ *emptypb.Empty) (*api.ResListPolls, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	polls, err := s.storage.ListPolls(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting list polls from storage: %v", err)
+	}
+
+	pbPolls := make([]*api.ResPoll, len(polls))
+	for i := range polls {
+		pbPolls[i] = toResPoll(&polls[i])
+	}
+
+	return &api.ResListPolls{Polls: pbPolls}, nil
+}
+
+func (s *Server) DeletePoll(ctx context.Context, req *api.ReqDeletePoll) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	if err := s.storage.DeletePoll(ctx, username, id); err != nil {
+		err = fmt.Errorf("deleting poll from storage: %w", err)
+		if errors.Is(err, storage.ErrPollNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// SetVotes replaces the votes of the invitee on the options of the poll.
+func (s *Server) SetVotes(ctx context.Context, req *api.ReqSetVotes) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+	votes, err := toPollVotes(username, req.GetVotes())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	if err := s.storage.SetVotes(ctx, username, id, votes); err != nil {
+		err = fmt.Errorf("saving votes to storage: %w", err)
+		switch {
+		case errors.Is(err, storage.ErrPollNotFound):
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, storage.ErrPollOptionNotFound):
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, storage.ErrPollFinalized):
+			return nil, status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// TallyPoll counts the votes on each option of the poll.
+func (s *Server) TallyPoll(ctx context.Context, req *api.ReqTallyPoll) (*api.ResTallyPoll, error) {
+	p, err := s.getPoll(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	tally := poll.Tally(p)
+	pbOptions := make([]*api.OptionTally, len(tally))
+	for i, option := range tally {
+		pbOptions[i] = &api.OptionTally{
+			Option:   toPbPollOption(&option.PollOption),
+			Yes:      int32(option.Yes),      //nolint:gosec
+			IfNeeded: int32(option.IfNeeded), //nolint:gosec
+			No:       int32(option.No),       //nolint:gosec
+			Pending:  option.Pending,
+		}
+	}
+	var winnerID string
+	if winner := poll.Winner(tally); winner != nil {
+		winnerID = winner.ID.String()
+	}
+
+	return &api.ResTallyPoll{Options: pbOptions, WinnerId: winnerID}, nil
+}
+
+// FinalizePoll creates the event of the chosen or winning option of the poll
+// and closes it. The invitees are invited to the event.
+func (s *Server) FinalizePoll(ctx context.Context, req *api.ReqFinalizePoll) (*api.ResFinalizePoll, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+	var optionID *uuid.UUID
+	if req.GetOptionId() != "" {
+		id, err := uuid.Parse(req.GetOptionId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parse option id: %v", err)
+		}
+		optionID = &id
+	}
+
+	event, err := poll.Finalize(ctx, s.storage, username, id, optionID)
+	if err != nil {
+		err = fmt.Errorf("finalize poll: %w", err)
+		switch {
+		case errors.Is(err, storage.ErrPollNotFound):
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, poll.ErrNotOrganizer):
+			return nil, status.Error(codes.PermissionDenied, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, storage.ErrPollOptionNotFound):
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, storage.ErrPollFinalized), errors.Is(err, poll.ErrNoWinner):
+			return nil, status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+		case errors.Is(err, storage.ErrDateBusy):
+			return nil, status.Error(codes.Aborted, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	conflicts := make([]string, len(event.Conflicts))
+	for i, conflictID := range event.Conflicts {
+		conflicts[i] = conflictID.String()
+	}
+
+	return &api.ResFinalizePoll{EventId: event.ID.String(), Conflicts: conflicts}, nil
+}
+
+func (s *Server) getPoll(ctx context.Context, idStr string) (*storage.Poll, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	p, err := s.storage.GetPoll(ctx, username, id)
+	if err != nil {
+		err = fmt.Errorf("getting poll from storage: %w", err)
+		if errors.Is(err, storage.ErrPollNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return p, nil
+}
+
+func toPoll(req *api.ReqCreatePoll) (*storage.Poll, error) {
+	if n := utf8.RuneCountInString(req.GetTitle()); n < 2 || n > maxPollTitleLen {
+		return nil, fmt.Errorf("title must be from 2 to %d characters", maxPollTitleLen)
+	}
+	if utf8.RuneCountInString(req.GetDescription()) > maxPollDescriptionLen {
+		return nil, fmt.Errorf("description must be at most %d characters", maxPollDescriptionLen)
+	}
+	if n := len(req.GetOptions()); n < 2 || n > maxPollOptions {
+		return nil, fmt.Errorf("poll must have from 2 to %d options", maxPollOptions)
+	}
+	if n := len(req.GetInvitees()); n < 1 || n > maxPollInvitees {
+		return nil, fmt.Errorf("poll must have from 1 to %d invitees", maxPollInvitees)
+	}
+
+	options := make([]storage.PollOption, len(req.GetOptions()))
+	for i, option := range req.GetOptions() {
+		if option.GetStartTime() == nil || option.GetEndTime() == nil {
+			return nil, errors.New("option start and end time are required")
+		}
+		options[i] = storage.PollOption{StartTime: option.GetStartTime().AsTime(), EndTime: option.GetEndTime().AsTime()} //nolint:exhaustruct
+	}
+	seen := make(map[string]bool, len(req.GetInvitees()))
+	for _, invitee := range req.GetInvitees() {
+		if invitee == "" || seen[invitee] {
+			return nil, fmt.Errorf("invalid invitee %q", invitee)
+		}
+		seen[invitee] = true
+	}
+
+	//nolint:exhaustruct
+	return &storage.Poll{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Options:     options,
+		Invitees:    req.GetInvitees(),
+	}, nil
+}
+
+func toPollVotes(username string, pbVotes []*api.PollVote) ([]storage.PollVote, error) {
+	if len(pbVotes) > maxPollOptions {
+		return nil, fmt.Errorf("at most %d votes", maxPollOptions)
+	}
+	votes := make([]storage.PollVote, len(pbVotes))
+	seen := make(map[uuid.UUID]bool, len(pbVotes))
+	for i, pbVote := range pbVotes {
+		optionID, err := uuid.Parse(pbVote.GetOptionId())
+		if err != nil {
+			return nil, fmt.Errorf("parse option id: %w", err)
+		}
+		if seen[optionID] {
+			return nil, fmt.Errorf("second vote on option %s", optionID)
+		}
+		seen[optionID] = true
+		vote := storage.Vote(pbVote.GetVote())
+		switch vote {
+		case storage.VoteYes, storage.VoteNo, storage.VoteIfNeeded:
+		default:
+			return nil, fmt.Errorf("invalid vote %q", pbVote.GetVote())
+		}
+		votes[i] = storage.PollVote{OptionID: optionID, Username: username, Vote: vote}
+	}
+
+	return votes, nil
+}
+
+func toPbPollOption(option *storage.PollOption) *api.PollOption {
+	return &api.PollOption{
+		Id:        option.ID.String(),
+		StartTime: timestamppb.New(option.StartTime),
+		EndTime:   timestamppb.New(option.EndTime),
+	}
+}
+
+func toResPoll(p *storage.Poll) *api.ResPoll {
+	pbOptions := make([]*api.PollOption, len(p.Options))
+	for i := range p.Options {
+		pbOptions[i] = toPbPollOption(&p.Options[i])
+	}
+	pbVotes := make([]*api.PollVote, len(p.Votes))
+	for i, vote := range p.Votes {
+		pbVotes[i] = &api.PollVote{OptionId: vote.OptionID.String(), Username: vote.Username, Vote: string(vote.Vote)}
+	}
+	var finalOptionID, eventID string
+	if p.FinalOptionID != nil {
+		finalOptionID = p.FinalOptionID.String()
+	}
+	if p.EventID != nil {
+		eventID = p.EventID.String()
+	}
+
+	return &api.ResPoll{
+		Id:            p.ID.String(),
+		Title:         p.Title,
+		Description:   p.Description,
+		Options:       pbOptions,
+		Invitees:      p.Invitees,
+		Votes:         pbVotes,
+		FinalOptionId: finalOptionID,
+		EventId:       eventID,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		Organizer:     p.Username,
+	}
+}
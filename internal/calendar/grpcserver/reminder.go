@@ -0,0 +1,34 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func toReminders(pbReminders []*api.Reminder) ([]storage.Reminder, error) {
+	reminders := make([]storage.Reminder, len(pbReminders))
+	for i, pbReminder := range pbReminders {
+		channel, err := storage.ParseChannel(pbReminder.GetChannel())
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		reminders[i] = storage.Reminder{Offset: pbReminder.GetOffset().AsDuration(), Channel: channel}
+	}
+	if err := storage.CheckReminders(reminders); err != nil {
+		return nil, fmt.Errorf("reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func toPbReminders(reminders []storage.Reminder) []*api.Reminder {
+	pbReminders := make([]*api.Reminder, len(reminders))
+	for i, reminder := range reminders {
+		pbReminders[i] = &api.Reminder{Offset: durationpb.New(reminder.Offset), Channel: string(reminder.Channel)}
+	}
+
+	return pbReminders
+}
@@ -0,0 +1,191 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxResourceNameLen = 64
+	maxResourceKindLen = 32
+)
+
+func (s *Server) CreateResource(ctx context.Context, req *api.ReqCreateResource) (*api.ResCreateResource, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	resource, err := toResource(req.GetName(), req.GetKind(), req.GetCapacity(), req.GetAttributes())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	id, err := s.storage.CreateResource(ctx, resource)
+	if err != nil {
+		err = fmt.Errorf("saving resource to storage: %w", err)
+		if errors.Is(err, storage.ErrResourceExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &api.ResCreateResource{Id: id.String()}, nil
+}
+
+func (s *Server) GetResource(ctx context.Context, req *api.ReqGetResource) (*api.ResResource, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	resource, err := s.storage.GetResource(ctx, id)
+	if err != nil {
+		err := fmt.Errorf("getting resource from storage: %w", err)
+		if errors.Is(err, storage.ErrResourceNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return toResResource(resource), nil
+}
+
+func (s *Server) ListResources(ctx context.Context, req *api.ReqListResources) (*api.ResListResources, error) {
+	resources, err := s.storage.ListResources(ctx, req.GetKind(), int(req.GetMinCapacity()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting list resources from storage: %v", err)
+	}
+
+	pbResources := make([]*api.ResResource, len(resources))
+	for i := range resources {
+		pbResources[i] = toResResource(&resources[i])
+	}
+
+	return &api.ResListResources{Resources: pbResources}, nil
+}
+
+func (s *Server) UpdateResource(ctx context.Context, req *api.ReqUpdateResource) (*emptypb.Empty, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+	resource, err := toResource(req.GetName(), req.GetKind(), req.GetCapacity(), req.GetAttributes())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	if err := s.storage.UpdateResource(ctx, id, resource); err != nil {
+		err = fmt.Errorf("updating resource in storage: %w", err)
+		if errors.Is(err, storage.ErrResourceNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		if errors.Is(err, storage.ErrResourceExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteResource(ctx context.Context, req *api.ReqDeleteResource) (*emptypb.Empty, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse uuid: %v", err)
+	}
+
+	if err := s.storage.DeleteResource(ctx, id); err != nil {
+		err := fmt.Errorf("deleting resource from storage: %w", err)
+		if errors.Is(err, storage.ErrResourceNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// checkAdmin returns nil if the user may manage the resources.
+func (s *Server) checkAdmin(ctx context.Context) error {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+	user, err := s.storage.GetUser(ctx, username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "getting user from storage: %v", err)
+	}
+	if user.Role != "admin" {
+		return status.Error(codes.PermissionDenied, "admin role needed") //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// toResource validates the resource as the HTTP API does.
+func toResource(name, kind string, capacity int32, attributes map[string]string) (*storage.Resource, error) {
+	if name == "" || utf8.RuneCountInString(name) > maxResourceNameLen {
+		return nil, fmt.Errorf("invalid name: %q", name)
+	}
+	if kind == "" || utf8.RuneCountInString(kind) > maxResourceKindLen {
+		return nil, fmt.Errorf("invalid kind: %q", kind)
+	}
+	if capacity < 0 {
+		return nil, fmt.Errorf("invalid capacity: %d", capacity)
+	}
+
+	//nolint:exhaustruct
+	return &storage.Resource{
+		Name:       name,
+		Kind:       kind,
+		Capacity:   int(capacity),
+		Attributes: attributes,
+	}, nil
+}
+
+func toResResource(resource *storage.Resource) *api.ResResource {
+	return &api.ResResource{
+		Id:         resource.ID.String(),
+		Name:       resource.Name,
+		Kind:       resource.Kind,
+		Capacity:   int32(resource.Capacity), //nolint:gosec
+		Attributes: resource.Attributes,
+		CreatedAt:  timestamppb.New(resource.CreatedAt),
+	}
+}
+
+// parseResources returns the ids of the resources, nil if there are none.
+func parseResources(strIDs []string) ([]uuid.UUID, error) {
+	if len(strIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(strIDs))
+	for i, strID := range strIDs {
+		id, err := uuid.Parse(strID)
+		if err != nil {
+			return nil, fmt.Errorf("parse resource id: %w", err)
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
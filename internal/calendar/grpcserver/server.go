@@ -10,6 +10,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/mrvin/calendar/internal/booking"
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
 	"github.com/mrvin/calendar/internal/storage"
@@ -32,14 +33,20 @@ type Server struct {
 	conn    net.Listener
 	addr    string
 	storage storage.Storage
+	opts    storage.Options
 	auth    *auth.Auth
+	// bookings bounds the bookings, which need no account.
+	bookings *booking.RateLimit
 }
 
-func New(ctx context.Context, conf *Conf, storage storage.Storage, auth *auth.Auth) (*Server, error) {
+// New returns the server; storageOpts must be those of st.
+func New(ctx context.Context, conf *Conf, st storage.Storage, auth *auth.Auth, storageOpts ...storage.Option) (*Server, error) {
 	var server Server
 
-	server.storage = storage
+	server.storage = st
+	server.opts = storage.NewOptions(storageOpts...)
 	server.auth = auth
+	server.bookings = booking.NewRateLimit()
 
 	var err error
 	lc := net.ListenConfig{} //nolint:exhaustruct
@@ -109,6 +116,9 @@ func (s *Server) authInterceptor(ctx context.Context, req any, info *grpc.UnaryS
 	publicMethods := map[string]bool{
 		"/calendar.CalendarService/Register": true,
 		"/calendar.CalendarService/Login":    true,
+		// Booking pages are for customers without an account.
+		"/calendar.CalendarService/GetBookingPage": true,
+		"/calendar.CalendarService/Book":           true,
 	}
 	if publicMethods[info.FullMethod] {
 		return handler(ctx, req)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
@@ -13,20 +14,30 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) Register(ctx context.Context, req *api.ReqRegister) (*emptypb.Empty, error) {
 	ctx = logger.WithUsername(ctx, req.GetUsername())
 	//TODO:add validation
+	if _, err := storage.LoadLocation(req.GetTimeZone()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	policy, err := parseConflictPolicy(req.GetConflictPolicy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
 	hashPassword, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "generate hash password: %v", err)
 	}
 	user := storage.User{
-		Name:         req.GetUsername(),
-		HashPassword: string(hashPassword),
-		Email:        req.GetEmail(),
-		Role:         "user",
+		Name:           req.GetUsername(),
+		HashPassword:   string(hashPassword),
+		Email:          req.GetEmail(),
+		Role:           "user",
+		TimeZone:       req.GetTimeZone(),
+		ConflictPolicy: policy,
 	}
 	if err := s.storage.CreateUser(ctx, &user); err != nil {
 		err = fmt.Errorf("saving user to storage: %w", err)
@@ -67,10 +78,27 @@ func (s *Server) GetUser(ctx context.Context, _ *emptypb.Empty) (*api.ResUser, e
 		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
 	}
 
+	var pbWorkingHours *api.WorkingHours
+	if wh := user.WorkingHours; wh != nil {
+		pbWorkingHours = &api.WorkingHours{Start: wh.Start, End: wh.End, Weekdays: wh.Weekdays, TimeZone: wh.TimeZone}
+	}
+	pbOutOfOffice := make([]*api.Interval, len(user.OutOfOffice))
+	for i, ooo := range user.OutOfOffice {
+		pbOutOfOffice[i] = &api.Interval{StartTime: timestamppb.New(ooo.StartTime), EndTime: timestamppb.New(ooo.EndTime)}
+	}
+
 	return &api.ResUser{
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
+		Name:               user.Name,
+		Email:              user.Email,
+		Role:               user.Role,
+		TimeZone:           user.TimeZone,
+		ConflictPolicy:     string(user.ConflictPolicy),
+		ShareFreeBusy:      user.ShareFreeBusy,
+		WorkingHours:       pbWorkingHours,
+		OutOfOffice:        pbOutOfOffice,
+		DeclineUnavailable: user.DeclineUnavailable,
+		RetentionDays:      int32(user.RetentionDays), //nolint:gosec
+		WebhookUrl:         user.WebhookURL,
 	}, nil
 }
 
@@ -90,3 +118,84 @@ func (s *Server) DeleteUser(ctx context.Context, _ *emptypb.Empty) (*emptypb.Emp
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *Server) UpdateUserSettings(ctx context.Context, req *api.ReqUserSettings) (*emptypb.Empty, error) {
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "getting username from ctx: %v", err)
+	}
+
+	if _, err := storage.LoadLocation(req.GetTimeZone()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	policy, err := parseConflictPolicy(req.GetConflictPolicy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	if days := req.GetRetentionDays(); days < 0 || days > storage.MaxRetentionDays {
+		return nil, status.Errorf(codes.InvalidArgument, "retention of %d days, use 0 to %d", days, storage.MaxRetentionDays)
+	}
+	if err := storage.CheckWebhookURL(req.GetWebhookUrl()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	//nolint:exhaustruct
+	user := storage.User{
+		Name:           username,
+		TimeZone:       req.GetTimeZone(),
+		ConflictPolicy: policy,
+		ShareFreeBusy:  req.GetShareFreeBusy(),
+		WorkSchedule:   toWorkSchedule(req),
+		RetentionDays:  int(req.GetRetentionDays()),
+		WebhookURL:     req.GetWebhookUrl(),
+	}
+	if err := user.WorkSchedule.Check(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	if err := s.storage.UpdateUserSettings(ctx, &user); err != nil {
+		err := fmt.Errorf("updating user settings in storage: %w", err)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error()) //nolint:wrapcheck
+		}
+		return nil, status.Error(codes.Internal, err.Error()) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseConflictPolicy(str string) (storage.ConflictPolicy, error) {
+	policy := storage.ConflictPolicy(str)
+	switch policy {
+	case "", storage.ConflictReject, storage.ConflictWarn, storage.ConflictAllow:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q, use reject, warn or allow", policy)
+	}
+}
+
+func toWorkSchedule(req *api.ReqUserSettings) storage.WorkSchedule {
+	ws := storage.WorkSchedule{
+		WorkingHours:       nil,
+		OutOfOffice:        make([]storage.OutOfOffice, len(req.GetOutOfOffice())),
+		DeclineUnavailable: req.GetDeclineUnavailable(),
+	}
+	if pbWorkingHours := req.GetWorkingHours(); pbWorkingHours != nil {
+		weekdays := make([]string, len(pbWorkingHours.GetWeekdays()))
+		for i, day := range pbWorkingHours.GetWeekdays() {
+			weekdays[i] = strings.ToUpper(day)
+		}
+		ws.WorkingHours = &storage.WorkingHours{
+			Start:    pbWorkingHours.GetStart(),
+			End:      pbWorkingHours.GetEnd(),
+			Weekdays: weekdays,
+			TimeZone: pbWorkingHours.GetTimeZone(),
+		}
+		if ws.WorkingHours.TimeZone == "" {
+			ws.WorkingHours.TimeZone = req.GetTimeZone()
+		}
+	}
+	for i, ooo := range req.GetOutOfOffice() {
+		ws.OutOfOffice[i] = storage.OutOfOffice{StartTime: ooo.GetStartTime().AsTime(), EndTime: ooo.GetEndTime().AsTime()}
+	}
+
+	return ws
+}
@@ -0,0 +1,89 @@
+// Package caldav implements the subset of CalDAV (RFC 4791) needed by
+// desktop and phone clients to sync the events of a user: discovery of the
+// calendar with PROPFIND, calendar-query and calendar-multiget reports, and
+// GET, PUT and DELETE of calendar objects with ETags.
+//
+// Each user has a single calendar. A calendar object holds a series with
+// the overrides of its occurrences and is named after their UID.
+package caldav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/calendar/httpserver/handlers"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// Prefix is the path of the CalDAV tree.
+const Prefix = "/dav/"
+
+const calendarName = "default"
+
+const maxObjectSize = 1 << 20 // 1 MiB
+
+var errForbidden = errors.New("access to resources of another user")
+
+type Handler struct {
+	st storage.EventStorage
+}
+
+// New returns the handler of the CalDAV tree. The username must be set in
+// the request context by the auth middleware.
+func New(st storage.EventStorage) http.Handler {
+	h := &Handler{st: st}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodOptions+" "+Prefix, options)
+	mux.HandleFunc("PROPFIND "+Prefix+"{$}", handlers.ErrorHandler("CalDAV propfind root", h.propfindRoot))
+	mux.HandleFunc("PROPFIND "+Prefix+"principals/{user}/{$}", handlers.ErrorHandler("CalDAV propfind principal", h.propfindPrincipal))
+	mux.HandleFunc("PROPFIND "+Prefix+"calendars/{user}/{$}", handlers.ErrorHandler("CalDAV propfind home", h.propfindHome))
+	mux.HandleFunc("PROPFIND "+Prefix+"calendars/{user}/"+calendarName+"/{$}", handlers.ErrorHandler("CalDAV propfind calendar", h.propfindCalendar))
+	mux.HandleFunc("REPORT "+Prefix+"calendars/{user}/"+calendarName+"/{$}", handlers.ErrorHandler("CalDAV report", h.report))
+	mux.HandleFunc("PROPFIND "+Prefix+"calendars/{user}/"+calendarName+"/{name}", handlers.ErrorHandler("CalDAV propfind object", h.propfindObject))
+	mux.HandleFunc(http.MethodGet+" "+Prefix+"calendars/{user}/"+calendarName+"/{name}", handlers.ErrorHandler("CalDAV get object", h.getObject))
+	mux.HandleFunc(http.MethodPut+" "+Prefix+"calendars/{user}/"+calendarName+"/{name}", handlers.ErrorHandler("CalDAV put object", h.putObject))
+	mux.HandleFunc(http.MethodDelete+" "+Prefix+"calendars/{user}/"+calendarName+"/{name}", handlers.ErrorHandler("CalDAV delete object", h.deleteObject))
+
+	return mux
+}
+
+func options(res http.ResponseWriter, _ *http.Request) {
+	res.Header().Set("DAV", "1, 3, calendar-access")
+	res.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	res.WriteHeader(http.StatusOK)
+}
+
+// getUser returns the user of the request, who must own the requested resource.
+func getUser(req *http.Request) (context.Context, string, int, error) {
+	ctx := req.Context()
+
+	username, err := auth.GetUsernameFromCtx(ctx)
+	if err != nil {
+		return ctx, "", http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+	}
+	ctx = logger.WithUsername(ctx, username)
+
+	if user := req.PathValue("user"); user != "" && user != username {
+		return ctx, "", http.StatusForbidden, errForbidden
+	}
+
+	return ctx, username, http.StatusOK, nil
+}
+
+func principalPath(username string) string {
+	return Prefix + "principals/" + url.PathEscape(username) + "/"
+}
+
+func homePath(username string) string {
+	return Prefix + "calendars/" + url.PathEscape(username) + "/"
+}
+
+func calendarPath(username string) string {
+	return homePath(username) + calendarName + "/"
+}
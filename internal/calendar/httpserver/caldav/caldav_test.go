@@ -0,0 +1,229 @@
+package caldav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+const standup = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTART:20250106T090000Z\r\n" +
+	"DTEND:20250106T091500Z\r\n" +
+	"SUMMARY:Stand-up\r\n" +
+	"RRULE:FREQ=DAILY;COUNT=5\r\n" +
+	"END:VEVENT\r\n" +
+	"%s" +
+	"END:VCALENDAR\r\n"
+
+const standupOverride = "BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"RECURRENCE-ID:20250107T090000Z\r\n" +
+	"DTSTART:20250107T100000Z\r\n" +
+	"DTEND:20250107T101500Z\r\n" +
+	"SUMMARY:Late stand-up\r\n" +
+	"END:VEVENT\r\n"
+
+const objectPath = "/dav/calendars/bob/default/standup@example.com.ics"
+
+func do(t *testing.T, handler http.Handler, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(logger.WithUsername(context.Background(), "bob"), method, path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("create new request: %v", err)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	return res
+}
+
+func TestCalDAV(t *testing.T) {
+	handler := New(memory.New())
+
+	res := do(t, handler, "PROPFIND", "/dav/principals/bob/", "", nil)
+	if res.Code != http.StatusMultiStatus || !strings.Contains(res.Body.String(), "<c:calendar-home-set><d:href>/dav/calendars/bob/</d:href>") {
+		t.Fatalf("propfind principal: %d %s", res.Code, res.Body)
+	}
+	if res := do(t, handler, "PROPFIND", "/dav/principals/alice/", "", nil); res.Code != http.StatusForbidden {
+		t.Errorf("propfind principal of another user: expected %d, got %d", http.StatusForbidden, res.Code)
+	}
+
+	res = do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), map[string]string{"If-None-Match": "*"})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("put: %d %s", res.Code, res.Body)
+	}
+	etag := res.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("put: no ETag")
+	}
+	if res := do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), map[string]string{"If-None-Match": "*"}); res.Code != http.StatusPreconditionFailed {
+		t.Errorf("put existing with If-None-Match: expected %d, got %d", http.StatusPreconditionFailed, res.Code)
+	}
+
+	// Add an override of the second occurrence.
+	res = do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", standupOverride, 1), map[string]string{"If-Match": etag})
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("put override: %d %s", res.Code, res.Body)
+	}
+	if res.Header().Get("ETag") == etag {
+		t.Error("put override: ETag not changed")
+	}
+	if res := do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), map[string]string{"If-Match": etag}); res.Code != http.StatusPreconditionFailed {
+		t.Errorf("put with stale ETag: expected %d, got %d", http.StatusPreconditionFailed, res.Code)
+	}
+	if res := do(t, handler, http.MethodDelete, objectPath, "", map[string]string{"If-Match": etag}); res.Code != http.StatusPreconditionFailed {
+		t.Errorf("delete with stale ETag: expected %d, got %d", http.StatusPreconditionFailed, res.Code)
+	}
+	etag = res.Header().Get("ETag")
+
+	res = do(t, handler, "PROPFIND", "/dav/calendars/bob/default/", `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/"><d:prop><d:getetag/><cs:getctag/><d:unknown/></d:prop></d:propfind>`,
+		map[string]string{"Depth": "1"})
+	body := res.Body.String()
+	if res.Code != http.StatusMultiStatus || !strings.Contains(body, "<d:href>"+objectPath+"</d:href>") ||
+		!strings.Contains(body, escape(etag)) || !strings.Contains(body, "<d:unknown/>") {
+		t.Fatalf("propfind calendar: %d %s", res.Code, body)
+	}
+
+	query := `<?xml version="1.0"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+<d:prop><d:getetag/><c:calendar-data/></d:prop>
+<c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT">
+<c:time-range start="20250107T000000Z" end="20250108T000000Z"/>
+</c:comp-filter></c:comp-filter></c:filter>
+</c:calendar-query>`
+	res = do(t, handler, "REPORT", "/dav/calendars/bob/default/", query, nil)
+	if res.Code != http.StatusMultiStatus || !strings.Contains(res.Body.String(), "Late stand-up") {
+		t.Fatalf("report calendar-query: %d %s", res.Code, res.Body)
+	}
+	query = strings.ReplaceAll(query, "2025010", "2026010")
+	res = do(t, handler, "REPORT", "/dav/calendars/bob/default/", query, nil)
+	if res.Code != http.StatusMultiStatus || strings.Contains(res.Body.String(), "<d:response>") {
+		t.Fatalf("report calendar-query out of range: %d %s", res.Code, res.Body)
+	}
+
+	res = do(t, handler, "REPORT", "/dav/calendars/bob/default/", `<?xml version="1.0"?>
+<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+<d:prop><d:getetag/></d:prop>
+<d:href>`+objectPath+`</d:href>
+<d:href>/dav/calendars/bob/default/missing.ics</d:href>
+</c:calendar-multiget>`, nil)
+	if res.Code != http.StatusMultiStatus || !strings.Contains(res.Body.String(), "404 Not Found") {
+		t.Fatalf("report calendar-multiget: %d %s", res.Code, res.Body)
+	}
+
+	res = do(t, handler, http.MethodGet, objectPath, "", nil)
+	data, _ := io.ReadAll(res.Body)
+	if res.Code != http.StatusOK || res.Header().Get("ETag") != etag || strings.Count(string(data), "BEGIN:VEVENT") != 2 {
+		t.Fatalf("get: %d %s", res.Code, data)
+	}
+
+	// Remove the override, restoring the occurrence.
+	res = do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), nil)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("put without override: %d %s", res.Code, res.Body)
+	}
+	res = do(t, handler, http.MethodGet, objectPath, "", nil)
+	if strings.Count(res.Body.String(), "BEGIN:VEVENT") != 1 || strings.Contains(res.Body.String(), "EXDATE") {
+		t.Errorf("get after removing override: %s", res.Body)
+	}
+
+	if res := do(t, handler, http.MethodDelete, objectPath, "", nil); res.Code != http.StatusNoContent {
+		t.Fatalf("delete: %d %s", res.Code, res.Body)
+	}
+	if res := do(t, handler, http.MethodGet, objectPath, "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("get deleted: expected %d, got %d", http.StatusNotFound, res.Code)
+	}
+}
+
+// racingStorage changes the series of the user right after it is listed, as
+// a concurrent request would between the checks of a request and its update.
+type racingStorage struct {
+	*memory.Storage
+	race bool
+}
+
+func (s *racingStorage) ListSeries(ctx context.Context, username string) ([]storage.Event, error) {
+	events, err := s.Storage.ListSeries(ctx, username)
+	if err != nil || !s.race || len(events) == 0 {
+		return events, err
+	}
+	s.race = false
+	changed := events[0]
+	changed.Title = "Changed concurrently"
+	changed.Revision = 0
+	if err := s.UpdateEvent(ctx, username, changed.ID, &changed); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func TestPutObject_ConcurrentChange(t *testing.T) {
+	st := &racingStorage{Storage: memory.New(), race: false}
+	handler := New(st)
+
+	res := do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), nil)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("put: %d %s", res.Code, res.Body)
+	}
+	etag := res.Header().Get("ETag")
+
+	st.race = true
+	res = do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", standupOverride, 1), map[string]string{"If-Match": etag})
+	if res.Code != http.StatusPreconditionFailed {
+		t.Fatalf("put changed concurrently: expected %d, got %d %s", http.StatusPreconditionFailed, res.Code, res.Body)
+	}
+	res = do(t, handler, http.MethodGet, objectPath, "", nil)
+	if !strings.Contains(res.Body.String(), "Changed concurrently") || strings.Contains(res.Body.String(), "Late stand-up") {
+		t.Errorf("get after rejected put: %s", res.Body)
+	}
+}
+
+func TestDeleteObject_ConcurrentChange(t *testing.T) {
+	st := &racingStorage{Storage: memory.New(), race: false}
+	handler := New(st)
+
+	res := do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", "", 1), nil)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("put: %d %s", res.Code, res.Body)
+	}
+
+	st.race = true
+	res = do(t, handler, http.MethodDelete, objectPath, "", nil)
+	if res.Code != http.StatusPreconditionFailed {
+		t.Fatalf("delete changed concurrently: expected %d, got %d %s", http.StatusPreconditionFailed, res.Code, res.Body)
+	}
+	res = do(t, handler, http.MethodGet, objectPath, "", nil)
+	if res.Code != http.StatusOK || !strings.Contains(res.Body.String(), "Changed concurrently") {
+		t.Errorf("get after rejected delete: %d %s", res.Code, res.Body)
+	}
+}
+
+func TestPutObject_InvalidOverride(t *testing.T) {
+	handler := New(memory.New())
+
+	// The override is not of an occurrence of the series.
+	override := strings.Replace(standupOverride, "RECURRENCE-ID:20250107T090000Z", "RECURRENCE-ID:20250107T093000Z", 1)
+	res := do(t, handler, http.MethodPut, objectPath, strings.Replace(standup, "%s", override, 1), nil)
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("put with invalid override: expected %d, got %d %s", http.StatusBadRequest, res.Code, res.Body)
+	}
+	if res := do(t, handler, http.MethodGet, objectPath, "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("get after rejected put: expected %d, got %d %s", http.StatusNotFound, res.Code, res.Body)
+	}
+}
@@ -0,0 +1,126 @@
+package caldav
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+const objectExt = ".ics"
+
+// object is a calendar object resource: a series with its overrides
+// sharing one UID.
+type object struct {
+	uid    string
+	master *storage.Event
+	events []storage.Event // master first, then overrides by recurrence id
+}
+
+// listObjects groups the stored events of the user into calendar objects
+// ordered by UID.
+func listObjects(ctx context.Context, st storage.EventStorage, username string) ([]*object, error) {
+	events, err := st.ListSeries(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+	slices.SortStableFunc(events, func(a, b storage.Event) int {
+		switch {
+		case a.ParentID == nil && b.ParentID != nil:
+			return -1
+		case a.ParentID != nil && b.ParentID == nil:
+			return 1
+		case a.RecurrenceID != nil && b.RecurrenceID != nil:
+			return a.RecurrenceID.Compare(*b.RecurrenceID)
+		}
+		return 0
+	})
+
+	byUID := make(map[string]*object)
+	for _, event := range events {
+		uid := icalendar.UID(&event)
+		obj, ok := byUID[uid]
+		if !ok {
+			obj = &object{uid: uid, master: nil, events: nil}
+			byUID[uid] = obj
+		}
+		obj.events = append(obj.events, event)
+	}
+
+	objects := make([]*object, 0, len(byUID))
+	for _, obj := range byUID {
+		if obj.events[0].ParentID == nil {
+			obj.master = &obj.events[0]
+		}
+		objects = append(objects, obj)
+	}
+	slices.SortFunc(objects, func(a, b *object) int {
+		return strings.Compare(a.uid, b.uid)
+	})
+
+	return objects, nil
+}
+
+// findObject returns the calendar object with the UID or nil.
+func findObject(objects []*object, uid string) *object {
+	i, ok := slices.BinarySearchFunc(objects, uid, func(obj *object, uid string) int {
+		return strings.Compare(obj.uid, uid)
+	})
+	if !ok {
+		return nil
+	}
+
+	return objects[i]
+}
+
+func (o *object) name() string {
+	return url.PathEscape(o.uid) + objectExt
+}
+
+// etag changes with any stored field of the events of the object.
+func (o *object) etag() string {
+	data, _ := json.Marshal(o.events) //nolint:errchkjson
+	sum := sha256.Sum256(data)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func (o *object) calendar() *ical.Component {
+	cal := icalendar.NewCalendar()
+	now := time.Now()
+	for i := range o.events {
+		cal.Children = append(cal.Children, icalendar.VEvent(&o.events[i], now))
+	}
+	icalendar.AddTimezones(cal)
+
+	return cal
+}
+
+// ctag changes with any object of the calendar.
+func ctag(objects []*object) string {
+	hash := sha256.New()
+	for _, obj := range objects {
+		hash.Write([]byte(obj.uid + obj.etag()))
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// uidFromName returns the UID of the calendar object with the resource name.
+func uidFromName(name string) (string, bool) {
+	uid, ok := strings.CutSuffix(name, objectExt)
+	if !ok || uid == "" {
+		return "", false
+	}
+
+	return uid, true
+}
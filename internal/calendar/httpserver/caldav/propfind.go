@@ -0,0 +1,202 @@
+package caldav
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+// readPropfind returns the requested properties, nil for all of them.
+func readPropfind(req *http.Request) ([]xml.Name, error) {
+	var request requestPropfind
+	if err := decodeBody(req.Body, &request); err != nil {
+		return nil, err
+	}
+	if request.AllProp != nil {
+		return nil, nil
+	}
+
+	return request.Prop, nil
+}
+
+// depth returns whether the members of a collection are requested.
+// Infinite depth is treated as 1.
+func depth(req *http.Request) bool {
+	return req.Header.Get("Depth") != "0"
+}
+
+func (h *Handler) propfindRoot(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	names, err := readPropfind(req)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	responses := []response{newResponse(Prefix, collectionProps(username), names)}
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) propfindPrincipal(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	names, err := readPropfind(req)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	all := props{
+		propResourceType:         "<d:principal/>",
+		propDisplayName:          escape(username),
+		propCurrentUserPrincipal: href(principalPath(username)),
+		propPrincipalURL:         href(principalPath(username)),
+		propCalendarHomeSet:      href(homePath(username)),
+	}
+	responses := []response{newResponse(principalPath(username), all, names)}
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) propfindHome(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	names, err := readPropfind(req)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	responses := []response{newResponse(homePath(username), collectionProps(username), names)}
+	if depth(req) {
+		objects, err := listObjects(ctx, h.st, username)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, err
+		}
+		responses = append(responses, newResponse(calendarPath(username), calendarProps(username, objects), names))
+	}
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) propfindCalendar(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	names, err := readPropfind(req)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	objects, err := listObjects(ctx, h.st, username)
+	if err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+	responses := []response{newResponse(calendarPath(username), calendarProps(username, objects), names)}
+	if depth(req) {
+		for _, obj := range objects {
+			responses = append(responses, newResponse(calendarPath(username)+obj.name(), objectProps(obj, names), names))
+		}
+	}
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) propfindObject(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	names, err := readPropfind(req)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	obj, code, err := h.getObjectByName(ctx, username, req.PathValue("name"))
+	if err != nil {
+		return ctx, code, err
+	}
+	responses := []response{newResponse(calendarPath(username)+obj.name(), objectProps(obj, names), names)}
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+func collectionProps(username string) props {
+	return props{
+		propResourceType:         "<d:collection/>",
+		propCurrentUserPrincipal: href(principalPath(username)),
+	}
+}
+
+func calendarProps(username string, objects []*object) props {
+	return props{
+		propResourceType:                  "<d:collection/><c:calendar/>",
+		propDisplayName:                   "Calendar",
+		propOwner:                         href(principalPath(username)),
+		propCurrentUserPrincipal:          href(principalPath(username)),
+		propCurrentUserPrivilegeSet:       "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>",
+		propSupportedCalendarComponentSet: `<c:comp name="` + ical.CompEvent + `"/>`,
+		propSupportedReportSet: "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>",
+		propGetCTag: escape(ctag(objects)),
+	}
+}
+
+// objectProps returns the properties of the calendar object, rendering its
+// data only if requested.
+func objectProps(obj *object, names []xml.Name) props {
+	all := props{
+		propResourceType:   "",
+		propGetETag:        escape(obj.etag()),
+		propGetContentType: escape(icalendar.ContentType),
+	}
+	for _, name := range names {
+		if name == propCalendarData {
+			all[propCalendarData] = escape(obj.calendar().String())
+		}
+	}
+
+	return all
+}
+
+func (h *Handler) getObjectByName(ctx context.Context, username, name string) (*object, int, error) {
+	objects, err := listObjects(ctx, h.st, username)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	uid, ok := uidFromName(name)
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("%w: %q", errObjectNotFound, name)
+	}
+	obj := findObject(objects, uid)
+	if obj == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("%w: %q", errObjectNotFound, name)
+	}
+
+	return obj, http.StatusOK, nil
+}
@@ -0,0 +1,148 @@
+package caldav
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+var errUnsupportedReport = errors.New("unsupported report")
+
+func (h *Handler) report(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	var request requestReport
+	if err := decodeBody(req.Body, &request); err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+	names := []xml.Name(request.Prop)
+	if request.AllProp != nil {
+		names = nil
+	}
+
+	objects, err := listObjects(ctx, h.st, username)
+	if err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	var responses []response
+	switch request.XMLName {
+	case reportCalendarMultiget:
+		for _, hrefStr := range request.Hrefs {
+			obj := findObjectByHref(objects, username, hrefStr)
+			if obj == nil {
+				responses = append(responses, response{href: hrefStr, found: nil, absent: nil, status: http.StatusNotFound})
+				continue
+			}
+			responses = append(responses, newResponse(calendarPath(username)+obj.name(), objectProps(obj, names), names))
+		}
+	case reportCalendarQuery:
+		matched, err := h.query(ctx, username, objects, request.Filter)
+		if err != nil {
+			return ctx, http.StatusBadRequest, err
+		}
+		for _, obj := range matched {
+			responses = append(responses, newResponse(calendarPath(username)+obj.name(), objectProps(obj, names), names))
+		}
+	default:
+		return ctx, http.StatusForbidden, fmt.Errorf("%w: %s", errUnsupportedReport, request.XMLName.Local)
+	}
+
+	if err := writeMultistatus(res, responses); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+// query returns the objects matching the filter of a calendar-query. Only
+// the time range of VEVENT components is taken into account.
+func (h *Handler) query(ctx context.Context, username string, objects []*object, filter *filter) ([]*object, error) {
+	if filter == nil {
+		return objects, nil
+	}
+	calFilter := filter.CompFilter
+	if calFilter.Name != ical.CompCalendar {
+		return nil, nil
+	}
+	var timeRange *timeRange
+	for _, compFilter := range calFilter.CompFilters {
+		if compFilter.Name != ical.CompEvent {
+			return nil, nil
+		}
+		timeRange = compFilter.TimeRange
+	}
+	if timeRange == nil {
+		return objects, nil
+	}
+
+	start, end, err := parseTimeRange(timeRange)
+	if err != nil {
+		return nil, err
+	}
+	events, err := h.st.ListEvents(ctx, username, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	uids := make(map[string]bool)
+	for i := range events {
+		uids[icalendar.UID(&events[i])] = true
+	}
+	matched := make([]*object, 0, len(uids))
+	for _, obj := range objects {
+		if uids[obj.uid] {
+			matched = append(matched, obj)
+		}
+	}
+
+	return matched, nil
+}
+
+// parseTimeRange returns the bounds of the time range, an absent bound is
+// replaced by a distant one.
+func parseTimeRange(timeRange *timeRange) (time.Time, time.Time, error) {
+	start := time.Unix(0, 0).UTC()
+	end := time.Now().AddDate(100, 0, 0) //nolint:mnd
+	var err error
+	if timeRange.Start != "" {
+		if start, err = ical.ParseDateTime(&ical.Prop{Name: "start", Params: nil, Value: timeRange.Start}, time.UTC); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: time-range start: %w", errInvalidBody, err)
+		}
+	}
+	if timeRange.End != "" {
+		if end, err = ical.ParseDateTime(&ical.Prop{Name: "end", Params: nil, Value: timeRange.End}, time.UTC); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: time-range end: %w", errInvalidBody, err)
+		}
+	}
+
+	return start, end, nil
+}
+
+// findObjectByHref returns the object of the calendar of the user with the
+// href or nil.
+func findObjectByHref(objects []*object, username, hrefStr string) *object {
+	u, err := url.Parse(hrefStr)
+	if err != nil {
+		return nil
+	}
+	name, ok := strings.CutPrefix(u.Path, Prefix+"calendars/"+username+"/"+calendarName+"/")
+	if !ok {
+		return nil
+	}
+	uid, ok := uidFromName(name)
+	if !ok {
+		return nil
+	}
+
+	return findObject(objects, uid)
+}
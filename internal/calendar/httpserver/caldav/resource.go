@@ -0,0 +1,310 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+var (
+	errObjectNotFound     = errors.New("calendar object not found")
+	errPreconditionFailed = errors.New("precondition failed")
+	errInvalidObject      = errors.New("invalid calendar object")
+)
+
+func (h *Handler) getObject(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	obj, code, err := h.getObjectByName(ctx, username, req.PathValue("name"))
+	if err != nil {
+		return ctx, code, err
+	}
+
+	// Write iCalendar response
+	res.Header().Set("Content-Type", icalendar.ContentType)
+	res.Header().Set("ETag", obj.etag())
+	res.WriteHeader(http.StatusOK)
+	if err := ical.Encode(res, obj.calendar()); err != nil {
+		return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+	}
+
+	return ctx, http.StatusOK, nil
+}
+
+// putObject creates or replaces the calendar object. The changes are not
+// atomic: if an override cannot be stored, the series stays updated. The
+// preconditions are checked again by the storage on the first change, so
+// that of concurrent requests matching the same ETag only one succeeds.
+//
+//nolint:cyclop
+func (h *Handler) putObject(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	uid, ok := uidFromName(req.PathValue("name"))
+	if !ok {
+		return ctx, http.StatusBadRequest, fmt.Errorf("%w: resource name must end with %s", errInvalidObject, objectExt)
+	}
+
+	// Read iCalendar request
+	req.Body = http.MaxBytesReader(res, req.Body, maxObjectSize)
+	defer req.Body.Close()
+	cal, err := ical.Decode(req.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ctx, http.StatusRequestEntityTooLarge, fmt.Errorf("read body request: %w", err)
+		}
+		return ctx, http.StatusBadRequest, fmt.Errorf("decode calendar: %w", err)
+	}
+	master, overrides, err := readObject(cal, uid)
+	if err != nil {
+		return ctx, http.StatusBadRequest, err
+	}
+
+	objects, err := listObjects(ctx, h.st, username)
+	if err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+	obj := findObject(objects, uid)
+	if err := checkPreconditions(req, obj); err != nil {
+		return ctx, http.StatusPreconditionFailed, err
+	}
+
+	master.Username = username
+	status := http.StatusNoContent
+	if obj == nil {
+		status = http.StatusCreated
+		err = h.createObject(ctx, username, master, overrides)
+	} else {
+		err = h.updateObject(ctx, username, obj, master, overrides)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventChanged),
+			errors.Is(err, storage.ErrDuplicateUID) && req.Header.Get("If-None-Match") != "":
+			return ctx, http.StatusPreconditionFailed, fmt.Errorf("%w: %w", errPreconditionFailed, err)
+		case errors.Is(err, storage.ErrDateBusy), errors.Is(err, storage.ErrDuplicateUID):
+			return ctx, http.StatusConflict, err
+		case errors.Is(err, storage.ErrOccurrenceNotFound):
+			return ctx, http.StatusBadRequest, err
+		}
+		return ctx, http.StatusInternalServerError, err
+	}
+
+	if objects, err = listObjects(ctx, h.st, username); err != nil {
+		return ctx, http.StatusInternalServerError, err
+	}
+	if obj = findObject(objects, uid); obj != nil {
+		res.Header().Set("ETag", obj.etag())
+	}
+	res.WriteHeader(status)
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) deleteObject(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+	ctx, username, code, err := getUser(req)
+	if err != nil {
+		return ctx, code, err
+	}
+	obj, code, err := h.getObjectByName(ctx, username, req.PathValue("name"))
+	if err != nil {
+		return ctx, code, err
+	}
+	if err := checkPreconditions(req, obj); err != nil {
+		return ctx, http.StatusPreconditionFailed, err
+	}
+
+	// Overrides are deleted with their series. Only the events read are
+	// deleted, a change since fails the precondition.
+	for _, event := range obj.events {
+		if event.ParentID != nil && obj.master != nil {
+			continue
+		}
+		if err := h.st.DeleteEvent(ctx, username, event.ID, event.Revision); err != nil {
+			err = fmt.Errorf("delete event: %w", err)
+			if errors.Is(err, storage.ErrEventChanged) || errors.Is(err, storage.ErrEventNotFound) {
+				return ctx, http.StatusPreconditionFailed, fmt.Errorf("%w: %w", errPreconditionFailed, err)
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+	}
+	res.WriteHeader(http.StatusNoContent)
+
+	return ctx, http.StatusOK, nil
+}
+
+func (h *Handler) createObject(ctx context.Context, username string, master *storage.Event, overrides []*storage.Event) error {
+	// Clients may exclude overridden occurrences, they must exist to be overridden.
+	master.ExDates = withoutRecurrenceIDs(master.ExDates, overrides)
+	id, err := h.st.CreateEvent(ctx, master)
+	if err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+	for _, override := range overrides {
+		if err := h.st.UpdateEventOccurrence(ctx, username, id, *override.RecurrenceID, storage.ScopeThis, override); err != nil {
+			err = fmt.Errorf("update occurrence: %w", err)
+			// The object is created whole or not at all.
+			if errDelete := h.st.DeleteEvent(ctx, username, id, 0); errDelete != nil {
+				err = errors.Join(err, fmt.Errorf("delete created event: %w", errDelete))
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateObject replaces the stored object read before. The series is updated
+// first and only if it has not changed since read.
+func (h *Handler) updateObject(ctx context.Context, username string, obj *object, master *storage.Event, overrides []*storage.Event) error {
+	if obj.master == nil {
+		return fmt.Errorf("%w: %q", storage.ErrEventNotFound, obj.uid)
+	}
+	id := obj.master.ID
+	clientExDates := withoutRecurrenceIDs(master.ExDates, overrides)
+	// Kept overrides stay excluded from the series until replaced, deleted
+	// ones until deleted.
+	exDates := slices.Clone(clientExDates)
+	var deleted, restored []time.Time
+	for _, stored := range obj.events[1:] {
+		recurrenceID := *stored.RecurrenceID
+		if !slices.ContainsFunc(exDates, recurrenceID.Equal) {
+			exDates = append(exDates, recurrenceID)
+		}
+		if hasRecurrenceID(overrides, recurrenceID) {
+			continue
+		}
+		deleted = append(deleted, recurrenceID)
+		if !slices.ContainsFunc(clientExDates, recurrenceID.Equal) {
+			restored = append(restored, recurrenceID)
+		}
+	}
+
+	// Not nil ExDates replace the stored ones.
+	master.ExDates = exDates
+	master.Revision = obj.master.Revision
+	if err := h.st.UpdateEvent(ctx, username, id, master); err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	for _, recurrenceID := range deleted {
+		if err := h.st.DeleteEventOccurrence(ctx, username, id, recurrenceID, storage.ScopeThis); err != nil {
+			return fmt.Errorf("delete occurrence: %w", err)
+		}
+	}
+	// The occurrences of the deleted overrides are restored unless excluded
+	// by the client.
+	if len(restored) > 0 {
+		master.ExDates = slices.DeleteFunc(exDates, func(exDate time.Time) bool {
+			return slices.ContainsFunc(restored, exDate.Equal)
+		})
+		master.Revision = 0
+		if err := h.st.UpdateEvent(ctx, username, id, master); err != nil {
+			return fmt.Errorf("update event: %w", err)
+		}
+	}
+	for _, override := range overrides {
+		if err := h.st.UpdateEventOccurrence(ctx, username, id, *override.RecurrenceID, storage.ScopeThis, override); err != nil {
+			return fmt.Errorf("update occurrence: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readObject returns the series and the overrides of its occurrences of the
+// calendar object with the UID.
+func readObject(cal *ical.Component, uid string) (*storage.Event, []*storage.Event, error) {
+	if cal.Name != ical.CompCalendar {
+		return nil, nil, fmt.Errorf("%w: unexpected component %s", errInvalidObject, cal.Name)
+	}
+	zones := ical.ParseTimezones(cal)
+	var master *storage.Event
+	var overrides []*storage.Event
+	for _, vevent := range cal.ChildrenByName(ical.CompEvent) {
+		event, err := icalendar.Event(vevent, time.UTC, zones)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", errInvalidObject, err)
+		}
+		if event.UID != uid {
+			return nil, nil, fmt.Errorf("%w: UID %q does not match resource name", errInvalidObject, event.UID)
+		}
+		switch {
+		case event.RecurrenceID == nil && master != nil:
+			return nil, nil, fmt.Errorf("%w: several VEVENTs without RECURRENCE-ID", errInvalidObject)
+		case event.RecurrenceID == nil:
+			master = event
+		case hasRecurrenceID(overrides, *event.RecurrenceID):
+			return nil, nil, fmt.Errorf("%w: duplicate RECURRENCE-ID %s", errInvalidObject, event.RecurrenceID)
+		default:
+			overrides = append(overrides, event)
+		}
+	}
+	if master == nil {
+		return nil, nil, fmt.Errorf("%w: no VEVENT without RECURRENCE-ID", errInvalidObject)
+	}
+
+	return master, overrides, nil
+}
+
+// checkPreconditions evaluates the If-Match and If-None-Match headers
+// against the current state of the object, nil if it does not exist.
+func checkPreconditions(req *http.Request, obj *object) error {
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" {
+		if obj == nil || (ifMatch != "*" && !matchETag(ifMatch, obj.etag())) {
+			return fmt.Errorf("%w: If-Match %s", errPreconditionFailed, ifMatch)
+		}
+	}
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" && obj != nil {
+		if ifNoneMatch == "*" || matchETag(ifNoneMatch, obj.etag()) {
+			return fmt.Errorf("%w: If-None-Match %s", errPreconditionFailed, ifNoneMatch)
+		}
+	}
+
+	return nil
+}
+
+func matchETag(list, etag string) bool {
+	for candidate := range strings.SplitSeq(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasRecurrenceID(overrides []*storage.Event, recurrenceID time.Time) bool {
+	for _, override := range overrides {
+		if override.RecurrenceID.Equal(recurrenceID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutRecurrenceIDs returns the exclusions without the occurrences
+// overridden, never nil.
+func withoutRecurrenceIDs(exDates []time.Time, overrides []*storage.Event) []time.Time {
+	result := make([]time.Time, 0, len(exDates))
+	for _, exDate := range exDates {
+		if !hasRecurrenceID(overrides, exDate) {
+			result = append(result, exDate)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,224 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+)
+
+var (
+	propResourceType                  = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName                   = xml.Name{Space: nsDAV, Local: "displayname"}
+	propGetETag                       = xml.Name{Space: nsDAV, Local: "getetag"}
+	propGetContentType                = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	propCurrentUserPrincipal          = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL                  = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propOwner                         = xml.Name{Space: nsDAV, Local: "owner"}
+	propCurrentUserPrivilegeSet       = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	propSupportedReportSet            = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propCalendarHomeSet               = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	propSupportedCalendarComponentSet = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData                  = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	propGetCTag                       = xml.Name{Space: nsCS, Local: "getctag"}
+
+	reportCalendarQuery    = xml.Name{Space: nsCalDAV, Local: "calendar-query"}
+	reportCalendarMultiget = xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}
+)
+
+// prefixes are declared on the multistatus element, so that values of
+// properties can be written with them.
+var prefixes = map[string]string{
+	nsDAV:    "d",
+	nsCalDAV: "c",
+	nsCS:     "cs",
+}
+
+var errInvalidBody = errors.New("invalid request body")
+
+// propNames is the list of properties in a DAV:prop element of a request.
+type propNames []xml.Name
+
+func (p *propNames) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("read prop: %w", err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			*p = append(*p, token.Name)
+			if err := d.Skip(); err != nil {
+				return fmt.Errorf("read prop: %w", err)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type requestPropfind struct {
+	XMLName xml.Name  `xml:"DAV: propfind"`
+	AllProp *struct{} `xml:"DAV: allprop"`
+	Prop    propNames `xml:"DAV: prop"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	TimeRange   *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type filter struct {
+	CompFilter compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// requestReport is either a calendar-query or a calendar-multiget report.
+type requestReport struct {
+	XMLName xml.Name
+	AllProp *struct{} `xml:"DAV: allprop"`
+	Prop    propNames `xml:"DAV: prop"`
+	Hrefs   []string  `xml:"DAV: href"`
+	Filter  *filter   `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+// decodeBody decodes the XML request body into v. An empty body leaves v
+// unchanged.
+func decodeBody(body io.Reader, v any) error {
+	err := xml.NewDecoder(body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidBody, err)
+	}
+
+	return nil
+}
+
+// props maps the names of the properties of a resource to their values as
+// XML written with prefixes.
+type props map[xml.Name]string
+
+// response is a DAV:response element of a multistatus.
+type response struct {
+	href   string
+	found  props
+	absent []xml.Name
+	status int
+}
+
+// newResponse selects the requested properties of the resource. If names is
+// nil, all properties except calendar-data are selected.
+func newResponse(href string, all props, names []xml.Name) response {
+	resp := response{href: href, found: make(props), absent: nil, status: 0}
+	if names == nil {
+		for name, value := range all {
+			if name != propCalendarData {
+				resp.found[name] = value
+			}
+		}
+		return resp
+	}
+	for _, name := range names {
+		if value, ok := all[name]; ok {
+			resp.found[name] = value
+		} else {
+			resp.absent = append(resp.absent, name)
+		}
+	}
+
+	return resp
+}
+
+func writeMultistatus(res http.ResponseWriter, responses []response) error {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="` + nsCalDAV + `" xmlns:cs="` + nsCS + `">`)
+	for _, resp := range responses {
+		sb.WriteString("<d:response>")
+		sb.WriteString(href(resp.href))
+		if resp.status != 0 {
+			sb.WriteString("<d:status>" + statusLine(resp.status) + "</d:status>")
+		}
+		if len(resp.found) > 0 {
+			names := make([]xml.Name, 0, len(resp.found))
+			for name := range resp.found {
+				names = append(names, name)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				return names[i].Space+names[i].Local < names[j].Space+names[j].Local
+			})
+			sb.WriteString("<d:propstat><d:prop>")
+			for _, name := range names {
+				writeProp(&sb, name, resp.found[name])
+			}
+			sb.WriteString("</d:prop><d:status>" + statusLine(http.StatusOK) + "</d:status></d:propstat>")
+		}
+		if len(resp.absent) > 0 {
+			sb.WriteString("<d:propstat><d:prop>")
+			for _, name := range resp.absent {
+				writeProp(&sb, name, "")
+			}
+			sb.WriteString("</d:prop><d:status>" + statusLine(http.StatusNotFound) + "</d:status></d:propstat>")
+		}
+		sb.WriteString("</d:response>")
+	}
+	sb.WriteString("</d:multistatus>")
+
+	res.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	res.WriteHeader(http.StatusMultiStatus)
+	if _, err := io.WriteString(res, sb.String()); err != nil {
+		return fmt.Errorf("write multistatus: %w", err)
+	}
+
+	return nil
+}
+
+func writeProp(sb *strings.Builder, name xml.Name, value string) {
+	qname := name.Local
+	switch prefix, ok := prefixes[name.Space]; {
+	case ok:
+		qname = prefix + ":" + name.Local
+		sb.WriteString("<" + qname)
+	case name.Space == "":
+		sb.WriteString("<" + qname)
+	default:
+		qname = "x:" + name.Local
+		sb.WriteString("<" + qname + ` xmlns:x="` + escape(name.Space) + `"`)
+	}
+	if value == "" {
+		sb.WriteString("/>")
+		return
+	}
+	sb.WriteString(">" + value + "</" + qname + ">")
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func href(path string) string {
+	return "<d:href>" + escape(path) + "</d:href>"
+}
+
+func escape(text string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(text)) //nolint:errcheck
+
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mrvin/calendar/internal/sharing"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// accessStatus returns the status of the response to a user who may not
+// access the events of another one.
+func accessStatus(err error) int {
+	switch {
+	case errors.Is(err, sharing.ErrAccessDenied):
+		return http.StatusForbidden
+	case errors.Is(err, storage.ErrEventNotFound), errors.Is(err, storage.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// checkAdmin returns nil if the user may manage what all users share, such
+// as resources, and the status of the response otherwise.
+func checkAdmin(ctx context.Context, getter UserGetter, username string) (int, error) {
+	user, err := getter.GetUser(ctx, username)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("getting user from storage: %w", err)
+	}
+	if user.Role != "admin" {
+		return http.StatusForbidden, fmt.Errorf("%w: admin role needed", sharing.ErrAccessDenied)
+	}
+
+	return http.StatusOK, nil
+}
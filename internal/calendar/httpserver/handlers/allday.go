@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// eventTimes returns the bounds of the event in the request. An all-day
+// event is given by dates: end_date is its last day, start_date by default.
+func eventTimes(allDay bool, startTime, endTime time.Time, startDate, endDate string) (time.Time, time.Time, error) {
+	if !allDay {
+		if startTime.After(endTime) {
+			return time.Time{}, time.Time{}, errors.New("start_time must be before or equal to end_time")
+		}
+		return startTime, endTime, nil
+	}
+
+	start, err := time.Parse(time.DateOnly, startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse start_date: %w", err)
+	}
+	end := start
+	if endDate != "" {
+		if end, err = time.Parse(time.DateOnly, endDate); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse end_date: %w", err)
+		}
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, errors.New("start_date must be before or equal to end_date")
+	}
+
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// eventDates returns the first and the last day of an all-day event.
+func eventDates(event *storage.Event) (string, string) {
+	if !event.AllDay {
+		return "", ""
+	}
+
+	return event.StartTime.Format(time.DateOnly), event.EndTime.AddDate(0, 0, -1).Format(time.DateOnly)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import "github.com/mrvin/calendar/internal/storage"
+
+// RequestAttendee invites a user by username or anyone else by email.
+type RequestAttendee struct {
+	Username string `json:"username,omitempty" validate:"required_without=Email,omitempty,min=3,max=20"`
+	Email    string `json:"email,omitempty"    validate:"required_without=Username,excluded_with=Username,omitempty,email"`
+}
+
+// toAttendees returns nil, keeping the attendees of an updated event,
+// if the request has none.
+func toAttendees(request []RequestAttendee) []storage.Attendee {
+	if request == nil {
+		return nil
+	}
+	attendees := make([]storage.Attendee, len(request))
+	for i, attendee := range request {
+		attendees[i] = storage.Attendee{Username: attendee.Username, Email: attendee.Email, Status: "", Availability: ""}
+	}
+
+	return attendees
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrvin/calendar/internal/booking"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type SlotBooker interface {
+	booking.Booker
+	GetBookingLinkBySlug(ctx context.Context, slug string) (*storage.BookingLink, error)
+}
+
+//nolint:tagliatelle
+type RequestBook struct {
+	StartTime time.Time `json:"start_time"     validate:"required"`
+	Name      string    `json:"name"           validate:"required,min=1,max=128"`
+	Email     string    `json:"email"          validate:"required,email"`
+	Note      string    `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+type ResponseBook struct {
+	freebusy.Interval
+	Status string `json:"status"`
+}
+
+// NewBook books the slot of the booking link for the customer, who is
+// invited to the event created for the owner of the link. No account is
+// needed, the bookings are bounded by limit.
+func NewBook(booker SlotBooker, opts storage.Options, limit *booking.RateLimit) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		link, err := booker.GetBookingLinkBySlug(ctx, req.PathValue("slug"))
+		if err != nil {
+			err = fmt.Errorf("getting booking link from storage: %w", err)
+			if errors.Is(err, storage.ErrBookingLinkNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+		ctx = logger.WithUsername(ctx, link.Username)
+
+		// Read json request
+		var request RequestBook
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		customer := booking.Customer{Name: request.Name, Email: request.Email, Note: request.Note}
+		now := time.Now()
+		client, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			client = req.RemoteAddr
+		}
+		if err := limit.Allow(client, &customer, now); err != nil {
+			return ctx, http.StatusTooManyRequests, fmt.Errorf("book slot: %w", err)
+		}
+		event, err := booking.Book(ctx, booker, opts, link, request.StartTime, &customer, now)
+		if err != nil {
+			err = fmt.Errorf("book slot: %w", err)
+			if errors.Is(err, booking.ErrSlotNotAvailable) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseBook{
+			Interval: freebusy.Interval{Start: event.StartTime, End: event.EndTime},
+			Status:   "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mrvin/calendar/internal/booking"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type BookingPageSource interface {
+	freebusy.Source
+	GetBookingLinkBySlug(ctx context.Context, slug string) (*storage.BookingLink, error)
+}
+
+type ResponseBookingPage struct {
+	Title    string              `json:"title"`
+	Duration time.Duration       `json:"duration"`
+	Slots    []freebusy.Interval `json:"slots"`
+	Status   string              `json:"status"`
+}
+
+// NewGetBookingPage lists the open slots of the booking link, within the
+// optional start_time and end_time. No account is needed.
+func NewGetBookingPage(src BookingPageSource, opts storage.Options) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		link, err := src.GetBookingLinkBySlug(ctx, req.PathValue("slug"))
+		if err != nil {
+			err = fmt.Errorf("getting booking link from storage: %w", err)
+			if errors.Is(err, storage.ErrBookingLinkNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+		ctx = logger.WithUsername(ctx, link.Username)
+
+		now := time.Now()
+		start, end := now, now.Add(link.Lookahead)
+		if startStr := req.URL.Query().Get("start_time"); startStr != "" {
+			if start, err = time.Parse(time.RFC3339, startStr); err != nil {
+				return ctx, http.StatusBadRequest, errors.New("invalid start_time format, use RFC3339")
+			}
+		}
+		if endStr := req.URL.Query().Get("end_time"); endStr != "" {
+			if end, err = time.Parse(time.RFC3339, endStr); err != nil {
+				return ctx, http.StatusBadRequest, errors.New("invalid end_time format, use RFC3339")
+			}
+		}
+		slots, err := booking.Slots(ctx, src, opts, link, start, end, now)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("find slots: %w", err)
+		}
+
+		// Write json response
+		response := ResponseBookingPage{
+			Title:    link.Title,
+			Duration: link.Duration,
+			Slots:    make([]freebusy.Interval, 0, len(slots)),
+			Status:   "OK",
+		}
+		for _, slot := range slots {
+			response.Slots = append(response.Slots, freebusy.Interval{Start: slot, End: slot.Add(link.Duration)})
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/booking"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type BookingLinkCreator interface {
+	CreateBookingLink(ctx context.Context, link *storage.BookingLink) (uuid.UUID, error)
+}
+
+//nolint:tagliatelle
+type RequestBookingLink struct {
+	Slug      string        `json:"slug"                validate:"required,min=3,max=64"`
+	Title     string        `json:"title"               validate:"required,min=1,max=128"`
+	Duration  time.Duration `json:"duration"            validate:"required,gt=0"`
+	Buffer    time.Duration `json:"buffer,omitempty"    validate:"omitempty,gte=0"`
+	DayStart  string        `json:"day_start"           validate:"required"`
+	DayEnd    string        `json:"day_end"             validate:"required"`
+	Weekdays  []string      `json:"weekdays,omitempty"  validate:"omitempty,unique,dive,oneof=MO TU WE TH FR SA SU"`
+	TimeZone  string        `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	Lookahead time.Duration `json:"lookahead"           validate:"required,gt=0"`
+}
+
+type ResponseCreateBookingLink struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+func NewCreateBookingLink(creator BookingLinkCreator) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		link, status, err := readRequestBookingLink(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+		link.Username = username
+
+		id, err := creator.CreateBookingLink(ctx, link)
+		if err != nil {
+			err = fmt.Errorf("saving booking link to storage: %w", err)
+			if errors.Is(err, storage.ErrBookingLinkExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseCreateBookingLink{
+			ID:     id,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+// readRequestBookingLink reads and validates the booking link in the body of
+// the request, it returns the status of the response on error.
+func readRequestBookingLink(req *http.Request, validate *validator.Validate) (*storage.BookingLink, int, error) {
+	var request RequestBookingLink
+	body, err := io.ReadAll(req.Body)
+	defer req.Body.Close()
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+	}
+
+	// Validation
+	if err := validate.Struct(request); err != nil {
+		var vErrors validator.ValidationErrors
+		if errors.As(err, &vErrors) {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+	}
+
+	//nolint:exhaustruct
+	link := storage.BookingLink{
+		Slug:      request.Slug,
+		Title:     request.Title,
+		Duration:  request.Duration,
+		Buffer:    request.Buffer,
+		DayStart:  request.DayStart,
+		DayEnd:    request.DayEnd,
+		Weekdays:  request.Weekdays,
+		TimeZone:  request.TimeZone,
+		Lookahead: request.Lookahead,
+	}
+	if err := booking.CheckLink(&link); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return &link, http.StatusOK, nil
+}
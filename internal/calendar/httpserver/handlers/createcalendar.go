@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type CalendarCreator interface {
+	CreateCalendar(ctx context.Context, calendar *storage.Calendar) (uuid.UUID, error)
+}
+
+//nolint:tagliatelle
+type RequestCalendar struct {
+	Name         string         `json:"name"                    validate:"required,min=1,max=64"`
+	Color        string         `json:"color,omitempty"         validate:"omitempty,hexcolor"`
+	NotifyBefore *time.Duration `json:"notify_before,omitempty" validate:"omitempty"`
+}
+
+type ResponseCreateCalendar struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+func NewCreateCalendar(creator CalendarCreator) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		request, status, err := readRequestCalendar(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+
+		//nolint:exhaustruct
+		calendar := storage.Calendar{
+			Name:         request.Name,
+			Color:        request.Color,
+			NotifyBefore: request.NotifyBefore,
+			Username:     username,
+		}
+		id, err := creator.CreateCalendar(ctx, &calendar)
+		if err != nil {
+			err = fmt.Errorf("saving calendar to storage: %w", err)
+			if errors.Is(err, storage.ErrCalendarExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseCreateCalendar{
+			ID:     id,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+// readRequestCalendar reads and validates the calendar in the body of the
+// request, it returns the status of the response on error.
+func readRequestCalendar(req *http.Request, validate *validator.Validate) (*RequestCalendar, int, error) {
+	var request RequestCalendar
+	body, err := io.ReadAll(req.Body)
+	defer req.Body.Close()
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+	}
+
+	// Validation
+	if err := validate.Struct(request); err != nil {
+		var vErrors validator.ValidationErrors
+		if errors.As(err, &vErrors) {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+	}
+
+	return &request, http.StatusOK, nil
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+const feedTokenSize = 32 // in bytes
+
+type FeedCreator interface {
+	CreateFeed(ctx context.Context, feed *storage.Feed) (uuid.UUID, error)
+}
+
+type RequestCreateFeed struct {
+	Name string `json:"name,omitempty" validate:"omitempty,max=64"`
+}
+
+// ResponseFeedToken is returned when a token is issued, the only time it
+// is shown.
+type ResponseFeedToken struct {
+	ID     uuid.UUID `json:"id"`
+	Token  string    `json:"token"`
+	URL    string    `json:"url"`
+	Status string    `json:"status"`
+}
+
+func NewCreateFeed(creator FeedCreator) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request, the body is optional
+		var request RequestCreateFeed
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if len(body) != 0 {
+			if err := json.Unmarshal(body, &request); err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+			}
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		token, err := newFeedToken()
+		if err != nil {
+			return ctx, http.StatusInternalServerError, err
+		}
+		//nolint:exhaustruct
+		feed := storage.Feed{
+			Name:      request.Name,
+			TokenHash: hashFeedToken(token),
+			Username:  username,
+		}
+		id, err := creator.CreateFeed(ctx, &feed)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("saving feed to storage: %w", err)
+		}
+
+		// Write json response
+		if err := writeFeedToken(res, http.StatusCreated, id, token); err != nil {
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, feedTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate feed token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFeedToken(res http.ResponseWriter, status int, id uuid.UUID, token string) error {
+	response := ResponseFeedToken{
+		ID:     id,
+		Token:  token,
+		URL:    "/api/feeds/" + token + ".ics",
+		Status: "OK",
+	}
+	jsonResponse, err := json.Marshal(&response)
+	if err != nil {
+		return fmt.Errorf("marshal response: %w", err)
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	if _, err := res.Write(jsonResponse); err != nil {
+		return fmt.Errorf("write response: %w", err)
+	}
+
+	return nil
+}
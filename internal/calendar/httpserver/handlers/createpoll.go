@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/poll"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type PollCreator interface {
+	CreatePoll(ctx context.Context, poll *storage.Poll) (uuid.UUID, error)
+}
+
+//nolint:tagliatelle
+type RequestPollOption struct {
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time"   validate:"required,gtfield=StartTime"`
+}
+
+type RequestCreatePoll struct {
+	Title       string              `json:"title"                 validate:"required,min=2,max=64"`
+	Description string              `json:"description,omitempty" validate:"omitempty,min=2,max=512"`
+	Options     []RequestPollOption `json:"options"               validate:"required,min=2,max=20,dive"`
+	Invitees    []string            `json:"invitees"              validate:"required,min=1,max=100,unique,dive,required,max=64"`
+}
+
+type ResponseCreatePoll struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+func NewCreatePoll(creator PollCreator) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request
+		var request RequestCreatePoll
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		//nolint:exhaustruct
+		newPoll := storage.Poll{
+			Title:       request.Title,
+			Description: request.Description,
+			Options:     make([]storage.PollOption, len(request.Options)),
+			Invitees:    request.Invitees,
+			Username:    username,
+		}
+		for i, option := range request.Options {
+			newPoll.Options[i] = storage.PollOption{StartTime: option.StartTime, EndTime: option.EndTime} //nolint:exhaustruct
+		}
+		if err := poll.Check(&newPoll); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+		}
+
+		id, err := creator.CreatePoll(ctx, &newPoll)
+		if err != nil {
+			err = fmt.Errorf("saving poll to storage: %w", err)
+			if errors.Is(err, storage.ErrUserNotFound) {
+				return ctx, http.StatusBadRequest, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseCreatePoll{
+			ID:     id,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
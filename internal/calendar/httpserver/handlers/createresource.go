@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type ResourceCreator interface {
+	UserGetter
+	CreateResource(ctx context.Context, resource *storage.Resource) (uuid.UUID, error)
+}
+
+type RequestResource struct {
+	Name       string            `json:"name"                 validate:"required,min=1,max=64"`
+	Kind       string            `json:"kind"                 validate:"required,min=1,max=32"`
+	Capacity   int               `json:"capacity,omitempty"   validate:"min=0"`
+	Attributes map[string]string `json:"attributes,omitempty" validate:"omitempty,max=32,dive,keys,min=1,max=64,endkeys,max=256"`
+}
+
+type ResponseCreateResource struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+// NewCreateResource adds a resource bookable by all users, only admins may.
+func NewCreateResource(creator ResourceCreator) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+		if status, err := checkAdmin(ctx, creator, username); err != nil {
+			return ctx, status, err
+		}
+
+		request, status, err := readRequestResource(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+
+		//nolint:exhaustruct
+		resource := storage.Resource{
+			Name:       request.Name,
+			Kind:       request.Kind,
+			Capacity:   request.Capacity,
+			Attributes: request.Attributes,
+		}
+		id, err := creator.CreateResource(ctx, &resource)
+		if err != nil {
+			err = fmt.Errorf("saving resource to storage: %w", err)
+			if errors.Is(err, storage.ErrResourceExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseCreateResource{
+			ID:     id,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+// readRequestResource reads and validates the resource in the body of the
+// request, it returns the status of the response on error.
+func readRequestResource(req *http.Request, validate *validator.Validate) (*RequestResource, int, error) {
+	var request RequestResource
+	body, err := io.ReadAll(req.Body)
+	defer req.Body.Close()
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+	}
+
+	// Validation
+	if err := validate.Struct(request); err != nil {
+		var vErrors validator.ValidationErrors
+		if errors.As(err, &vErrors) {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+	}
+
+	return &request, http.StatusOK, nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type CalendarDeleter interface {
+	DeleteCalendar(ctx context.Context, username string, id uuid.UUID) error
+}
+
+// NewDeleteCalendar deletes the calendar with its events.
+func NewDeleteCalendar(deleter CalendarDeleter) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		if err := deleter.DeleteCalendar(ctx, username, id); err != nil {
+			err = fmt.Errorf("deleting calendar from storage: %w", err)
+			if errors.Is(err, storage.ErrCalendarNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusNoContent)
+
+		return ctx, http.StatusNoContent, nil
+	}
+}
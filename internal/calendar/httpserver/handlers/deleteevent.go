@@ -5,16 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/sharing"
 	"github.com/mrvin/calendar/internal/storage"
 	httpresponse "github.com/mrvin/calendar/pkg/http/response"
 )
 
 type EventDeleter interface {
-	DeleteEvent(ctx context.Context, username string, id uuid.UUID) error
+	sharing.EventSource
+	DeleteEvent(ctx context.Context, username string, id uuid.UUID, revision int64) error
+	DeleteEventOccurrence(ctx context.Context, username string, id uuid.UUID, recurrenceID time.Time, scope storage.Scope) error
 }
 
 func NewDeleteEvent(deleter EventDeleter) HandlerFunc {
@@ -26,6 +30,10 @@ func NewDeleteEvent(deleter EventDeleter) HandlerFunc {
 		if err != nil {
 			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
 		}
+		recurrenceID, scope, err := parseOccurrence(req)
+		if err != nil {
+			return ctx, http.StatusBadRequest, err
+		}
 
 		username, err := auth.GetUsernameFromCtx(ctx)
 		if err != nil {
@@ -33,9 +41,18 @@ func NewDeleteEvent(deleter EventDeleter) HandlerFunc {
 		}
 		ctx = logger.WithUsername(ctx, username)
 
-		if err := deleter.DeleteEvent(ctx, username, id); err != nil {
+		owner, err := sharing.EventOwner(ctx, deleter, username, id, storage.RoleEditor)
+		if err != nil {
+			return ctx, accessStatus(err), err
+		}
+		if recurrenceID == nil {
+			err = deleter.DeleteEvent(ctx, owner, id, 0)
+		} else {
+			err = deleter.DeleteEventOccurrence(ctx, owner, id, *recurrenceID, scope)
+		}
+		if err != nil {
 			err = fmt.Errorf("deleting event from storage: %w", err)
-			if errors.Is(err, storage.ErrEventNotFound) {
+			if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrOccurrenceNotFound) {
 				return ctx, http.StatusNotFound, err
 			}
 			return ctx, http.StatusInternalServerError, err
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type GrantDeleter interface {
+	DeleteGrant(ctx context.Context, owner, grantee string) error
+}
+
+func NewDeleteGrant(deleter GrantDeleter) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		grantee := req.PathValue("username")
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		if err := deleter.DeleteGrant(ctx, username, grantee); err != nil {
+			err = fmt.Errorf("deleting grant from storage: %w", err)
+			if errors.Is(err, storage.ErrGrantNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusNoContent)
+
+		return ctx, http.StatusNoContent, nil
+	}
+}
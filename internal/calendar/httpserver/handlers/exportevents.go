@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+func NewExportEvents(src icalendar.EventSource) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		start, end := icalendar.DefaultWindow(time.Now())
+		if startStr := req.URL.Query().Get("start_time"); startStr != "" {
+			if start, err = time.Parse(time.RFC3339, startStr); err != nil {
+				return ctx, http.StatusBadRequest, errors.New("invalid start_time format, use RFC3339")
+			}
+		}
+		if endStr := req.URL.Query().Get("end_time"); endStr != "" {
+			if end, err = time.Parse(time.RFC3339, endStr); err != nil {
+				return ctx, http.StatusBadRequest, errors.New("invalid end_time format, use RFC3339")
+			}
+		}
+		if start.After(end) {
+			return ctx, http.StatusBadRequest, errors.New("start_time must be before or equal to end_time")
+		}
+
+		cal, err := icalendar.Export(ctx, src, username, start, end)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("export events: %w", err)
+		}
+
+		// Write iCalendar response
+		res.Header().Set("Content-Type", icalendar.ContentType)
+		res.Header().Set("Content-Disposition", `attachment; filename="calendar.ics"`)
+		res.WriteHeader(http.StatusOK)
+		if err := ical.Encode(res, cal); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestFeed(t *testing.T) {
+	st := memory.New()
+	ctx := logger.WithUsername(context.Background(), "bob")
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	//nolint:exhaustruct
+	if _, err := st.CreateEvent(ctx, &storage.Event{Title: "Dentist", StartTime: start, EndTime: start.Add(time.Hour), Username: "bob"}); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodPost+" /api/auth/me/feeds", ErrorHandler("Create feed", NewCreateFeed(st)))
+	mux.HandleFunc(http.MethodPost+" /api/auth/me/feeds/{id}/rotate", ErrorHandler("Rotate feed", NewRotateFeed(st)))
+	mux.HandleFunc(http.MethodDelete+" /api/auth/me/feeds/{id}", ErrorHandler("Delete feed", NewDeleteFeed(st)))
+	mux.HandleFunc(http.MethodGet+" /api/feeds/{file}", ErrorHandler("Get feed", NewGetFeed(st, st)))
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("create new request: %v", err)
+		}
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		return res
+	}
+
+	res := do(http.MethodPost, "/api/auth/me/feeds", `{"name":"Work"}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("create feed: %d %s", res.Code, res.Body)
+	}
+	var feed ResponseFeedToken
+	if err := json.Unmarshal(res.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+
+	res = do(http.MethodGet, feed.URL, "")
+	if res.Code != http.StatusOK || !strings.Contains(res.Body.String(), "SUMMARY:Dentist") ||
+		!strings.Contains(res.Body.String(), "X-WR-CALNAME:Work") {
+		t.Fatalf("get feed: %d %s", res.Code, res.Body)
+	}
+
+	res = do(http.MethodPost, "/api/auth/me/feeds/"+feed.ID.String()+"/rotate", "")
+	var rotated ResponseFeedToken
+	if err := json.Unmarshal(res.Body.Bytes(), &rotated); err != nil || rotated.Token == feed.Token {
+		t.Fatalf("rotate feed: %d %s", res.Code, res.Body)
+	}
+	if res := do(http.MethodGet, feed.URL, ""); res.Code != http.StatusNotFound {
+		t.Errorf("get feed by old token: expected %d, got %d", http.StatusNotFound, res.Code)
+	}
+	if res := do(http.MethodGet, rotated.URL, ""); res.Code != http.StatusOK {
+		t.Errorf("get feed by new token: expected %d, got %d", http.StatusOK, res.Code)
+	}
+
+	if res := do(http.MethodDelete, "/api/auth/me/feeds/"+feed.ID.String(), ""); res.Code != http.StatusNoContent {
+		t.Fatalf("delete feed: %d %s", res.Code, res.Body)
+	}
+	if res := do(http.MethodGet, rotated.URL, ""); res.Code != http.StatusNotFound {
+		t.Errorf("get revoked feed: expected %d, got %d", http.StatusNotFound, res.Code)
+	}
+}
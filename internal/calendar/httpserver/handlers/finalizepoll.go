@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/poll"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+//nolint:tagliatelle
+type RequestFinalizePoll struct {
+	// OptionID is the chosen option, the winner of the votes if nil.
+	OptionID *uuid.UUID `json:"option_id,omitempty"`
+}
+
+//nolint:tagliatelle
+type ResponseFinalizePoll struct {
+	EventID uuid.UUID `json:"event_id"`
+	// Conflicts are the busy events overlapped by the event if the conflict
+	// policy of the user is warn.
+	Conflicts []uuid.UUID `json:"conflicts,omitempty"`
+	Status    string      `json:"status"`
+}
+
+// NewFinalizePoll creates the event of the chosen or winning option of the
+// poll and closes it. The invitees are invited to the event.
+func NewFinalizePoll(finalizer poll.Finalizer) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request, the body is optional.
+		var request RequestFinalizePoll
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if len(body) != 0 {
+			if err := json.Unmarshal(body, &request); err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+			}
+		}
+
+		event, err := poll.Finalize(ctx, finalizer, username, id, request.OptionID)
+		if err != nil {
+			err = fmt.Errorf("finalize poll: %w", err)
+			switch {
+			case errors.Is(err, storage.ErrPollNotFound):
+				return ctx, http.StatusNotFound, err
+			case errors.Is(err, poll.ErrNotOrganizer):
+				return ctx, http.StatusForbidden, err
+			case errors.Is(err, storage.ErrPollOptionNotFound):
+				return ctx, http.StatusBadRequest, err
+			case errors.Is(err, storage.ErrPollFinalized), errors.Is(err, poll.ErrNoWinner), errors.Is(err, storage.ErrDateBusy):
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseFinalizePoll{
+			EventID:   event.ID,
+			Conflicts: event.Conflicts,
+			Status:    "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusCreated)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
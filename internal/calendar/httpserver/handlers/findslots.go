@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+//nolint:tagliatelle
+type RequestWorkingHours struct {
+	Start    string   `json:"start"               validate:"required"`
+	End      string   `json:"end"                 validate:"required"`
+	Weekdays []string `json:"weekdays,omitempty"  validate:"omitempty,unique,dive,oneof=MO TU WE TH FR SA SU"`
+	TimeZone string   `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+}
+
+//nolint:tagliatelle
+type RequestFindSlots struct {
+	Usernames    []string             `json:"usernames"               validate:"required,min=1,max=50,unique,dive,required"`
+	Duration     time.Duration        `json:"duration"                validate:"required,gt=0"`
+	StartTime    time.Time            `json:"start_time"              validate:"required"`
+	EndTime      time.Time            `json:"end_time"                validate:"required,gtfield=StartTime"`
+	WorkingHours *RequestWorkingHours `json:"working_hours,omitempty" validate:"omitempty"`
+	Limit        int                  `json:"limit,omitempty"         validate:"omitempty,min=1,max=50"`
+}
+
+type ResponseFindSlots struct {
+	Slots  []freebusy.Interval `json:"slots"`
+	Status string              `json:"status"`
+}
+
+func NewFindSlots(src freebusy.Source, opts storage.Options) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request
+		var request RequestFindSlots
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		query := freebusy.SlotQuery{
+			Usernames:    request.Usernames,
+			Duration:     request.Duration,
+			Start:        request.StartTime,
+			End:          request.EndTime,
+			WorkingHours: nil,
+			Buffer:       0,
+			Limit:        request.Limit,
+		}
+		if request.WorkingHours != nil {
+			if query.WorkingHours, err = workingHours(request.WorkingHours); err != nil {
+				return ctx, http.StatusBadRequest, err
+			}
+		}
+		slots, err := freebusy.FindSlots(ctx, src, opts, username, &query)
+		if err != nil {
+			err = fmt.Errorf("find slots: %w", err)
+			switch {
+			case errors.Is(err, freebusy.ErrInvalidSlotQuery):
+				return ctx, http.StatusBadRequest, err
+			case errors.Is(err, freebusy.ErrNotAvailable):
+				return ctx, http.StatusForbidden, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseFindSlots{
+			Slots:  make([]freebusy.Interval, 0, len(slots)),
+			Status: "OK",
+		}
+		for _, start := range slots {
+			response.Slots = append(response.Slots, freebusy.Interval{Start: start, End: start.Add(request.Duration)})
+		}
+		jsonResponse, err := json.Marshal(response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+func workingHours(request *RequestWorkingHours) (*freebusy.WorkingHours, error) {
+	start, err := freebusy.ParseClock(request.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: working hours start: %w", err)
+	}
+	end, err := freebusy.ParseClock(request.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: working hours end: %w", err)
+	}
+	weekdays, err := freebusy.ParseWeekdays(request.Weekdays)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	wh := freebusy.WorkingHours{Start: start, End: end, Weekdays: weekdays, Location: nil}
+	if request.TimeZone != "" {
+		if wh.Location, err = storage.LoadLocation(request.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+
+	return &wh, nil
+}
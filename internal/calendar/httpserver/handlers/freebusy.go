@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/freebusy"
+	"github.com/mrvin/calendar/internal/logger"
+)
+
+//nolint:tagliatelle
+type RequestFreeBusy struct {
+	Usernames []string  `json:"usernames"  validate:"required,min=1,max=50,unique,dive,required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time"   validate:"required,gtfield=StartTime"`
+}
+
+type UserFreeBusy struct {
+	Username string              `json:"username"`
+	Busy     []freebusy.Interval `json:"busy"`
+	Error    string              `json:"error,omitempty"`
+}
+
+type ResponseFreeBusy struct {
+	Users []UserFreeBusy `json:"users"`
+	// Busy is the time when any of the users is busy.
+	Busy   []freebusy.Interval `json:"busy"`
+	Status string              `json:"status"`
+}
+
+func NewFreeBusy(src freebusy.Source) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request
+		var request RequestFreeBusy
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+		if request.EndTime.Sub(request.StartTime) > freebusy.MaxRange {
+			return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: time range longer than %s", freebusy.MaxRange)
+		}
+
+		users, err := freebusy.Query(ctx, src, username, request.Usernames, request.StartTime, request.EndTime)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("query free/busy: %w", err)
+		}
+
+		// Write json response
+		response := ResponseFreeBusy{
+			Users:  make([]UserFreeBusy, 0, len(users)),
+			Busy:   freebusy.MergeUsers(users),
+			Status: "OK",
+		}
+		for _, user := range users {
+			userFreeBusy := UserFreeBusy{Username: user.Username, Busy: user.Busy, Error: ""}
+			if user.Err != nil {
+				userFreeBusy.Error = user.Err.Error()
+			}
+			response.Users = append(response.Users, userFreeBusy)
+		}
+		jsonResponse, err := json.Marshal(response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -11,22 +11,38 @@ import (
 	"github.com/google/uuid"
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/sharing"
 	"github.com/mrvin/calendar/internal/storage"
 )
 
 type EventGetter interface {
+	sharing.EventSource
 	GetEvent(ctx context.Context, username string, id uuid.UUID) (*storage.Event, error)
 }
 
 //nolint:tagliatelle
 type ResponseGetEvent struct {
-	ID           uuid.UUID      `json:"id"`
-	Title        string         `json:"title"`
-	Description  string         `json:"description,omitempty"`
-	StartTime    time.Time      `json:"start_time"`
-	EndTime      time.Time      `json:"end_time"`
-	NotifyBefore *time.Duration `json:"notify_before,omitempty"`
-	Status       string         `json:"status"`
+	ID           uuid.UUID          `json:"id"`
+	Title        string             `json:"title"`
+	Description  string             `json:"description,omitempty"`
+	StartTime    time.Time          `json:"start_time"`
+	EndTime      time.Time          `json:"end_time"`
+	AllDay       bool               `json:"all_day,omitempty"`
+	StartDate    string             `json:"start_date,omitempty"`
+	EndDate      string             `json:"end_date,omitempty"`
+	TimeZone     string             `json:"time_zone,omitempty"`
+	Transparency string             `json:"transparency,omitempty"`
+	Reminders    []storage.Reminder `json:"reminders,omitempty"`
+	RRule        string             `json:"rrule,omitempty"`
+	ExDates      []time.Time        `json:"exdates,omitempty"`
+	ParentID     *uuid.UUID         `json:"parent_id,omitempty"`
+	RecurrenceID *time.Time         `json:"recurrence_id,omitempty"`
+	UID          string             `json:"uid,omitempty"`
+	CalendarID   *uuid.UUID         `json:"calendar_id,omitempty"`
+	Resources    []uuid.UUID        `json:"resources,omitempty"`
+	Organizer    string             `json:"organizer,omitempty"`
+	Attendees    []storage.Attendee `json:"attendees,omitempty"`
+	Status       string             `json:"status"`
 }
 
 func NewGetEvent(getter EventGetter) HandlerFunc {
@@ -45,7 +61,11 @@ func NewGetEvent(getter EventGetter) HandlerFunc {
 		}
 		ctx = logger.WithUsername(ctx, username)
 
-		event, err := getter.GetEvent(ctx, username, id)
+		owner, err := sharing.EventOwner(ctx, getter, username, id, storage.RoleViewer)
+		if err != nil {
+			return ctx, accessStatus(err), err
+		}
+		event, err := getter.GetEvent(ctx, owner, id)
 		if err != nil {
 			err = fmt.Errorf("getting event from storage: %w", err)
 			if errors.Is(err, storage.ErrEventNotFound) {
@@ -55,13 +75,28 @@ func NewGetEvent(getter EventGetter) HandlerFunc {
 		}
 
 		// Write json response
+		startDate, endDate := eventDates(event)
 		response := ResponseGetEvent{
 			ID:           event.ID,
 			Title:        event.Title,
 			Description:  event.Description,
 			StartTime:    event.StartTime,
 			EndTime:      event.EndTime,
-			NotifyBefore: event.NotifyBefore,
+			AllDay:       event.AllDay,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			TimeZone:     event.TimeZone,
+			Transparency: string(event.Transparency),
+			Reminders:    event.Reminders,
+			RRule:        event.RRule,
+			ExDates:      event.ExDates,
+			ParentID:     event.ParentID,
+			RecurrenceID: event.RecurrenceID,
+			UID:          event.UID,
+			CalendarID:   event.CalendarID,
+			Resources:    event.Resources,
+			Organizer:    event.Organizer,
+			Attendees:    event.Attendees,
 			Status:       "OK",
 		}
 		jsonResponseEvent, err := json.Marshal(response)
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+type FeedGetter interface {
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*storage.Feed, error)
+}
+
+// NewGetFeed serves the events of the owner of the feed token around the
+// current time. The token is the only credential.
+func NewGetFeed(getter FeedGetter, src icalendar.EventSource) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		token, ok := strings.CutSuffix(req.PathValue("file"), ".ics")
+		if !ok || token == "" {
+			return ctx, http.StatusNotFound, storage.ErrFeedNotFound
+		}
+		feed, err := getter.GetFeedByTokenHash(ctx, hashFeedToken(token))
+		if err != nil {
+			err = fmt.Errorf("getting feed from storage: %w", err)
+			if errors.Is(err, storage.ErrFeedNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+		ctx = logger.WithUsername(ctx, feed.Username)
+
+		start, end := icalendar.DefaultWindow(time.Now())
+		cal, err := icalendar.Export(ctx, src, feed.Username, start, end)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("export events: %w", err)
+		}
+		if feed.Name != "" {
+			cal.AddText("X-WR-CALNAME", feed.Name)
+		}
+
+		// Write iCalendar response
+		res.Header().Set("Content-Type", icalendar.ContentType)
+		res.Header().Set("Cache-Control", "private, no-cache")
+		res.WriteHeader(http.StatusOK)
+		if err := ical.Encode(res, cal); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
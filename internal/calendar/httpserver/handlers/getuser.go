@@ -15,11 +15,21 @@ type UserGetter interface {
 	GetUser(ctx context.Context, username string) (*storage.User, error)
 }
 
+//nolint:tagliatelle
 type ResponseGetUser struct {
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
-	Status string `json:"status"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+	TimeZone       string `json:"time_zone,omitempty"`
+	ConflictPolicy string `json:"conflict_policy,omitempty"`
+	ShareFreeBusy  bool   `json:"share_free_busy"`
+	// WorkingHours are omitted if the user works at any time.
+	WorkingHours       *storage.WorkingHours `json:"working_hours,omitempty"`
+	OutOfOffice        []storage.OutOfOffice `json:"out_of_office"`
+	DeclineUnavailable bool                  `json:"decline_unavailable"`
+	RetentionDays      int                   `json:"retention_days,omitempty"`
+	WebhookURL         string                `json:"webhook_url,omitempty"`
+	Status             string                `json:"status"`
 }
 
 func NewGetUser(getter UserGetter) HandlerFunc {
@@ -42,10 +52,21 @@ func NewGetUser(getter UserGetter) HandlerFunc {
 
 		// Write json response
 		response := ResponseGetUser{
-			Name:   user.Name,
-			Email:  user.Email,
-			Role:   user.Role,
-			Status: "OK",
+			Name:               user.Name,
+			Email:              user.Email,
+			Role:               user.Role,
+			TimeZone:           user.TimeZone,
+			ConflictPolicy:     string(user.ConflictPolicy),
+			ShareFreeBusy:      user.ShareFreeBusy,
+			WorkingHours:       user.WorkingHours,
+			OutOfOffice:        user.OutOfOffice,
+			DeclineUnavailable: user.DeclineUnavailable,
+			RetentionDays:      user.RetentionDays,
+			WebhookURL:         user.WebhookURL,
+			Status:             "OK",
+		}
+		if response.OutOfOffice == nil {
+			response.OutOfOffice = []storage.OutOfOffice{}
 		}
 		jsonResponse, err := json.Marshal(response)
 		if err != nil {
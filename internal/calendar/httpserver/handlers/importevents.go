@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+const maxImportSize = 10 << 20 // 10 MiB
+
+type ResponseImportEvents struct {
+	Items  []icalendar.ImportResult `json:"items"`
+	Status string                   `json:"status"`
+}
+
+// NewImportEvents accepts an iCalendar object either as the request body or
+// as the "file" field of a multipart form.
+func NewImportEvents(dst icalendar.EventImporter) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read iCalendar request
+		req.Body = http.MaxBytesReader(res, req.Body, maxImportSize)
+		defer req.Body.Close()
+		var body io.Reader = req.Body
+		if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("read file from form: %w", err)
+			}
+			defer file.Close()
+			body = file
+		}
+		cal, err := ical.Decode(body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return ctx, http.StatusRequestEntityTooLarge, fmt.Errorf("read body request: %w", err)
+			}
+			return ctx, http.StatusBadRequest, fmt.Errorf("decode calendar: %w", err)
+		}
+		if cal.Name != ical.CompCalendar {
+			return ctx, http.StatusBadRequest, fmt.Errorf("decode calendar: unexpected component %s", cal.Name)
+		}
+
+		items, err := icalendar.Import(ctx, dst, username, cal)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("import events: %w", err)
+		}
+
+		// Write json response
+		response := ResponseImportEvents{
+			Items:  items,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -8,13 +8,17 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/sharing"
 	"github.com/mrvin/calendar/internal/storage"
 )
 
 type EventsLister interface {
+	sharing.Source
 	ListEvents(ctx context.Context, username string, start, end time.Time) ([]storage.Event, error)
+	ListCalendarEvents(ctx context.Context, username string, calendarIDs []uuid.UUID, start, end time.Time) ([]storage.Event, error)
 }
 
 type ResponseListEvents struct {
@@ -46,8 +50,33 @@ func NewListEvents(lister EventsLister) HandlerFunc {
 		if start.After(end) {
 			return ctx, http.StatusBadRequest, errors.New("start_time must be before or equal to end_time")
 		}
+		if end.Sub(start) > storage.MaxListRange {
+			return ctx, http.StatusBadRequest, fmt.Errorf("time range longer than %s", storage.MaxListRange)
+		}
+		// The events of the user who shared them if the owner is given.
+		owner := username
+		if ownerStr := req.URL.Query().Get("owner"); ownerStr != "" {
+			if err := sharing.Check(ctx, lister, username, ownerStr, storage.RoleViewer); err != nil {
+				return ctx, accessStatus(err), err
+			}
+			owner = ownerStr
+		}
+		// Only the events in the calendars if any are given.
+		calendarIDs := make([]uuid.UUID, 0)
+		for _, idStr := range req.URL.Query()["calendar_id"] {
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("parse calendar_id: %w", err)
+			}
+			calendarIDs = append(calendarIDs, id)
+		}
 
-		events, err := lister.ListEvents(ctx, username, start, end)
+		var events []storage.Event
+		if len(calendarIDs) == 0 {
+			events, err = lister.ListEvents(ctx, owner, start, end)
+		} else {
+			events, err = lister.ListCalendarEvents(ctx, owner, calendarIDs, start, end)
+		}
 		if err != nil {
 			return ctx, http.StatusInternalServerError, fmt.Errorf("getting list events from storage: %w", err)
 		}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestListEvents_Range(t *testing.T) {
+	st := memory.New()
+	ctx := logger.WithUsername(context.Background(), "bob")
+	handler := ErrorHandler("List events", NewListEvents(st))
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"start_time=2025-01-01T00:00:00Z&end_time=2026-01-01T00:00:00Z", http.StatusOK},
+		{"start_time=2025-01-01T00:00:00Z&end_time=2026-01-03T00:00:00Z", http.StatusBadRequest},
+		{"start_time=2025-01-02T00:00:00Z&end_time=2025-01-01T00:00:00Z", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/api/events?"+test.query, nil)
+		if err != nil {
+			t.Fatalf("create new request: %v", err)
+		}
+		res := httptest.NewRecorder()
+		handler(res, req)
+		if res.Code != test.code {
+			t.Errorf("%s: have %d %s, want %d", test.query, res.Code, res.Body, test.code)
+		}
+	}
+}
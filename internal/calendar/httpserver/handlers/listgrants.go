@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type GrantsLister interface {
+	ListGrants(ctx context.Context, owner string) ([]storage.Grant, error)
+	ListGrantsTo(ctx context.Context, grantee string) ([]storage.Grant, error)
+}
+
+type ResponseListGrants struct {
+	Grants []storage.Grant `json:"grants"`
+	Status string          `json:"status"`
+}
+
+// NewListGrants lists the users the user shares the events with.
+func NewListGrants(lister GrantsLister) HandlerFunc {
+	return newListGrants(lister.ListGrants)
+}
+
+// NewListSharedGrants lists the users who share their events with the user.
+func NewListSharedGrants(lister GrantsLister) HandlerFunc {
+	return newListGrants(lister.ListGrantsTo)
+}
+
+func newListGrants(list func(ctx context.Context, username string) ([]storage.Grant, error)) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		grants, err := list(ctx, username)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting grants from storage: %w", err)
+		}
+
+		// Write json response
+		response := ResponseListGrants{
+			Grants: grants,
+			Status: "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type ResourcesLister interface {
+	ListResources(ctx context.Context, kind string, minCapacity int) ([]storage.Resource, error)
+}
+
+type ResponseListResources struct {
+	Resources []storage.Resource `json:"resources"`
+	Status    string             `json:"status"`
+}
+
+// NewListResources lists the resources of the kind given in the query, if
+// any, which hold at least min_capacity people.
+func NewListResources(lister ResourcesLister) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		kind := req.URL.Query().Get("kind")
+		var minCapacity int
+		if minCapacityStr := req.URL.Query().Get("min_capacity"); minCapacityStr != "" {
+			var err error
+			if minCapacity, err = strconv.Atoi(minCapacityStr); err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("parse min_capacity: %w", err)
+			}
+		}
+
+		resources, err := lister.ListResources(ctx, kind, minCapacity)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting resources from storage: %w", err)
+		}
+
+		// Write json response
+		response := ResponseListResources{
+			Resources: resources,
+			Status:    "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
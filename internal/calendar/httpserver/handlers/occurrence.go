@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// parseOccurrence returns the occurrence of a recurring event selected by the
+// recurrence_id and scope query parameters, or nil if the whole event is addressed.
+func parseOccurrence(req *http.Request) (*time.Time, storage.Scope, error) {
+	recurrenceIDStr := req.URL.Query().Get("recurrence_id")
+	scope := storage.Scope(req.URL.Query().Get("scope"))
+
+	if recurrenceIDStr == "" {
+		if scope != "" && scope != storage.ScopeAll {
+			return nil, "", errors.New("scope requires recurrence_id")
+		}
+		return nil, storage.ScopeAll, nil
+	}
+	recurrenceID, err := time.Parse(time.RFC3339, recurrenceIDStr)
+	if err != nil {
+		return nil, "", errors.New("invalid recurrence_id format, use RFC3339")
+	}
+
+	switch scope {
+	case "":
+		scope = storage.ScopeThis
+	case storage.ScopeThis, storage.ScopeFollowing, storage.ScopeAll:
+	default:
+		return nil, "", fmt.Errorf("invalid scope %q, use this, following or all", scope)
+	}
+
+	return &recurrenceID, scope, nil
+}
@@ -19,10 +19,13 @@ type UserCreator interface {
 	CreateUser(ctx context.Context, user *storage.User) error
 }
 
+//nolint:tagliatelle
 type RequestRegister struct {
-	Username string `json:"username" validate:"required,min=3,max=20"`
-	Password string `json:"password" validate:"required,min=6,max=32"` //nolint:gosec
-	Email    string `json:"email"    validate:"required,email"`
+	Username       string `json:"username"                  validate:"required,min=3,max=20"`
+	Password       string `json:"password"                  validate:"required,min=6,max=32"` //nolint:gosec
+	Email          string `json:"email"                     validate:"required,email"`
+	TimeZone       string `json:"time_zone,omitempty"       validate:"omitempty,timezone"`
+	ConflictPolicy string `json:"conflict_policy,omitempty" validate:"omitempty,oneof=reject warn allow"`
 }
 
 func NewRegister(creator UserCreator) HandlerFunc {
@@ -57,10 +60,12 @@ func NewRegister(creator UserCreator) HandlerFunc {
 		}
 
 		user := storage.User{
-			Name:         request.Username,
-			HashPassword: string(hashPassword),
-			Email:        request.Email,
-			Role:         "user",
+			Name:           request.Username,
+			HashPassword:   string(hashPassword),
+			Email:          request.Email,
+			Role:           "user",
+			TimeZone:       request.TimeZone,
+			ConflictPolicy: storage.ConflictPolicy(request.ConflictPolicy),
 		}
 
 		if err = creator.CreateUser(ctx, &user); err != nil {
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// RequestReminder notifies the owner through the channel offset before the
+// start of the event.
+type RequestReminder struct {
+	Offset  time.Duration `json:"offset"  validate:"gte=0"`
+	Channel string        `json:"channel" validate:"required,oneof=email webhook"`
+}
+
+func toReminders(request []RequestReminder) []storage.Reminder {
+	if request == nil {
+		return nil
+	}
+	reminders := make([]storage.Reminder, len(request))
+	for i, reminder := range request {
+		reminders[i] = storage.Reminder{Offset: reminder.Offset, Channel: storage.Channel(reminder.Channel)}
+	}
+
+	return reminders
+}
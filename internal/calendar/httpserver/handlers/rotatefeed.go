@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type FeedTokenUpdater interface {
+	UpdateFeedToken(ctx context.Context, username string, id uuid.UUID, tokenHash string) error
+}
+
+// NewRotateFeed issues a new token of the feed, the old one stops working.
+func NewRotateFeed(updater FeedTokenUpdater) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		token, err := newFeedToken()
+		if err != nil {
+			return ctx, http.StatusInternalServerError, err
+		}
+		if err := updater.UpdateFeedToken(ctx, username, id, hashFeedToken(token)); err != nil {
+			err = fmt.Errorf("updating feed token in storage: %w", err)
+			if errors.Is(err, storage.ErrFeedNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		if err := writeFeedToken(res, http.StatusOK, id, token); err != nil {
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type GrantSetter interface {
+	SetGrant(ctx context.Context, grant *storage.Grant) error
+}
+
+type RequestSetGrant struct {
+	Role string `json:"role" validate:"required,oneof=owner editor viewer freebusy"`
+}
+
+// NewSetGrant shares the events of the user with the user in the path,
+// replacing the role granted before.
+func NewSetGrant(setter GrantSetter) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		grantee := req.PathValue("username")
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+		if grantee == username {
+			return ctx, http.StatusBadRequest, errors.New("cannot share events with yourself")
+		}
+
+		// Read json request
+		var request RequestSetGrant
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		//nolint:exhaustruct
+		grant := storage.Grant{
+			Owner:   username,
+			Grantee: grantee,
+			Role:    storage.Role(request.Role),
+		}
+		if err := setter.SetGrant(ctx, &grant); err != nil {
+			err = fmt.Errorf("saving grant to storage: %w", err)
+			if errors.Is(err, storage.ErrUserNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
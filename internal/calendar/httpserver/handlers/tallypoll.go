@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/poll"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+//nolint:tagliatelle
+type ResponseTallyPoll struct {
+	Options []poll.OptionTally `json:"options"`
+	// WinnerID is the option most invitees can attend, none if nobody can.
+	WinnerID *uuid.UUID `json:"winner_id,omitempty"`
+	Status   string     `json:"status"`
+}
+
+// NewTallyPoll counts the votes on each option of the poll.
+func NewTallyPoll(getter PollGetter) HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		p, err := getter.GetPoll(ctx, username, id)
+		if err != nil {
+			err = fmt.Errorf("getting poll from storage: %w", err)
+			if errors.Is(err, storage.ErrPollNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		response := ResponseTallyPoll{
+			Options:  poll.Tally(p),
+			WinnerID: nil,
+			Status:   "OK",
+		}
+		if winner := poll.Winner(response.Options); winner != nil {
+			response.WinnerID = &winner.ID
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
+
+		return ctx, http.StatusOK, nil
+	}
+}
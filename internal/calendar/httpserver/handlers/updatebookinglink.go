@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type BookingLinkUpdater interface {
+	UpdateBookingLink(ctx context.Context, username string, id uuid.UUID, link *storage.BookingLink) error
+}
+
+// NewUpdateBookingLink replaces the settings of the booking link, the events
+// already booked are kept.
+func NewUpdateBookingLink(updater BookingLinkUpdater) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		link, status, err := readRequestBookingLink(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+		if err := updater.UpdateBookingLink(ctx, username, id, link); err != nil {
+			err = fmt.Errorf("updating booking link in storage: %w", err)
+			if errors.Is(err, storage.ErrBookingLinkNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			if errors.Is(err, storage.ErrBookingLinkExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
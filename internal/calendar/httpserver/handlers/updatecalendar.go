@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type CalendarUpdater interface {
+	UpdateCalendar(ctx context.Context, username string, id uuid.UUID, calendar *storage.Calendar) error
+}
+
+// NewUpdateCalendar replaces the name, color and default reminder of the
+// calendar, the events keep their reminders.
+func NewUpdateCalendar(updater CalendarUpdater) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		request, status, err := readRequestCalendar(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+
+		//nolint:exhaustruct
+		calendar := storage.Calendar{
+			Name:         request.Name,
+			Color:        request.Color,
+			NotifyBefore: request.NotifyBefore,
+		}
+		if err := updater.UpdateCalendar(ctx, username, id, &calendar); err != nil {
+			err = fmt.Errorf("updating calendar in storage: %w", err)
+			if errors.Is(err, storage.ErrCalendarNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			if errors.Is(err, storage.ErrCalendarExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -13,21 +13,40 @@ import (
 	"github.com/google/uuid"
 	"github.com/mrvin/calendar/internal/calendar/auth"
 	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/sharing"
 	"github.com/mrvin/calendar/internal/storage"
-	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+	"github.com/mrvin/calendar/pkg/rrule"
 )
 
 type EventUpdater interface {
+	sharing.EventSource
 	UpdateEvent(ctx context.Context, username string, id uuid.UUID, event *storage.Event) error
+	UpdateEventOccurrence(ctx context.Context, username string, id uuid.UUID, recurrenceID time.Time, scope storage.Scope, event *storage.Event) error
 }
 
 //nolint:tagliatelle
 type RequestUpdateEvent struct {
-	Title        string         `json:"title"                   validate:"required,min=2,max=64"`
-	Description  string         `json:"description,omitempty"   validate:"omitempty,min=2,max=512"`
-	StartTime    time.Time      `json:"start_time"              validate:"required"`
-	EndTime      time.Time      `json:"end_time"                validate:"required"`
-	NotifyBefore *time.Duration `json:"notify_before,omitempty" validate:"omitempty"`
+	Title        string            `json:"title"                   validate:"required,min=2,max=64"`
+	Description  string            `json:"description,omitempty"   validate:"omitempty,min=2,max=512"`
+	StartTime    time.Time         `json:"start_time"              validate:"required_unless=AllDay true"`
+	EndTime      time.Time         `json:"end_time"                validate:"required_unless=AllDay true"`
+	AllDay       bool              `json:"all_day,omitempty"`
+	StartDate    string            `json:"start_date,omitempty"    validate:"required_if=AllDay true,omitempty,datetime=2006-01-02"`
+	EndDate      string            `json:"end_date,omitempty"      validate:"omitempty,datetime=2006-01-02"`
+	TimeZone     string            `json:"time_zone,omitempty"     validate:"omitempty,timezone"`
+	Transparency string            `json:"transparency,omitempty"  validate:"omitempty,oneof=busy free"`
+	Reminders    []RequestReminder `json:"reminders,omitempty"     validate:"omitempty,max=10,dive"`
+	RRule        string            `json:"rrule,omitempty"         validate:"omitempty,max=256"`
+	Attendees    []RequestAttendee `json:"attendees,omitempty"     validate:"omitempty,max=100,dive"`
+	CalendarID   *uuid.UUID        `json:"calendar_id,omitempty"`
+	Resources    []uuid.UUID       `json:"resources,omitempty"     validate:"omitempty,max=20"`
+}
+
+type ResponseUpdateEvent struct {
+	// Conflicts are the busy events overlapped by the event if the conflict
+	// policy of the user is warn.
+	Conflicts []uuid.UUID `json:"conflicts,omitempty"`
+	Status    string      `json:"status"`
 }
 
 func NewUpdateEvent(updater EventUpdater) HandlerFunc {
@@ -40,6 +59,10 @@ func NewUpdateEvent(updater EventUpdater) HandlerFunc {
 		if err != nil {
 			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
 		}
+		recurrenceID, scope, err := parseOccurrence(req)
+		if err != nil {
+			return ctx, http.StatusBadRequest, err
+		}
 
 		username, err := auth.GetUsernameFromCtx(ctx)
 		if err != nil {
@@ -66,32 +89,77 @@ func NewUpdateEvent(updater EventUpdater) HandlerFunc {
 			}
 			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
 		}
-		if request.StartTime.After(request.EndTime) {
-			return ctx, http.StatusBadRequest, errors.New("start_time must be before or equal to end_time")
+		reminders := toReminders(request.Reminders)
+		if err := storage.CheckReminders(reminders); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+		}
+		startTime, endTime, err := eventTimes(request.AllDay, request.StartTime, request.EndTime, request.StartDate, request.EndDate)
+		if err != nil {
+			return ctx, http.StatusBadRequest, err
+		}
+		if request.RRule != "" {
+			rule, err := rrule.Parse(request.RRule)
+			if err != nil {
+				return ctx, http.StatusBadRequest, fmt.Errorf("parse rrule: %w", err)
+			}
+			request.RRule = rule.String()
+		}
+
+		owner, err := sharing.EventOwner(ctx, updater, username, id, storage.RoleEditor)
+		if err != nil {
+			return ctx, accessStatus(err), err
 		}
 
 		event := storage.Event{
 			ID:           id,
 			Title:        request.Title,
 			Description:  request.Description,
-			StartTime:    request.StartTime,
-			EndTime:      request.EndTime,
-			NotifyBefore: request.NotifyBefore,
-			Username:     username,
+			StartTime:    startTime,
+			EndTime:      endTime,
+			AllDay:       request.AllDay,
+			TimeZone:     request.TimeZone,
+			Transparency: storage.Transparency(request.Transparency),
+			Reminders:    reminders,
+			RRule:        request.RRule,
+			Username:     owner,
+			Attendees:    toAttendees(request.Attendees),
+			CalendarID:   request.CalendarID,
+			Resources:    request.Resources,
+		}
+		if recurrenceID == nil {
+			err = updater.UpdateEvent(ctx, owner, id, &event)
+		} else {
+			err = updater.UpdateEventOccurrence(ctx, owner, id, *recurrenceID, scope, &event)
 		}
-		if err := updater.UpdateEvent(ctx, username, id, &event); err != nil {
+		if err != nil {
 			err = fmt.Errorf("updating event to storage: %w", err)
-			if errors.Is(err, storage.ErrDateBusy) {
+			if errors.Is(err, storage.ErrDateBusy) || errors.Is(err, storage.ErrResourceBusy) {
 				return ctx, http.StatusConflict, err
 			}
-			if errors.Is(err, storage.ErrEventNotFound) {
+			if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrOccurrenceNotFound) {
 				return ctx, http.StatusNotFound, err
 			}
+			if errors.Is(err, storage.ErrUserNotFound) || errors.Is(err, storage.ErrCalendarNotFound) ||
+				errors.Is(err, storage.ErrResourceNotFound) {
+				return ctx, http.StatusBadRequest, err
+			}
 			return ctx, http.StatusInternalServerError, err
 		}
 
 		// Write json response
-		httpresponse.WriteOK(res, http.StatusOK)
+		response := ResponseUpdateEvent{
+			Conflicts: event.Conflicts,
+			Status:    "OK",
+		}
+		jsonResponse, err := json.Marshal(&response)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("marshal response: %w", err)
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		if _, err := res.Write(jsonResponse); err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("write response: %w", err)
+		}
 
 		return ctx, http.StatusOK, nil
 	}
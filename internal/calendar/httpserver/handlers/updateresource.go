@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type ResourceUpdater interface {
+	UserGetter
+	UpdateResource(ctx context.Context, id uuid.UUID, resource *storage.Resource) error
+}
+
+// NewUpdateResource replaces the name, kind, capacity and attributes of the
+// resource, the reservations are kept.
+func NewUpdateResource(updater ResourceUpdater) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+		if status, err := checkAdmin(ctx, updater, username); err != nil {
+			return ctx, status, err
+		}
+
+		request, status, err := readRequestResource(req, validate)
+		if err != nil {
+			return ctx, status, err
+		}
+
+		//nolint:exhaustruct
+		resource := storage.Resource{
+			Name:       request.Name,
+			Kind:       request.Kind,
+			Capacity:   request.Capacity,
+			Attributes: request.Attributes,
+		}
+		if err := updater.UpdateResource(ctx, id, &resource); err != nil {
+			err = fmt.Errorf("updating resource in storage: %w", err)
+			if errors.Is(err, storage.ErrResourceNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			if errors.Is(err, storage.ErrResourceExists) {
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type UserSettingsUpdater interface {
+	UpdateUserSettings(ctx context.Context, user *storage.User) error
+}
+
+//nolint:tagliatelle
+type RequestOutOfOffice struct {
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time"   validate:"required,gtfield=StartTime"`
+}
+
+//nolint:tagliatelle
+type RequestUserSettings struct {
+	TimeZone       string `json:"time_zone,omitempty"       validate:"omitempty,timezone"`
+	ConflictPolicy string `json:"conflict_policy,omitempty" validate:"omitempty,oneof=reject warn allow"`
+	ShareFreeBusy  bool   `json:"share_free_busy,omitempty"`
+	// WorkingHours are in the time zone of the user unless given.
+	WorkingHours       *RequestWorkingHours `json:"working_hours,omitempty"       validate:"omitempty"`
+	OutOfOffice        []RequestOutOfOffice `json:"out_of_office,omitempty"       validate:"max=100,dive"`
+	DeclineUnavailable bool                 `json:"decline_unavailable,omitempty"`
+	// RetentionDays are the days the events are kept after they end, the
+	// default of the server if 0.
+	RetentionDays int `json:"retention_days,omitempty" validate:"gte=0,lte=36500"`
+	// WebhookURL is where the reminders by webhook are posted.
+	WebhookURL string `json:"webhook_url,omitempty" validate:"omitempty,http_url"`
+}
+
+func NewUpdateUserSettings(updater UserSettingsUpdater) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request
+		var request RequestUserSettings
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		//nolint:exhaustruct
+		user := storage.User{
+			Name:           username,
+			TimeZone:       request.TimeZone,
+			ConflictPolicy: storage.ConflictPolicy(request.ConflictPolicy),
+			ShareFreeBusy:  request.ShareFreeBusy,
+			WorkSchedule:   workSchedule(&request),
+			RetentionDays:  request.RetentionDays,
+			WebhookURL:     request.WebhookURL,
+		}
+		if err := user.WorkSchedule.Check(); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+		}
+		if err := updater.UpdateUserSettings(ctx, &user); err != nil {
+			err = fmt.Errorf("updating user settings in storage: %w", err)
+			if errors.Is(err, storage.ErrUserNotFound) {
+				return ctx, http.StatusNotFound, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
+
+func workSchedule(request *RequestUserSettings) storage.WorkSchedule {
+	ws := storage.WorkSchedule{
+		WorkingHours:       nil,
+		OutOfOffice:        make([]storage.OutOfOffice, len(request.OutOfOffice)),
+		DeclineUnavailable: request.DeclineUnavailable,
+	}
+	if wh := request.WorkingHours; wh != nil {
+		ws.WorkingHours = &storage.WorkingHours{
+			Start:    wh.Start,
+			End:      wh.End,
+			Weekdays: wh.Weekdays,
+			TimeZone: wh.TimeZone,
+		}
+		if ws.WorkingHours.TimeZone == "" {
+			ws.WorkingHours.TimeZone = request.TimeZone
+		}
+	}
+	for i, ooo := range request.OutOfOffice {
+		ws.OutOfOffice[i] = storage.OutOfOffice{StartTime: ooo.StartTime, EndTime: ooo.EndTime}
+	}
+
+	return ws
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/logger"
+	"github.com/mrvin/calendar/internal/storage"
+	httpresponse "github.com/mrvin/calendar/pkg/http/response"
+)
+
+type PollVoter interface {
+	SetVotes(ctx context.Context, username string, id uuid.UUID, votes []storage.PollVote) error
+}
+
+//nolint:tagliatelle
+type RequestVote struct {
+	OptionID uuid.UUID `json:"option_id" validate:"required"`
+	Vote     string    `json:"vote"      validate:"required,oneof=yes no if-needed"`
+}
+
+type RequestVotePoll struct {
+	Votes []RequestVote `json:"votes" validate:"max=20,dive"`
+}
+
+// NewVotePoll replaces the votes of the invitee on the options of the poll.
+func NewVotePoll(voter PollVoter) HandlerFunc {
+	validate := validator.New()
+	return func(res http.ResponseWriter, req *http.Request) (context.Context, int, error) {
+		ctx := req.Context()
+
+		idStr := req.PathValue("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("parse id: %w", err)
+		}
+
+		username, err := auth.GetUsernameFromCtx(ctx)
+		if err != nil {
+			return ctx, http.StatusInternalServerError, fmt.Errorf("getting username from ctx: %w", err)
+		}
+		ctx = logger.WithUsername(ctx, username)
+
+		// Read json request
+		var request RequestVotePoll
+		body, err := io.ReadAll(req.Body)
+		defer req.Body.Close()
+		if err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("read body request: %w", err)
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return ctx, http.StatusBadRequest, fmt.Errorf("unmarshal body request: %w", err)
+		}
+
+		// Validation
+		if err := validate.Struct(request); err != nil {
+			var vErrors validator.ValidationErrors
+			if errors.As(err, &vErrors) {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: tag: %s value: %s", vErrors[0].Tag(), vErrors[0].Value())
+			}
+			return ctx, http.StatusInternalServerError, fmt.Errorf("validation: %w", err)
+		}
+
+		votes := make([]storage.PollVote, len(request.Votes))
+		seen := make(map[uuid.UUID]bool, len(request.Votes))
+		for i, vote := range request.Votes {
+			if seen[vote.OptionID] {
+				return ctx, http.StatusBadRequest, fmt.Errorf("invalid request: second vote on option %s", vote.OptionID)
+			}
+			seen[vote.OptionID] = true
+			votes[i] = storage.PollVote{OptionID: vote.OptionID, Username: username, Vote: storage.Vote(vote.Vote)}
+		}
+		if err := voter.SetVotes(ctx, username, id, votes); err != nil {
+			err = fmt.Errorf("saving votes to storage: %w", err)
+			switch {
+			case errors.Is(err, storage.ErrPollNotFound):
+				return ctx, http.StatusNotFound, err
+			case errors.Is(err, storage.ErrPollOptionNotFound):
+				return ctx, http.StatusBadRequest, err
+			case errors.Is(err, storage.ErrPollFinalized):
+				return ctx, http.StatusConflict, err
+			}
+			return ctx, http.StatusInternalServerError, err
+		}
+
+		// Write json response
+		httpresponse.WriteOK(res, http.StatusOK)
+
+		return ctx, http.StatusOK, nil
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/mrvin/calendar/internal/booking"
 	authservice "github.com/mrvin/calendar/internal/calendar/auth"
+	"github.com/mrvin/calendar/internal/calendar/httpserver/caldav"
 	"github.com/mrvin/calendar/internal/calendar/httpserver/handlers"
 	"github.com/mrvin/calendar/internal/storage"
 	"github.com/mrvin/calendar/pkg/http/logger"
@@ -41,8 +43,10 @@ type Server struct {
 	conf *Conf
 }
 
-func New(conf *Conf, st storage.Storage, auth *authservice.Auth) *Server {
+// New returns the server; storageOpts must be those of st.
+func New(conf *Conf, st storage.Storage, auth *authservice.Auth, storageOpts ...storage.Option) *Server {
 	mux := http.NewServeMux()
+	opts := storage.NewOptions(storageOpts...)
 
 	// info
 	mux.HandleFunc(http.MethodGet+" /api/health", handlers.Health)
@@ -53,6 +57,15 @@ func New(conf *Conf, st storage.Storage, auth *authservice.Auth) *Server {
 	mux.HandleFunc(http.MethodPost+" /api/auth/login", handlers.ErrorHandler("Login", handlers.NewLogin(auth)))
 	mux.HandleFunc(http.MethodGet+" /api/auth/me", auth.Authorized(handlers.ErrorHandler("Get user", handlers.NewGetUser(st))))
 	mux.HandleFunc(http.MethodDelete+" /api/auth/me", auth.Authorized(handlers.ErrorHandler("Delete user", handlers.NewDeleteUser(st))))
+	mux.HandleFunc(http.MethodPut+" /api/auth/me/settings", auth.Authorized(handlers.ErrorHandler("Update user settings", handlers.NewUpdateUserSettings(st))))
+	mux.HandleFunc(http.MethodPost+" /api/auth/me/feeds", auth.Authorized(handlers.ErrorHandler("Create feed", handlers.NewCreateFeed(st))))
+	mux.HandleFunc(http.MethodGet+" /api/auth/me/feeds", auth.Authorized(handlers.ErrorHandler("List feeds", handlers.NewListFeeds(st))))
+	mux.HandleFunc(http.MethodPost+" /api/auth/me/feeds/{id}/rotate", auth.Authorized(handlers.ErrorHandler("Rotate feed", handlers.NewRotateFeed(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/auth/me/feeds/{id}", auth.Authorized(handlers.ErrorHandler("Delete feed", handlers.NewDeleteFeed(st))))
+	mux.HandleFunc(http.MethodGet+" /api/auth/me/grants", auth.Authorized(handlers.ErrorHandler("List grants", handlers.NewListGrants(st))))
+	mux.HandleFunc(http.MethodPut+" /api/auth/me/grants/{username}", auth.Authorized(handlers.ErrorHandler("Set grant", handlers.NewSetGrant(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/auth/me/grants/{username}", auth.Authorized(handlers.ErrorHandler("Delete grant", handlers.NewDeleteGrant(st))))
+	mux.HandleFunc(http.MethodGet+" /api/auth/me/shared", auth.Authorized(handlers.ErrorHandler("List shared grants", handlers.NewListSharedGrants(st))))
 	//	mux.HandleFunc(http.MethodPost+" /api/auth/refresh")
 	//	mux.HandleFunc(http.MethodPost+" /api/auth/logout")
 
@@ -60,10 +73,57 @@ func New(conf *Conf, st storage.Storage, auth *authservice.Auth) *Server {
 	mux.HandleFunc(http.MethodPost+" /api/events", auth.Authorized(handlers.ErrorHandler("Create event", handlers.NewCreateEvent(st))))
 	mux.HandleFunc(http.MethodGet+" /api/events/{id}", auth.Authorized(handlers.ErrorHandler("Get event", handlers.NewGetEvent(st))))
 	mux.HandleFunc(http.MethodGet+" /api/events", auth.Authorized(handlers.ErrorHandler("List events", handlers.NewListEvents(st))))
+	mux.HandleFunc(http.MethodPost+" /api/events/import", auth.Authorized(handlers.ErrorHandler("Import events", handlers.NewImportEvents(st))))
+	mux.HandleFunc(http.MethodGet+" /api/events.ics", auth.Authorized(handlers.ErrorHandler("Export events", handlers.NewExportEvents(st))))
 	mux.HandleFunc(http.MethodPut+" /api/events/{id}", auth.Authorized(handlers.ErrorHandler("Update event", handlers.NewUpdateEvent(st))))
 	mux.HandleFunc(http.MethodDelete+" /api/events/{id}", auth.Authorized(handlers.ErrorHandler("Delete event", handlers.NewDeleteEvent(st))))
-
-	loggerServer := logger.Logger{Inner: mux}
+	mux.HandleFunc(http.MethodPut+" /api/events/{id}/rsvp", auth.Authorized(handlers.ErrorHandler("Respond to event", handlers.NewRespondEvent(st))))
+
+	// Calendars
+	mux.HandleFunc(http.MethodPost+" /api/calendars", auth.Authorized(handlers.ErrorHandler("Create calendar", handlers.NewCreateCalendar(st))))
+	mux.HandleFunc(http.MethodGet+" /api/calendars", auth.Authorized(handlers.ErrorHandler("List calendars", handlers.NewListCalendars(st))))
+	mux.HandleFunc(http.MethodGet+" /api/calendars/{id}", auth.Authorized(handlers.ErrorHandler("Get calendar", handlers.NewGetCalendar(st))))
+	mux.HandleFunc(http.MethodPut+" /api/calendars/{id}", auth.Authorized(handlers.ErrorHandler("Update calendar", handlers.NewUpdateCalendar(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/calendars/{id}", auth.Authorized(handlers.ErrorHandler("Delete calendar", handlers.NewDeleteCalendar(st))))
+
+	// Resources
+	mux.HandleFunc(http.MethodPost+" /api/resources", auth.Authorized(handlers.ErrorHandler("Create resource", handlers.NewCreateResource(st))))
+	mux.HandleFunc(http.MethodGet+" /api/resources", auth.Authorized(handlers.ErrorHandler("List resources", handlers.NewListResources(st))))
+	mux.HandleFunc(http.MethodGet+" /api/resources/{id}", auth.Authorized(handlers.ErrorHandler("Get resource", handlers.NewGetResource(st))))
+	mux.HandleFunc(http.MethodPut+" /api/resources/{id}", auth.Authorized(handlers.ErrorHandler("Update resource", handlers.NewUpdateResource(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/resources/{id}", auth.Authorized(handlers.ErrorHandler("Delete resource", handlers.NewDeleteResource(st))))
+
+	// Polls
+	mux.HandleFunc(http.MethodPost+" /api/polls", auth.Authorized(handlers.ErrorHandler("Create poll", handlers.NewCreatePoll(st))))
+	mux.HandleFunc(http.MethodGet+" /api/polls", auth.Authorized(handlers.ErrorHandler("List polls", handlers.NewListPolls(st))))
+	mux.HandleFunc(http.MethodGet+" /api/polls/{id}", auth.Authorized(handlers.ErrorHandler("Get poll", handlers.NewGetPoll(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/polls/{id}", auth.Authorized(handlers.ErrorHandler("Delete poll", handlers.NewDeletePoll(st))))
+	mux.HandleFunc(http.MethodPut+" /api/polls/{id}/votes", auth.Authorized(handlers.ErrorHandler("Vote poll", handlers.NewVotePoll(st))))
+	mux.HandleFunc(http.MethodGet+" /api/polls/{id}/tally", auth.Authorized(handlers.ErrorHandler("Tally poll", handlers.NewTallyPoll(st))))
+	mux.HandleFunc(http.MethodPost+" /api/polls/{id}/finalize", auth.Authorized(handlers.ErrorHandler("Finalize poll", handlers.NewFinalizePoll(st))))
+
+	// Booking links
+	mux.HandleFunc(http.MethodPost+" /api/auth/me/booking-links", auth.Authorized(handlers.ErrorHandler("Create booking link", handlers.NewCreateBookingLink(st))))
+	mux.HandleFunc(http.MethodGet+" /api/auth/me/booking-links", auth.Authorized(handlers.ErrorHandler("List booking links", handlers.NewListBookingLinks(st))))
+	mux.HandleFunc(http.MethodGet+" /api/auth/me/booking-links/{id}", auth.Authorized(handlers.ErrorHandler("Get booking link", handlers.NewGetBookingLink(st))))
+	mux.HandleFunc(http.MethodPut+" /api/auth/me/booking-links/{id}", auth.Authorized(handlers.ErrorHandler("Update booking link", handlers.NewUpdateBookingLink(st))))
+	mux.HandleFunc(http.MethodDelete+" /api/auth/me/booking-links/{id}", auth.Authorized(handlers.ErrorHandler("Delete booking link", handlers.NewDeleteBookingLink(st))))
+	mux.HandleFunc(http.MethodGet+" /api/booking/{slug}", handlers.ErrorHandler("Get booking page", handlers.NewGetBookingPage(st, opts)))
+	mux.HandleFunc(http.MethodPost+" /api/booking/{slug}", handlers.ErrorHandler("Book", handlers.NewBook(st, opts, booking.NewRateLimit())))
+
+	// Free/busy
+	mux.HandleFunc(http.MethodPost+" /api/freebusy", auth.Authorized(handlers.ErrorHandler("Free busy", handlers.NewFreeBusy(st))))
+	mux.HandleFunc(http.MethodPost+" /api/freebusy/slots", auth.Authorized(handlers.ErrorHandler("Find slots", handlers.NewFindSlots(st, opts))))
+
+	// Feeds
+	mux.HandleFunc(http.MethodGet+" /api/feeds/{file}", handlers.ErrorHandler("Get feed", handlers.NewGetFeed(st, st)))
+
+	// CalDAV
+	mux.Handle("/.well-known/caldav", http.RedirectHandler(caldav.Prefix, http.StatusMovedPermanently))
+	mux.Handle(caldav.Prefix, auth.BasicAuthorized(caldav.New(st)))
+
+	// The feeds are public, their tokens must not leak into the logs.
+	loggerServer := logger.Logger{Inner: mux, SecretPrefixes: []string{"/api/feeds/"}}
 
 	return &Server{
 		//nolint:exhaustruct
@@ -0,0 +1,160 @@
+// Package freebusy computes when users are busy without revealing the
+// details of their events.
+package freebusy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mrvin/calendar/internal/sharing"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// MaxRange bounds the period of a query.
+const MaxRange = 366 * 24 * time.Hour
+
+// ErrNotAvailable is reported for users who do not share their free/busy
+// time and for unknown users alike, so that users cannot be probed.
+var ErrNotAvailable = errors.New("free/busy time of the user is not available")
+
+type Source interface {
+	sharing.Source
+	GetUser(ctx context.Context, name string) (*storage.User, error)
+	ListEvents(ctx context.Context, username string, start, end time.Time) ([]storage.Event, error)
+}
+
+// Interval is the period [Start, End).
+//
+//nolint:tagliatelle
+type Interval struct {
+	Start time.Time `json:"start_time"`
+	End   time.Time `json:"end_time"`
+}
+
+// UserBusy is the busy time of a user, or the reason it is not available.
+type UserBusy struct {
+	Username string
+	Busy     []Interval
+	Err      error
+}
+
+// Query returns the busy time of the users within [start, end) as seen by
+// the requester, in the order of usernames. The requester always sees their
+// own busy time, other users only if they share it with everyone or granted
+// the requester access.
+func Query(ctx context.Context, src Source, requester string, usernames []string, start, end time.Time) ([]UserBusy, error) {
+	result := make([]UserBusy, 0, len(usernames))
+	for _, username := range usernames {
+		busy, err := userBusy(ctx, src, requester, username, start, end)
+		if err != nil && !errors.Is(err, ErrNotAvailable) {
+			return nil, err
+		}
+		result = append(result, UserBusy{Username: username, Busy: busy, Err: err})
+	}
+
+	return result, nil
+}
+
+func userBusy(ctx context.Context, src Source, requester, username string, start, end time.Time) ([]Interval, error) {
+	events, err := busyEvents(ctx, src, requester, username, start, end)
+	if err != nil {
+		return nil, err
+	}
+	intervals := make([]Interval, 0, len(events))
+	for _, event := range events {
+		eventStart, eventEnd, err := event.Span()
+		if err != nil {
+			return nil, fmt.Errorf("busy interval: %w", err)
+		}
+		interval := Interval{Start: eventStart.UTC(), End: eventEnd.UTC()}
+		if interval.Start.Before(start) {
+			interval.Start = start.UTC()
+		}
+		if interval.End.After(end) {
+			interval.End = end.UTC()
+		}
+		if interval.Start.Before(interval.End) {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	return Merge(intervals), nil
+}
+
+// busyEvents returns the busy occurrences of the events of the user within
+// [start, end) as single events. All-day occurrences up to
+// storage.MaxZoneOffset around it are returned too, their dates may fall
+// within it in their time zone.
+func busyEvents(ctx context.Context, src Source, requester, username string, start, end time.Time) ([]storage.Event, error) {
+	if username != requester {
+		user, err := src.GetUser(ctx, username)
+		if err != nil {
+			if errors.Is(err, storage.ErrUserNotFound) {
+				return nil, ErrNotAvailable
+			}
+			return nil, fmt.Errorf("get user: %w", err)
+		}
+		if !user.ShareFreeBusy {
+			role, err := sharing.GetRole(ctx, src, requester, username)
+			if err != nil {
+				return nil, fmt.Errorf("get role: %w", err)
+			}
+			if !role.Allows(storage.RoleFreeBusy) {
+				return nil, ErrNotAvailable
+			}
+		}
+	}
+
+	events, err := src.ListEvents(ctx, username, start.Add(-storage.MaxZoneOffset), end.Add(storage.MaxZoneOffset))
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	busy := make([]storage.Event, 0, len(events))
+	for _, event := range events {
+		if !event.IsBusy() {
+			continue
+		}
+		if attendee := event.Attendee(username); event.Username != username && attendee != nil && attendee.Status == storage.PartStatDeclined {
+			continue
+		}
+		event.RRule = ""
+		busy = append(busy, event)
+	}
+
+	return busy, nil
+}
+
+// Merge returns the union of the intervals as disjoint intervals in
+// ascending order. Adjacent intervals are joined.
+func Merge(intervals []Interval) []Interval {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	merged := make([]Interval, 0, len(sorted))
+	for _, interval := range sorted {
+		if n := len(merged); n > 0 && !interval.Start.After(merged[n-1].End) {
+			if interval.End.After(merged[n-1].End) {
+				merged[n-1].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+
+	return merged
+}
+
+// MergeUsers returns the time when any of the users is busy.
+func MergeUsers(users []UserBusy) []Interval {
+	var all []Interval
+	for _, user := range users {
+		all = append(all, user.Busy...)
+	}
+
+	return Merge(all)
+}
@@ -0,0 +1,96 @@
+package freebusy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestMerge(t *testing.T) {
+	base := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	got := Merge([]Interval{
+		{Start: at(5), End: at(6)},
+		{Start: at(0), End: at(2)},
+		{Start: at(1), End: at(3)},
+		{Start: at(3), End: at(4)},
+		{Start: at(5), End: at(5)},
+	})
+	want := []Interval{{Start: at(0), End: at(4)}, {Start: at(5), End: at(6)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Merge: expected %v, got %v", want, got)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	start := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	for _, user := range []storage.User{
+		{Name: "alice", ShareFreeBusy: true},
+		{Name: "bob", ShareFreeBusy: false},
+	} {
+		if err := st.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	for _, event := range []storage.Event{
+		{Title: "Stand-up", Username: "alice", StartTime: start.Add(9 * time.Hour), EndTime: start.Add(10 * time.Hour)},
+		{Title: "Review", Username: "alice", StartTime: start.Add(10 * time.Hour), EndTime: start.Add(11 * time.Hour)},
+		{Title: "Focus", Username: "alice", StartTime: start.Add(14 * time.Hour), EndTime: start.Add(15 * time.Hour), Transparency: storage.TransparencyFree},
+		{Title: "Night shift", Username: "alice", StartTime: start.Add(-2 * time.Hour), EndTime: start.Add(time.Hour)},
+		{Title: "Lunch", Username: "bob", StartTime: start.Add(12 * time.Hour), EndTime: start.Add(13 * time.Hour)},
+	} {
+		if _, err := st.CreateEvent(ctx, &event); err != nil {
+			t.Fatalf("CreateEvent: %v", err)
+		}
+	}
+
+	users, err := Query(ctx, st, "bob", []string{"alice", "bob", "carol"}, start, end)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("Query: expected 3 users, got %d", len(users))
+	}
+	wantAlice := []Interval{
+		{Start: start, End: start.Add(time.Hour)},
+		{Start: start.Add(9 * time.Hour), End: start.Add(11 * time.Hour)},
+	}
+	if users[0].Err != nil || !slices.Equal(users[0].Busy, wantAlice) {
+		t.Errorf("alice: expected %v, got %v (%v)", wantAlice, users[0].Busy, users[0].Err)
+	}
+	if users[1].Err != nil || len(users[1].Busy) != 1 {
+		t.Errorf("bob: own busy time expected, got %v (%v)", users[1].Busy, users[1].Err)
+	}
+	if !errors.Is(users[2].Err, ErrNotAvailable) {
+		t.Errorf("carol: expected %v, got %v", ErrNotAvailable, users[2].Err)
+	}
+
+	users, err = Query(ctx, st, "alice", []string{"bob"}, start, end)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if !errors.Is(users[0].Err, ErrNotAvailable) || users[0].Busy != nil {
+		t.Errorf("bob not sharing: expected %v, got %v (%v)", ErrNotAvailable, users[0].Busy, users[0].Err)
+	}
+
+	if err := st.SetGrant(ctx, &storage.Grant{Owner: "bob", Grantee: "alice", Role: storage.RoleFreeBusy}); err != nil {
+		t.Fatalf("SetGrant: %v", err)
+	}
+	users, err = Query(ctx, st, "alice", []string{"bob"}, start, end)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if users[0].Err != nil || len(users[0].Busy) != 1 {
+		t.Errorf("bob granting free/busy: expected busy time, got %v (%v)", users[0].Busy, users[0].Err)
+	}
+}
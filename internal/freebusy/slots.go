@@ -0,0 +1,210 @@
+package freebusy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+const (
+	// SlotStep is the distance between the candidate start times, counted
+	// from the midnight in the time zone of the working hours.
+	SlotStep = 15 * time.Minute
+	// MaxSlotRange bounds the period searched for slots.
+	MaxSlotRange = 92 * 24 * time.Hour
+	// DefaultSlotLimit is the number of slots returned if not given.
+	DefaultSlotLimit = 5
+)
+
+var ErrInvalidSlotQuery = errors.New("invalid slot query")
+
+// WorkingHours limits the slots to the same hours of the given weekdays.
+type WorkingHours struct {
+	// Start and End are the times since the midnight, Start before End.
+	Start, End time.Duration
+	// Weekdays are the working days, every day if empty.
+	Weekdays []time.Weekday
+	// Location is the time zone of the working hours, the time zone of the
+	// requester if nil.
+	Location *time.Location
+}
+
+type SlotQuery struct {
+	Usernames []string
+	Duration  time.Duration
+	// Start and End bound the slots.
+	Start, End time.Time
+	// WorkingHours are optional, the slots may start at any time if nil.
+	WorkingHours *WorkingHours
+	// Buffer is kept free before and after each slot, even outside the
+	// working hours and the bounds.
+	Buffer time.Duration
+	Limit  int
+}
+
+// FindSlots returns up to query.Limit earliest start times of the slots of
+// query.Duration when none of the users is busy. A slot is busy for a user
+// if a new event in it would conflict with one of theirs under opts, as on
+// creating events, or if the buffer around the slot would. All the users
+// must share their free/busy time with the requester.
+func FindSlots(ctx context.Context, src Source, opts storage.Options, requester string, query *SlotQuery) ([]time.Time, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	if wh := query.WorkingHours; wh != nil && wh.Location == nil {
+		user, err := src.GetUser(ctx, requester)
+		if err != nil {
+			return nil, fmt.Errorf("get user: %w", err)
+		}
+		loc, err := storage.LoadLocation(user.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("time zone of user: %w", err)
+		}
+		withLocation := *wh
+		withLocation.Location = loc
+		withWorkingHours := *query
+		withWorkingHours.WorkingHours = &withLocation
+		query = &withWorkingHours
+	}
+
+	var events []storage.Event
+	for _, username := range query.Usernames {
+		userEvents, err := busyEvents(ctx, src, requester, username, query.Start.Add(-query.Buffer), query.End.Add(query.Buffer))
+		if err != nil {
+			if errors.Is(err, ErrNotAvailable) {
+				return nil, fmt.Errorf("%w: %s", err, username)
+			}
+			return nil, err
+		}
+		events = append(events, userEvents...)
+	}
+	slices.SortFunc(events, func(a, b storage.Event) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultSlotLimit
+	}
+	slots := make([]time.Time, 0, limit)
+	for _, window := range query.windows() {
+		for start := window.Start; !start.Add(query.Duration).After(window.End); {
+			//nolint:exhaustruct
+			candidate := storage.Event{StartTime: start.Add(-query.Buffer), EndTime: start.Add(query.Duration + query.Buffer)}
+			conflictEnd, err := lastConflictEnd(opts, &candidate, events)
+			if err != nil {
+				return nil, err
+			}
+			if conflictEnd.IsZero() {
+				slots = append(slots, start)
+				if len(slots) == limit {
+					return slots, nil
+				}
+				start = start.Add(SlotStep)
+				continue
+			}
+			// No slot starts before the conflicting event and the buffer end.
+			start = align(conflictEnd.Add(query.Buffer), window.Start)
+		}
+	}
+
+	return slots, nil
+}
+
+func (q *SlotQuery) validate() error {
+	switch {
+	case q.Duration <= 0:
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidSlotQuery)
+	case q.Buffer < 0:
+		return fmt.Errorf("%w: buffer must not be negative", ErrInvalidSlotQuery)
+	case !q.Start.Before(q.End):
+		return fmt.Errorf("%w: start time must be before end time", ErrInvalidSlotQuery)
+	case q.End.Sub(q.Start) > MaxSlotRange:
+		return fmt.Errorf("%w: time range longer than %s", ErrInvalidSlotQuery, MaxSlotRange)
+	}
+	if wh := q.WorkingHours; wh != nil {
+		if wh.Start < 0 || wh.End > 24*time.Hour || wh.Start >= wh.End {
+			return fmt.Errorf("%w: working hours must start before they end within a day", ErrInvalidSlotQuery)
+		}
+	}
+
+	return nil
+}
+
+// windows returns the periods within the query bounds the slots must fit
+// in, their starts aligned to SlotStep.
+func (q *SlotQuery) windows() []Interval {
+	wh := q.WorkingHours
+	loc := time.UTC
+	if wh != nil && wh.Location != nil {
+		loc = wh.Location
+	}
+	start := q.Start.In(loc)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	if wh == nil {
+		return []Interval{{Start: align(start, day), End: q.End.In(loc)}}
+	}
+
+	var windows []Interval
+	for ; day.Before(q.End); day = day.AddDate(0, 0, 1) {
+		if len(wh.Weekdays) != 0 && !slices.Contains(wh.Weekdays, day.Weekday()) {
+			continue
+		}
+		window := Interval{Start: day.Add(wh.Start), End: day.Add(wh.End)}
+		if window.Start.Before(start) {
+			window.Start = align(start, day)
+		}
+		if window.End.After(q.End) {
+			window.End = q.End.In(loc)
+		}
+		if window.Start.Before(window.End) {
+			windows = append(windows, window)
+		}
+	}
+
+	return windows
+}
+
+// align returns the first time from t that is a whole number of SlotStep
+// after origin.
+func align(t, origin time.Time) time.Time {
+	if rem := t.Sub(origin) % SlotStep; rem > 0 {
+		return t.Add(SlotStep - rem)
+	}
+
+	return t
+}
+
+// lastConflictEnd returns the end of the event conflicting with the
+// candidate that ends last, zero if none. The events must be sorted by start
+// time.
+func lastConflictEnd(opts storage.Options, candidate *storage.Event, events []storage.Event) (time.Time, error) {
+	var conflictEnd time.Time
+	for i := range events {
+		// All-day events start up to storage.MaxZoneOffset before their
+		// stored start in their time zone.
+		if !events[i].StartTime.Add(-storage.MaxZoneOffset).Before(candidate.EndTime) {
+			break
+		}
+		ok, err := opts.Conflicts(candidate, &events[i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("check conflict: %w", err)
+		}
+		if !ok {
+			continue
+		}
+		_, end, err := events[i].Span()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("check conflict: %w", err)
+		}
+		if end.After(conflictEnd) {
+			conflictEnd = end
+		}
+	}
+
+	return conflictEnd, nil
+}
@@ -0,0 +1,116 @@
+package freebusy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestFindSlots(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	// Monday.
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	at := func(days, hours, minutes int) time.Time {
+		return day.AddDate(0, 0, days).Add(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	}
+
+	for _, user := range []storage.User{
+		{Name: "alice", ShareFreeBusy: true},
+		{Name: "bob", ShareFreeBusy: false},
+	} {
+		if err := st.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	for _, event := range []storage.Event{
+		{Title: "Stand-up", Username: "alice", StartTime: at(0, 9, 0), EndTime: at(0, 9, 15), RRule: "FREQ=DAILY"},
+		{Title: "Review", Username: "alice", StartTime: at(0, 9, 30), EndTime: at(0, 10, 40)},
+		{Title: "Webinar", Username: "alice", StartTime: at(0, 11, 0), EndTime: at(0, 12, 0), Transparency: storage.TransparencyFree},
+		{Title: "Lunch", Username: "bob", StartTime: at(0, 10, 30), EndTime: at(0, 11, 30)},
+	} {
+		if _, err := st.CreateEvent(ctx, &event); err != nil {
+			t.Fatalf("CreateEvent: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query SlotQuery
+		want  []time.Time
+	}{
+		{
+			name: "working hours",
+			query: SlotQuery{
+				Usernames:    []string{"alice", "bob"},
+				Duration:     30 * time.Minute,
+				Start:        day,
+				End:          day.AddDate(0, 0, 7),
+				WorkingHours: &WorkingHours{Start: 9 * time.Hour, End: 12 * time.Hour, Weekdays: nil, Location: time.UTC},
+				Buffer:       0,
+				Limit:        4,
+			},
+			want: []time.Time{at(0, 11, 30), at(1, 9, 15), at(1, 9, 30), at(1, 9, 45)},
+		},
+		{
+			name: "weekdays",
+			query: SlotQuery{
+				Usernames:    []string{"bob"},
+				Duration:     time.Hour,
+				Start:        at(0, 12, 7),
+				End:          day.AddDate(0, 0, 7),
+				WorkingHours: &WorkingHours{Start: 17 * time.Hour, End: 18 * time.Hour, Weekdays: []time.Weekday{time.Saturday}, Location: time.UTC},
+				Buffer:       0,
+				Limit:        0,
+			},
+			want: []time.Time{at(5, 17, 0)},
+		},
+		{
+			name: "no working hours",
+			query: SlotQuery{
+				Usernames:    []string{"alice"},
+				Duration:     15 * time.Minute,
+				Start:        at(0, 8, 50),
+				End:          at(0, 11, 0),
+				WorkingHours: nil,
+				Buffer:       0,
+				Limit:        2,
+			},
+			want: []time.Time{at(0, 9, 15), at(0, 10, 45)},
+		},
+		{
+			name: "buffer",
+			query: SlotQuery{
+				Usernames:    []string{"alice"},
+				Duration:     30 * time.Minute,
+				Start:        at(0, 9, 0),
+				End:          at(0, 12, 0),
+				WorkingHours: nil,
+				Buffer:       15 * time.Minute,
+				Limit:        2,
+			},
+			want: []time.Time{at(0, 11, 0), at(0, 11, 15)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := FindSlots(ctx, st, storage.NewOptions(), "bob", &test.query)
+			if err != nil {
+				t.Fatalf("FindSlots: %v", err)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("FindSlots: expected %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	query := SlotQuery{Usernames: []string{"bob"}, Duration: time.Hour, Start: day, End: day.AddDate(0, 0, 1), WorkingHours: nil, Buffer: 0, Limit: 1}
+	if _, err := FindSlots(ctx, st, storage.NewOptions(), "alice", &query); !errors.Is(err, ErrNotAvailable) {
+		t.Errorf("FindSlots with a user not sharing: expected %v, got %v", ErrNotAvailable, err)
+	}
+}
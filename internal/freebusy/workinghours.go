@@ -0,0 +1,29 @@
+package freebusy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// ParseClock parses the time of day in the form 15:04 as the time since the
+// midnight. The end of the day is 24:00.
+func ParseClock(s string) (time.Duration, error) {
+	clock, err := storage.ParseClock(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidSlotQuery, err)
+	}
+
+	return clock, nil
+}
+
+// ParseWeekdays parses the days of the week given as in RRULE BYDAY: MO, TU...
+func ParseWeekdays(days []string) ([]time.Weekday, error) {
+	result, err := storage.ParseWeekdays(days)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSlotQuery, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,175 @@
+// Package icalendar converts events to and from iCalendar objects.
+package icalendar
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+const ProdID = "-//mrvin//Calendar//EN"
+
+// ContentType is the media type of iCalendar objects.
+const ContentType = "text/calendar; charset=utf-8"
+
+// exportWindow is the period before and after now exported by default.
+const exportWindow = 365 * 24 * time.Hour
+
+type EventSource interface {
+	GetEvent(ctx context.Context, username string, id uuid.UUID) (*storage.Event, error)
+	ListEvents(ctx context.Context, username string, start, end time.Time) ([]storage.Event, error)
+}
+
+// DefaultWindow returns the period exported when none is requested.
+func DefaultWindow(now time.Time) (time.Time, time.Time) {
+	return now.Add(-exportWindow), now.Add(exportWindow)
+}
+
+func NewCalendar() *ical.Component {
+	cal := ical.NewComponent(ical.CompCalendar)
+	cal.Add("VERSION", "2.0")
+	cal.Add("PRODID", ProdID)
+	cal.Add("CALSCALE", "GREGORIAN")
+
+	return cal
+}
+
+// Export returns the events of the user which overlap [start, end) as a
+// VCALENDAR. A recurring event is exported once as the whole series.
+func Export(ctx context.Context, src EventSource, username string, start, end time.Time) (*ical.Component, error) {
+	events, err := src.ListEvents(ctx, username, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	cal := NewCalendar()
+	now := time.Now()
+	exported := make(map[uuid.UUID]bool)
+	for i := range events {
+		event := &events[i]
+		if exported[event.ID] {
+			continue
+		}
+		exported[event.ID] = true
+		if event.IsRecurring() {
+			// Occurrences carry their own start time, the series keeps the first one.
+			if event, err = src.GetEvent(ctx, username, event.ID); err != nil {
+				return nil, fmt.Errorf("get event: %w", err)
+			}
+		}
+		cal.Children = append(cal.Children, VEvent(event, now))
+	}
+	AddTimezones(cal)
+
+	return cal, nil
+}
+
+// AddTimezones puts a VTIMEZONE in the calendar for each time zone its events
+// refer to by TZID, RFC 5545 section 3.2.19, with the observances from the
+// earliest time given in the time zone.
+func AddTimezones(cal *ical.Component) {
+	var tzids []string
+	from := make(map[string]time.Time)
+	for _, vevent := range cal.ChildrenByName(ical.CompEvent) {
+		for i := range vevent.Props {
+			prop := &vevent.Props[i]
+			tzid := prop.Param("TZID")
+			if tzid == "" {
+				continue
+			}
+			t, err := ical.ParseDateTime(prop, time.UTC)
+			if err != nil {
+				continue
+			}
+			earliest, ok := from[tzid]
+			if !ok {
+				tzids = append(tzids, tzid)
+			}
+			if !ok || t.Before(earliest) {
+				from[tzid] = t
+			}
+		}
+	}
+
+	vtimezones := make([]*ical.Component, 0, len(tzids)+len(cal.Children))
+	for _, tzid := range tzids {
+		loc, err := storage.LoadLocation(tzid)
+		if err != nil {
+			continue
+		}
+		vtimezones = append(vtimezones, ical.VTimezone(loc, from[tzid]))
+	}
+	cal.Children = append(vtimezones, cal.Children...)
+}
+
+// UID returns the iCalendar UID of the event: the imported one, if any, or
+// the event ID. An override of an occurrence shares the UID of its series.
+func UID(event *storage.Event) string {
+	return event.CalendarUID()
+}
+
+// VEvent converts the event to a VEVENT component.
+func VEvent(event *storage.Event, now time.Time) *ical.Component {
+	vevent := ical.NewComponent(ical.CompEvent)
+	vevent.Add("UID", UID(event))
+	vevent.Add("DTSTAMP", ical.FormatDateTime(now))
+	addTime(vevent, "DTSTART", event.StartTime, event)
+	addTime(vevent, "DTEND", event.EndTime, event)
+	vevent.AddText("SUMMARY", event.Title)
+	if event.Description != "" {
+		vevent.AddText("DESCRIPTION", event.Description)
+	}
+	if !event.IsBusy() {
+		vevent.Add("TRANSP", "TRANSPARENT")
+	}
+	if event.RRule != "" {
+		vevent.Add("RRULE", event.RRule)
+		for _, exDate := range event.ExDates {
+			addTime(vevent, "EXDATE", exDate, event)
+		}
+	}
+	if event.ParentID != nil && event.RecurrenceID != nil {
+		addTime(vevent, "RECURRENCE-ID", *event.RecurrenceID, event)
+	}
+	if event.Sequence > 0 {
+		vevent.Add("SEQUENCE", strconv.Itoa(event.Sequence))
+	}
+	// Reminders by several channels at the same time are one alarm.
+	offsets := make(map[time.Duration]bool, len(event.Reminders))
+	for _, reminder := range event.Reminders {
+		if offsets[reminder.Offset] {
+			continue
+		}
+		offsets[reminder.Offset] = true
+		valarm := ical.NewComponent(ical.CompAlarm)
+		valarm.Add("ACTION", "DISPLAY")
+		valarm.AddText("DESCRIPTION", event.Title)
+		valarm.Add("TRIGGER", ical.FormatDuration(-reminder.Offset))
+		vevent.Children = append(vevent.Children, valarm)
+	}
+
+	return vevent
+}
+
+// addTime appends a DATE-TIME property, or a DATE one for an all-day event.
+// Times of an event with a time zone are given on its wall clock with the
+// IANA name as TZID, so that clients repeat the event at the same local time.
+// The calendar must define the time zone, see AddTimezones.
+func addTime(vevent *ical.Component, name string, t time.Time, event *storage.Event) {
+	if event.AllDay {
+		vevent.Add(name, ical.FormatDate(t.UTC()), "VALUE", "DATE")
+		return
+	}
+	if event.TimeZone != "" && event.TimeZone != "UTC" {
+		if loc, err := storage.LoadLocation(event.TimeZone); err == nil {
+			vevent.Add(name, ical.FormatLocalDateTime(t.In(loc)), "TZID", event.TimeZone)
+			return
+		}
+	}
+	vevent.Add(name, ical.FormatDateTime(t))
+}
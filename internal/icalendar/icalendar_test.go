@@ -0,0 +1,93 @@
+package icalendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+func TestExport(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	series := storage.Event{
+		Title:     "Stand-up",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		Reminders: []storage.Reminder{
+			{Offset: 10 * time.Minute, Channel: storage.ChannelEmail},
+			{Offset: 10 * time.Minute, Channel: storage.ChannelWebhook},
+			{Offset: time.Hour, Channel: storage.ChannelEmail},
+		},
+		RRule:    "FREQ=DAILY;COUNT=5",
+		Username: "bob",
+	}
+	if _, err := st.CreateEvent(ctx, &series); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	single := storage.Event{
+		Title:       "Review, final",
+		Description: "Line 1\nLine 2",
+		StartTime:   start.Add(2 * time.Hour),
+		EndTime:     start.Add(3 * time.Hour),
+		TimeZone:    "Europe/Berlin",
+		Username:    "bob",
+	}
+	if _, err := st.CreateEvent(ctx, &single); err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	cal, err := Export(ctx, st, "bob", start, start.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	cal, err = ical.Decode(strings.NewReader(cal.String()))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	// The time zone referred to by TZID is defined in the calendar.
+	vtimezones := cal.ChildrenByName(ical.CompTimezone)
+	if len(vtimezones) != 1 || vtimezones[0].Get("TZID").Value != "Europe/Berlin" {
+		t.Fatalf("expected the VTIMEZONE of Europe/Berlin, got %d", len(vtimezones))
+	}
+	zones := ical.ParseTimezones(cal)
+	if _, ok := zones["Europe/Berlin"]; !ok || len(vtimezones[0].ChildrenByName(ical.CompDaylight)) != 1 {
+		t.Errorf("unexpected VTIMEZONE:\n%s", vtimezones[0])
+	}
+
+	vevents := cal.ChildrenByName(ical.CompEvent)
+	if len(vevents) != 2 {
+		t.Fatalf("expected series and single event, got %d VEVENTs", len(vevents))
+	}
+	for _, vevent := range vevents {
+		switch vevent.Get("UID").Value {
+		case series.ID.String():
+			if vevent.Get("RRULE").Value != series.RRule {
+				t.Errorf("RRULE: have %q", vevent.Get("RRULE").Value)
+			}
+			if vevent.Get("DTSTART").Value != "20250106T090000Z" {
+				t.Errorf("DTSTART must be the series start, have %q", vevent.Get("DTSTART").Value)
+			}
+			alarms := vevent.ChildrenByName(ical.CompAlarm)
+			if len(alarms) != 2 || alarms[0].Get("TRIGGER").Value != "-PT10M" || alarms[1].Get("TRIGGER").Value != "-PT1H" {
+				t.Errorf("expected VALARMs with TRIGGER:-PT10M and TRIGGER:-PT1H")
+			}
+		case single.ID.String():
+			if vevent.Text("SUMMARY") != single.Title || vevent.Text("DESCRIPTION") != single.Description {
+				t.Errorf("text not round-tripped: %q %q", vevent.Text("SUMMARY"), vevent.Text("DESCRIPTION"))
+			}
+			if prop := vevent.Get("DTSTART"); prop.Value != "20250106T120000" || prop.Param("TZID") != "Europe/Berlin" {
+				t.Errorf("unexpected DTSTART: %+v", prop)
+			}
+		default:
+			t.Errorf("unexpected UID %q", vevent.Get("UID").Value)
+		}
+	}
+}
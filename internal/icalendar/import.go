@@ -0,0 +1,260 @@
+package icalendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+	"github.com/mrvin/calendar/pkg/rrule"
+)
+
+// Limits of the event fields, the same as for events created through the API.
+const (
+	minTitleLen       = 2
+	maxTitleLen       = 64
+	maxDescriptionLen = 512
+	maxRRuleLen       = 256
+)
+
+var ErrInvalidEvent = errors.New("invalid event")
+
+type ImportStatus string
+
+const (
+	ImportCreated   ImportStatus = "created"
+	ImportDuplicate ImportStatus = "duplicate"
+	ImportBusy      ImportStatus = "busy"
+	ImportInvalid   ImportStatus = "invalid"
+)
+
+//nolint:tagliatelle
+type ImportResult struct {
+	UID          string       `json:"uid"`
+	RecurrenceID *time.Time   `json:"recurrence_id,omitempty"`
+	Status       ImportStatus `json:"status"`
+	ID           *uuid.UUID   `json:"id,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+type EventImporter interface {
+	CreateEvent(ctx context.Context, event *storage.Event) (uuid.UUID, error)
+	UpdateEventOccurrence(ctx context.Context, username string, id uuid.UUID, recurrenceID time.Time, scope storage.Scope, event *storage.Event) error
+}
+
+// Import creates an event for every VEVENT of the calendar and reports the
+// result for each of them. Overrides of occurrences are applied to the series
+// imported from the same calendar. Only storage failures abort the import.
+func Import(ctx context.Context, dst EventImporter, username string, cal *ical.Component) ([]ImportResult, error) {
+	vevents := cal.ChildrenByName(ical.CompEvent)
+	results := make([]ImportResult, 0, len(vevents))
+	overrides := make([]*storage.Event, 0)
+	series := make(map[string]int)
+	zones := ical.ParseTimezones(cal)
+
+	// Series go first, so that their overrides can refer to them.
+	for _, vevent := range vevents {
+		event, err := Event(vevent, time.UTC, zones)
+		if err != nil {
+			results = append(results, ImportResult{UID: vevent.Text("UID"), Status: ImportInvalid, Error: err.Error()})
+			continue
+		}
+		event.Username = username
+		if event.RecurrenceID != nil {
+			overrides = append(overrides, event)
+			continue
+		}
+
+		result := ImportResult{UID: event.UID, Status: ImportCreated}
+		id, err := dst.CreateEvent(ctx, event)
+		switch {
+		case err == nil:
+			result.ID = &id
+		case errors.Is(err, storage.ErrDuplicateUID):
+			result.Status = ImportDuplicate
+		case errors.Is(err, storage.ErrDateBusy):
+			result.Status, result.Error = ImportBusy, err.Error()
+		default:
+			return nil, fmt.Errorf("create event %q: %w", event.UID, err)
+		}
+		if _, ok := series[event.UID]; !ok {
+			series[event.UID] = len(results)
+		}
+		results = append(results, result)
+	}
+
+	for _, event := range overrides {
+		result := ImportResult{UID: event.UID, RecurrenceID: event.RecurrenceID, Status: ImportCreated}
+		i, ok := series[event.UID]
+		switch {
+		case !ok:
+			result.Status, result.Error = ImportInvalid, "recurring event not found"
+		case results[i].Status != ImportCreated:
+			result.Status = results[i].Status
+		default:
+			err := dst.UpdateEventOccurrence(ctx, username, *results[i].ID, *event.RecurrenceID, storage.ScopeThis, event)
+			switch {
+			case err == nil:
+				result.ID = &event.ID
+			case errors.Is(err, storage.ErrOccurrenceNotFound):
+				result.Status, result.Error = ImportInvalid, err.Error()
+			case errors.Is(err, storage.ErrDateBusy):
+				result.Status, result.Error = ImportBusy, err.Error()
+			default:
+				return nil, fmt.Errorf("update occurrence %q %s: %w", event.UID, event.RecurrenceID, err)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Event converts the VEVENT component to an event. Floating times are
+// interpreted in loc, TZIDs are resolved through the time zones of the
+// calendar. An override of an occurrence has RecurrenceID set.
+//
+//nolint:cyclop
+func Event(vevent *ical.Component, loc *time.Location, zones ical.Timezones) (*storage.Event, error) {
+	uid := vevent.Text("UID")
+	if uid == "" {
+		return nil, fmt.Errorf("%w: missing UID", ErrInvalidEvent)
+	}
+	title := strings.TrimSpace(vevent.Text("SUMMARY"))
+	if n := utf8.RuneCountInString(title); n < minTitleLen || n > maxTitleLen {
+		return nil, fmt.Errorf("%w: SUMMARY must be from %d to %d characters", ErrInvalidEvent, minTitleLen, maxTitleLen)
+	}
+	description := vevent.Text("DESCRIPTION")
+	if utf8.RuneCountInString(description) > maxDescriptionLen {
+		return nil, fmt.Errorf("%w: DESCRIPTION longer than %d characters", ErrInvalidEvent, maxDescriptionLen)
+	}
+
+	dtStart := vevent.Get("DTSTART")
+	startTime, err := zones.ParseDateTime(dtStart, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: DTSTART: %w", ErrInvalidEvent, err)
+	}
+	// The events keep the IANA name of the time zone. The time zones only
+	// defined by the calendar have none, their events are kept in UTC.
+	timeZone := ""
+	if dtStart.Param("TZID") != "" {
+		if _, err := storage.LoadLocation(startTime.Location().String()); err == nil {
+			timeZone = startTime.Location().String()
+		}
+	}
+	endTime := startTime
+	switch {
+	case vevent.Get("DTEND") != nil:
+		if endTime, err = zones.ParseDateTime(vevent.Get("DTEND"), loc); err != nil {
+			return nil, fmt.Errorf("%w: DTEND: %w", ErrInvalidEvent, err)
+		}
+	case vevent.Get("DURATION") != nil:
+		duration, err := ical.ParseDuration(vevent.Get("DURATION").Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: DURATION: %w", ErrInvalidEvent, err)
+		}
+		endTime = startTime.Add(duration)
+	case ical.IsDate(dtStart):
+		endTime = startTime.AddDate(0, 0, 1)
+	}
+	if startTime.After(endTime) {
+		return nil, fmt.Errorf("%w: DTSTART must be before or equal to DTEND", ErrInvalidEvent)
+	}
+	// Dates of all-day events do not depend on the time zone.
+	allDay := ical.IsDate(dtStart)
+	if allDay {
+		startTime, endTime = storage.Date(startTime), storage.Date(endTime)
+	}
+
+	//nolint:exhaustruct
+	event := storage.Event{
+		Title:        title,
+		Description:  description,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		AllDay:       allDay,
+		TimeZone:     timeZone,
+		Transparency: transparency(vevent),
+		UID:          uid,
+	}
+
+	if prop := vevent.Get("RRULE"); prop != nil {
+		if len(prop.Value) > maxRRuleLen {
+			return nil, fmt.Errorf("%w: RRULE longer than %d characters", ErrInvalidEvent, maxRRuleLen)
+		}
+		rule, err := rrule.Parse(prop.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: RRULE: %w", ErrInvalidEvent, err)
+		}
+		event.RRule = rule.String()
+		for _, exProp := range vevent.GetAll("EXDATE") {
+			for value := range strings.SplitSeq(exProp.Value, ",") {
+				exProp.Value = value
+				exDate, err := zones.ParseDateTime(&exProp, loc)
+				if err != nil {
+					return nil, fmt.Errorf("%w: EXDATE: %w", ErrInvalidEvent, err)
+				}
+				if allDay && ical.IsDate(&exProp) {
+					exDate = storage.Date(exDate)
+				}
+				event.ExDates = append(event.ExDates, exDate)
+			}
+		}
+	}
+
+	if prop := vevent.Get("RECURRENCE-ID"); prop != nil {
+		recurrenceID, err := zones.ParseDateTime(prop, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: RECURRENCE-ID: %w", ErrInvalidEvent, err)
+		}
+		if allDay && ical.IsDate(prop) {
+			recurrenceID = storage.Date(recurrenceID)
+		}
+		event.RecurrenceID = &recurrenceID
+	}
+
+	event.Reminders = reminders(vevent)
+
+	return &event, nil
+}
+
+// transparency returns the transparency of the event, busy by default.
+func transparency(vevent *ical.Component) storage.Transparency {
+	if prop := vevent.Get("TRANSP"); prop != nil && strings.EqualFold(prop.Value, "TRANSPARENT") {
+		return storage.TransparencyFree
+	}
+
+	return storage.TransparencyBusy
+}
+
+// reminders returns an email reminder for each alarm of the event triggered
+// not later than its start, up to storage.MaxReminders.
+func reminders(vevent *ical.Component) []storage.Reminder {
+	var reminders []storage.Reminder
+	for _, valarm := range vevent.ChildrenByName(ical.CompAlarm) {
+		trigger := valarm.Get("TRIGGER")
+		if trigger == nil || trigger.Param("VALUE") == "DATE-TIME" || trigger.Param("RELATED") == "END" {
+			continue
+		}
+		d, err := ical.ParseDuration(trigger.Value)
+		if err != nil || d > 0 {
+			continue
+		}
+		reminder := storage.Reminder{Offset: -d, Channel: storage.ChannelEmail}
+		if slices.Contains(reminders, reminder) {
+			continue
+		}
+		reminders = append(reminders, reminder)
+		if len(reminders) == storage.MaxReminders {
+			break
+		}
+	}
+
+	return reminders
+}
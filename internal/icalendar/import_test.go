@@ -0,0 +1,221 @@
+package icalendar
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+const importCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTART;TZID=UTC:20250106T090000\r\n" +
+	"DURATION:PT15M\r\n" +
+	"SUMMARY:Stand-up\r\n" +
+	"RRULE:FREQ=DAILY;COUNT=5\r\n" +
+	"EXDATE:20250108T090000Z,20250109T090000Z\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER:-PT5M\r\n" +
+	"END:VALARM\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:EMAIL\r\n" +
+	"TRIGGER:-PT1H\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"RECURRENCE-ID:20250107T090000Z\r\n" +
+	"DTSTART:20250107T100000Z\r\n" +
+	"DTEND:20250107T101500Z\r\n" +
+	"SUMMARY:Late stand-up\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:busy@example.com\r\n" +
+	"DTSTART:20250106T091000Z\r\n" +
+	"DTEND:20250106T093000Z\r\n" +
+	"SUMMARY:Overlapping\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:invalid@example.com\r\n" +
+	"DTSTART:20250106T120000Z\r\n" +
+	"DTEND:20250106T110000Z\r\n" +
+	"SUMMARY:Ends before start\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:orphan@example.com\r\n" +
+	"RECURRENCE-ID:20250107T090000Z\r\n" +
+	"DTSTART:20250107T140000Z\r\n" +
+	"SUMMARY:No series\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestImport(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+
+	cal, err := ical.Decode(strings.NewReader(importCalendar))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	results, err := Import(ctx, st, "bob", cal)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := []ImportStatus{ImportCreated, ImportBusy, ImportInvalid, ImportCreated, ImportInvalid}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), results)
+	}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("result %d (%s): have %q, want %q: %s", i, result.UID, result.Status, want[i], result.Error)
+		}
+	}
+
+	series, err := st.GetEvent(ctx, "bob", *results[0].ID)
+	if err != nil {
+		t.Fatalf("GetEvent: %v", err)
+	}
+	if series.UID != "standup@example.com" || series.EndTime.Sub(series.StartTime) != 15*time.Minute {
+		t.Errorf("unexpected series: %+v", series)
+	}
+	wantReminders := []storage.Reminder{
+		{Offset: 5 * time.Minute, Channel: storage.ChannelEmail},
+		{Offset: time.Hour, Channel: storage.ChannelEmail},
+	}
+	if !slices.Equal(series.Reminders, wantReminders) {
+		t.Errorf("expected reminders %v, got %v", wantReminders, series.Reminders)
+	}
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	events, err := st.ListEvents(ctx, "bob", day, day.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	// 5 occurrences minus 2 exclusions and one moved by the override.
+	if len(events) != 3 {
+		t.Errorf("expected 3 events, got %d", len(events))
+	}
+
+	// A repeated import skips the series and their overrides.
+	results, err = Import(ctx, st, "bob", cal)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if results[0].Status != ImportDuplicate || results[3].Status != ImportDuplicate {
+		t.Errorf("expected duplicates, got %+v", results)
+	}
+}
+
+func TestEvent_AllDay(t *testing.T) {
+	vevent := ical.NewComponent(ical.CompEvent)
+	vevent.Add("UID", "vacation@example.com")
+	vevent.Add("DTSTART", "20250714", "VALUE", "DATE")
+	vevent.Add("DTEND", "20250719", "VALUE", "DATE")
+	vevent.AddText("SUMMARY", "Vacation")
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	event, err := Event(vevent, loc, nil)
+	if err != nil {
+		t.Fatalf("Event: %v", err)
+	}
+	start := time.Date(2025, time.July, 14, 0, 0, 0, 0, time.UTC)
+	if !event.AllDay || !event.StartTime.Equal(start) || !event.EndTime.Equal(start.AddDate(0, 0, 5)) {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	exported := VEvent(event, time.Now())
+	if prop := exported.Get("DTSTART"); prop.Value != "20250714" || !ical.IsDate(prop) {
+		t.Errorf("unexpected DTSTART: %+v", prop)
+	}
+	if prop := exported.Get("DTEND"); prop.Value != "20250719" || !ical.IsDate(prop) {
+		t.Errorf("unexpected DTEND: %+v", prop)
+	}
+}
+
+func TestEvent_TimeZone(t *testing.T) {
+	vevent := ical.NewComponent(ical.CompEvent)
+	vevent.Add("UID", "standup@example.com")
+	vevent.Add("DTSTART", "20250106T090000", "TZID", "Europe/Berlin")
+	vevent.Add("DURATION", "PT15M")
+	vevent.AddText("SUMMARY", "Stand-up")
+	vevent.Add("RRULE", "FREQ=WEEKLY")
+
+	event, err := Event(vevent, time.UTC, nil)
+	if err != nil {
+		t.Fatalf("Event: %v", err)
+	}
+	if event.TimeZone != "Europe/Berlin" || !event.StartTime.Equal(time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	exported := VEvent(event, time.Now())
+	if prop := exported.Get("DTSTART"); prop.Value != "20250106T090000" || prop.Param("TZID") != "Europe/Berlin" {
+		t.Errorf("unexpected DTSTART: %+v", prop)
+	}
+}
+
+func TestEvent_CalendarTimeZone(t *testing.T) {
+	cal, err := ical.Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:W. Europe Standard Time\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:16010101T030000\r\n" +
+		"TZOFFSETFROM:+0200\r\n" +
+		"TZOFFSETTO:+0100\r\n" +
+		"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=10\r\n" +
+		"END:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:16010101T020000\r\n" +
+		"TZOFFSETFROM:+0100\r\n" +
+		"TZOFFSETTO:+0200\r\n" +
+		"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=3\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Custom\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:19700101T000000\r\n" +
+		"TZOFFSETFROM:+0530\r\n" +
+		"TZOFFSETTO:+0530\r\n" +
+		"END:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	zones := ical.ParseTimezones(cal)
+
+	tests := []struct {
+		tzid     string
+		start    time.Time
+		timeZone string
+	}{
+		// Outlook names the time zones after Windows.
+		{"W. Europe Standard Time", time.Date(2025, time.July, 7, 7, 0, 0, 0, time.UTC), "Europe/Berlin"},
+		// A time zone without an IANA name is not kept.
+		{"Custom", time.Date(2025, time.July, 7, 3, 30, 0, 0, time.UTC), ""},
+	}
+	for _, test := range tests {
+		vevent := ical.NewComponent(ical.CompEvent)
+		vevent.Add("UID", "standup@example.com")
+		vevent.Add("DTSTART", "20250707T090000", "TZID", test.tzid)
+		vevent.Add("DTEND", "20250707T091500", "TZID", test.tzid)
+		vevent.AddText("SUMMARY", "Stand-up")
+
+		event, err := Event(vevent, time.UTC, zones)
+		if err != nil {
+			t.Errorf("%s: Event: %v", test.tzid, err)
+			continue
+		}
+		if !event.StartTime.Equal(test.start) || event.EndTime.Sub(event.StartTime) != 15*time.Minute || event.TimeZone != test.timeZone {
+			t.Errorf("%s: unexpected event: %+v", test.tzid, event)
+		}
+	}
+}
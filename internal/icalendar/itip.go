@@ -0,0 +1,120 @@
+package icalendar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+// ITIP returns the invitation as an iTIP message, RFC 5546: a VCALENDAR with
+// METHOD and the VEVENT with its organizer and attendees.
+func ITIP(invitation *storage.Invitation, now time.Time) *ical.Component {
+	cal := NewCalendar()
+	cal.Add("METHOD", string(invitation.Method))
+
+	event := &invitation.Event
+	vevent := VEvent(event, now)
+	// The alarm is a setting of the organizer.
+	vevent.Children = nil
+	addAddress(vevent, "ORGANIZER", invitation.Organizer, event.Organizer)
+	for _, attendee := range event.Attendees {
+		// A cancellation lists only the attendees it is sent to.
+		if invitation.Method == storage.MethodCancel && !slices.Contains(invitation.Recipients, attendee.Email) {
+			continue
+		}
+		status := attendee.Status
+		if status == "" {
+			status = storage.PartStatNeedsAction
+		}
+		params := []string{"PARTSTAT", strings.ToUpper(string(status))}
+		if status == storage.PartStatNeedsAction && invitation.Method == storage.MethodRequest {
+			params = append(params, "RSVP", "TRUE")
+		}
+		addAddress(vevent, "ATTENDEE", attendee.Email, attendee.Username, params...)
+	}
+	if invitation.Method == storage.MethodCancel {
+		for _, recipient := range invitation.Recipients {
+			if !slices.ContainsFunc(event.Attendees, func(a storage.Attendee) bool { return a.Email == recipient }) {
+				addAddress(vevent, "ATTENDEE", recipient, "")
+			}
+		}
+		vevent.Add("STATUS", "CANCELLED")
+	}
+	cal.Children = append(cal.Children, vevent)
+	AddTimezones(cal)
+
+	return cal
+}
+
+// addAddress appends a CAL-ADDRESS property with the common name, if any.
+func addAddress(vevent *ical.Component, name, email, commonName string, params ...string) {
+	if commonName != "" {
+		params = append([]string{"CN", commonName}, params...)
+	}
+	vevent.Add(name, "mailto:"+email, params...)
+}
+
+// ErrNotReply is returned for a calendar without METHOD:REPLY.
+var ErrNotReply = errors.New("not an iTIP REPLY")
+
+// Replies returns the responses of the attendees in an iTIP REPLY, one per
+// VEVENT and attendee. Only accepted, declined and tentative are responses.
+func Replies(cal *ical.Component) ([]storage.Reply, error) {
+	if method := cal.Get("METHOD"); method == nil || !strings.EqualFold(method.Value, "REPLY") {
+		return nil, ErrNotReply
+	}
+
+	zones := ical.ParseTimezones(cal)
+	var replies []storage.Reply
+	for _, vevent := range cal.ChildrenByName(ical.CompEvent) {
+		uid := vevent.Get("UID")
+		if uid == nil || uid.Value == "" {
+			return nil, errors.New("VEVENT without UID")
+		}
+		reply := storage.Reply{UID: uid.Value}
+		if prop := vevent.Get("SEQUENCE"); prop != nil {
+			sequence, err := strconv.Atoi(prop.Value)
+			if err != nil {
+				return nil, fmt.Errorf("SEQUENCE: %w", err)
+			}
+			reply.Sequence = sequence
+		}
+		if prop := vevent.Get("RECURRENCE-ID"); prop != nil {
+			recurrenceID, err := zones.ParseDateTime(prop, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("RECURRENCE-ID: %w", err)
+			}
+			reply.RecurrenceID = &recurrenceID
+		}
+		for _, attendee := range vevent.GetAll("ATTENDEE") {
+			email, ok := mailto(attendee.Value)
+			if !ok {
+				continue
+			}
+			status, err := storage.ParsePartStat(strings.ToLower(attendee.Param("PARTSTAT")))
+			if err != nil {
+				continue
+			}
+			reply.Email, reply.Status = email, status
+			replies = append(replies, reply)
+		}
+	}
+
+	return replies, nil
+}
+
+// mailto returns the email of a CAL-ADDRESS.
+func mailto(address string) (string, bool) {
+	const scheme = "mailto:"
+	if len(address) <= len(scheme) || !strings.EqualFold(address[:len(scheme)], scheme) {
+		return "", false
+	}
+
+	return address[len(scheme):], true
+}
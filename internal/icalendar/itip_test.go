@@ -0,0 +1,80 @@
+package icalendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+func TestITIP(t *testing.T) {
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := storage.Event{
+		ID:        uuid.New(),
+		Title:     "Planning",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Reminders: []storage.Reminder{{Offset: 10 * time.Minute, Channel: storage.ChannelEmail}},
+		Sequence:  2,
+		Organizer: "alice",
+		Attendees: []storage.Attendee{
+			{Username: "bob", Email: "bob@example.com", Status: storage.PartStatAccepted},
+			{Email: "carol@example.com", Status: storage.PartStatNeedsAction},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		method     storage.Method
+		recipients []string
+		attendees  []string
+	}{
+		{"request", storage.MethodRequest, []string{"carol@example.com"}, []string{"mailto:bob@example.com", "mailto:carol@example.com"}},
+		{"cancel removed", storage.MethodCancel, []string{"dave@example.com"}, []string{"mailto:dave@example.com"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			invitation := storage.Invitation{
+				Method:     test.method,
+				Event:      event,
+				Organizer:  "alice@example.com",
+				Recipients: test.recipients,
+			}
+			cal, err := ical.Decode(strings.NewReader(ITIP(&invitation, start).String()))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if method := cal.Get("METHOD").Value; method != string(test.method) {
+				t.Errorf("METHOD: have %q", method)
+			}
+			vevents := cal.ChildrenByName(ical.CompEvent)
+			if len(vevents) != 1 {
+				t.Fatalf("expected one VEVENT, got %d", len(vevents))
+			}
+			vevent := vevents[0]
+			if organizer := vevent.Get("ORGANIZER"); organizer.Value != "mailto:alice@example.com" || organizer.Param("CN") != "alice" {
+				t.Errorf("ORGANIZER: have %+v", organizer)
+			}
+			if sequence := vevent.Get("SEQUENCE").Value; sequence != "2" {
+				t.Errorf("SEQUENCE: have %q", sequence)
+			}
+			if len(vevent.ChildrenByName(ical.CompAlarm)) != 0 {
+				t.Error("the alarm of the organizer is sent")
+			}
+			var attendees []string
+			for _, attendee := range vevent.GetAll("ATTENDEE") {
+				attendees = append(attendees, attendee.Value)
+			}
+			if strings.Join(attendees, ",") != strings.Join(test.attendees, ",") {
+				t.Errorf("ATTENDEE: have %v, want %v", attendees, test.attendees)
+			}
+			cancelled := vevent.Get("STATUS") != nil && vevent.Get("STATUS").Value == "CANCELLED"
+			if cancelled != (test.method == storage.MethodCancel) {
+				t.Errorf("STATUS: cancelled %v", cancelled)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package poll tallies the votes of the polls on the time of meetings and
+// turns the winning option into an event.
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+var (
+	ErrNotOrganizer = errors.New("only the organizer may finalize the poll")
+	// ErrNoWinner is returned on finalizing a poll in which no option got a
+	// yes or if-needed vote, unless the option is chosen.
+	ErrNoWinner = errors.New("no option of the poll is acceptable to the invitees")
+)
+
+type Finalizer interface {
+	GetPoll(ctx context.Context, username string, id uuid.UUID) (*storage.Poll, error)
+	FinalizePoll(ctx context.Context, username string, id, optionID uuid.UUID, event *storage.Event) (uuid.UUID, error)
+}
+
+// OptionTally is the number of each vote on an option. Pending are the
+// invitees who did not vote on it.
+//
+//nolint:tagliatelle
+type OptionTally struct {
+	storage.PollOption
+	Yes      int      `json:"yes"`
+	IfNeeded int      `json:"if_needed"`
+	No       int      `json:"no"`
+	Pending  []string `json:"pending"`
+}
+
+// Tally counts the votes on each option of the poll, in the order of the
+// options.
+func Tally(poll *storage.Poll) []OptionTally {
+	tally := make([]OptionTally, len(poll.Options))
+	for i, option := range poll.Options {
+		tally[i].PollOption = option
+		voted := make(map[string]bool, len(poll.Invitees))
+		for _, vote := range poll.Votes {
+			if vote.OptionID != option.ID {
+				continue
+			}
+			voted[vote.Username] = true
+			switch vote.Vote {
+			case storage.VoteYes:
+				tally[i].Yes++
+			case storage.VoteIfNeeded:
+				tally[i].IfNeeded++
+			case storage.VoteNo:
+				tally[i].No++
+			}
+		}
+		tally[i].Pending = make([]string, 0)
+		for _, invitee := range poll.Invitees {
+			if !voted[invitee] {
+				tally[i].Pending = append(tally[i].Pending, invitee)
+			}
+		}
+	}
+
+	return tally
+}
+
+// Winner returns the option most invitees can attend, preferring those with
+// more yes votes and then the earlier ones, or nil if nobody can attend any.
+func Winner(tally []OptionTally) *OptionTally {
+	var winner *OptionTally
+	for i := range tally {
+		option := &tally[i]
+		if option.Yes+option.IfNeeded == 0 {
+			continue
+		}
+		if winner == nil || better(option, winner) {
+			winner = option
+		}
+	}
+
+	return winner
+}
+
+func better(a, b *OptionTally) bool {
+	if a.Yes+a.IfNeeded != b.Yes+b.IfNeeded {
+		return a.Yes+a.IfNeeded > b.Yes+b.IfNeeded
+	}
+
+	return a.Yes > b.Yes
+}
+
+// Finalize creates the event of the option of the poll, the winner if
+// optionID is nil, owned by the organizer with the invitees as attendees.
+// The attendees get an invitation to it like to any event.
+func Finalize(ctx context.Context, finalizer Finalizer, username string, id uuid.UUID, optionID *uuid.UUID) (*storage.Event, error) {
+	poll, err := finalizer.GetPoll(ctx, username, id)
+	if err != nil {
+		return nil, fmt.Errorf("get poll: %w", err)
+	}
+	if poll.Username != username {
+		return nil, ErrNotOrganizer
+	}
+	if poll.IsFinalized() {
+		return nil, fmt.Errorf("%w: %s", storage.ErrPollFinalized, id)
+	}
+
+	var option *storage.PollOption
+	if optionID != nil {
+		if option = poll.Option(*optionID); option == nil {
+			return nil, fmt.Errorf("%w: %s", storage.ErrPollOptionNotFound, *optionID)
+		}
+	} else {
+		winner := Winner(Tally(poll))
+		if winner == nil {
+			return nil, ErrNoWinner
+		}
+		option = &winner.PollOption
+	}
+
+	attendees := make([]storage.Attendee, len(poll.Invitees))
+	for i, invitee := range poll.Invitees {
+		attendees[i] = storage.Attendee{Username: invitee, Email: "", Status: storage.PartStatNeedsAction, Availability: ""}
+	}
+	//nolint:exhaustruct
+	event := storage.Event{
+		Title:       poll.Title,
+		Description: poll.Description,
+		StartTime:   option.StartTime,
+		EndTime:     option.EndTime,
+		Username:    username,
+		Attendees:   attendees,
+	}
+	if _, err := finalizer.FinalizePoll(ctx, username, id, option.ID, &event); err != nil {
+		return nil, fmt.Errorf("finalize poll: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Check returns an error if an option of the new poll does not end after it
+// starts, two options are the same or the organizer is among the invitees.
+func Check(poll *storage.Poll) error {
+	seen := make(map[[2]time.Time]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		if !option.StartTime.Before(option.EndTime) {
+			return errors.New("option must start before it ends")
+		}
+		key := [2]time.Time{option.StartTime.UTC(), option.EndTime.UTC()}
+		if seen[key] {
+			return errors.New("options must differ")
+		}
+		seen[key] = true
+	}
+	if slices.Contains(poll.Invitees, poll.Username) {
+		return errors.New("organizer must not be invited")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestTally(t *testing.T) {
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	poll := storage.Poll{
+		Options: []storage.PollOption{
+			{ID: [16]byte{1}, StartTime: start, EndTime: start.Add(time.Hour)},
+			{ID: [16]byte{2}, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)},
+			{ID: [16]byte{3}, StartTime: start.Add(4 * time.Hour), EndTime: start.Add(5 * time.Hour)},
+		},
+		Invitees: []string{"bob", "carol", "dave"},
+		Votes: []storage.PollVote{
+			{OptionID: [16]byte{1}, Username: "bob", Vote: storage.VoteIfNeeded},
+			{OptionID: [16]byte{2}, Username: "bob", Vote: storage.VoteYes},
+			{OptionID: [16]byte{3}, Username: "bob", Vote: storage.VoteNo},
+			{OptionID: [16]byte{1}, Username: "carol", Vote: storage.VoteYes},
+			{OptionID: [16]byte{2}, Username: "carol", Vote: storage.VoteIfNeeded},
+			{OptionID: [16]byte{3}, Username: "carol", Vote: storage.VoteNo},
+		},
+	}
+
+	tally := Tally(&poll)
+	for i, want := range [][3]int{{1, 1, 0}, {1, 1, 0}, {0, 0, 2}} {
+		got := [3]int{tally[i].Yes, tally[i].IfNeeded, tally[i].No}
+		if got != want {
+			t.Errorf("Tally option %d: expected %v, got %v", i, want, got)
+		}
+		if !slices.Equal(tally[i].Pending, []string{"dave"}) {
+			t.Errorf("Tally option %d: expected pending [dave], got %v", i, tally[i].Pending)
+		}
+	}
+	// A tie goes to the earlier option.
+	if winner := Winner(tally); winner == nil || winner.ID != poll.Options[0].ID {
+		t.Errorf("Winner: expected option %s, got %+v", poll.Options[0].ID, winner)
+	}
+	// More yes votes win a tie of the attendable votes.
+	poll.Votes[0].Vote = storage.VoteIfNeeded
+	poll.Votes[3].Vote = storage.VoteIfNeeded
+	if winner := Winner(Tally(&poll)); winner == nil || winner.ID != poll.Options[1].ID {
+		t.Errorf("Winner: expected option %s, got %+v", poll.Options[1].ID, winner)
+	}
+	// Nobody can attend.
+	if winner := Winner(Tally(&poll)[2:]); winner != nil {
+		t.Errorf("Winner: expected none, got %+v", winner)
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	for _, name := range []string{"alice", "bob", "carol"} {
+		user := storage.User{Name: name, Email: name + "@example.com"}
+		if err := st.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	poll := storage.Poll{
+		Title: "Planning",
+		Options: []storage.PollOption{
+			{StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)},
+			{StartTime: start, EndTime: start.Add(time.Hour)},
+		},
+		Invitees: []string{"bob", "carol"},
+		Username: "alice",
+	}
+	if err := Check(&poll); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	id, err := st.CreatePoll(ctx, &poll)
+	if err != nil {
+		t.Fatalf("CreatePoll: %v", err)
+	}
+	// The options are kept by start time.
+	early, late := poll.Options[0], poll.Options[1]
+	if !early.StartTime.Equal(start) {
+		t.Fatalf("CreatePoll: expected the earliest option first, got %v", poll.Options)
+	}
+
+	if _, err := Finalize(ctx, st, "alice", id, nil); !errors.Is(err, ErrNoWinner) {
+		t.Errorf("Finalize without votes: expected %v, got %v", ErrNoWinner, err)
+	}
+	if err := st.SetVotes(ctx, "alice", id, nil); !errors.Is(err, storage.ErrPollNotFound) {
+		t.Errorf("SetVotes by organizer: expected %v, got %v", storage.ErrPollNotFound, err)
+	}
+	votes := map[string][]storage.PollVote{
+		"bob":   {{OptionID: early.ID, Vote: storage.VoteNo}, {OptionID: late.ID, Vote: storage.VoteYes}},
+		"carol": {{OptionID: early.ID, Vote: storage.VoteYes}, {OptionID: late.ID, Vote: storage.VoteIfNeeded}},
+	}
+	for username, userVotes := range votes {
+		if err := st.SetVotes(ctx, username, id, userVotes); err != nil {
+			t.Fatalf("SetVotes %s: %v", username, err)
+		}
+	}
+	if _, err := Finalize(ctx, st, "bob", id, nil); !errors.Is(err, ErrNotOrganizer) {
+		t.Errorf("Finalize by invitee: expected %v, got %v", ErrNotOrganizer, err)
+	}
+
+	event, err := Finalize(ctx, st, "alice", id, nil)
+	if err != nil {
+		t.Fatalf("Finalize: %v", err)
+	}
+	if !event.StartTime.Equal(late.StartTime) || event.Title != "Planning" || len(event.Attendees) != 2 {
+		t.Errorf("Finalize: unexpected event %+v", event)
+	}
+	got, err := st.GetPoll(ctx, "carol", id)
+	if err != nil {
+		t.Fatalf("GetPoll: %v", err)
+	}
+	if got.FinalOptionID == nil || *got.FinalOptionID != late.ID || got.EventID == nil || *got.EventID != event.ID {
+		t.Errorf("GetPoll: expected poll finalized with event %s, got %+v", event.ID, got)
+	}
+	// The invitees are notified through the invitation outbox.
+	invitations, err := st.ListInvitations(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListInvitations: %v", err)
+	}
+	if len(invitations) != 1 || invitations[0].Method != storage.MethodRequest ||
+		!slices.Equal(invitations[0].Recipients, []string{"bob@example.com", "carol@example.com"}) {
+		t.Errorf("ListInvitations: unexpected invitations %+v", invitations)
+	}
+
+	if _, err := Finalize(ctx, st, "alice", id, &early.ID); !errors.Is(err, storage.ErrPollFinalized) {
+		t.Errorf("Finalize again: expected %v, got %v", storage.ErrPollFinalized, err)
+	}
+	if err := st.SetVotes(ctx, "bob", id, votes["bob"]); !errors.Is(err, storage.ErrPollFinalized) {
+		t.Errorf("SetVotes after finalize: expected %v, got %v", storage.ErrPollFinalized, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		poll storage.Poll
+	}{
+		{"empty option", storage.Poll{
+			Options:  []storage.PollOption{{StartTime: start, EndTime: start}},
+			Username: "alice",
+		}},
+		{"same options", storage.Poll{
+			Options: []storage.PollOption{
+				{StartTime: start, EndTime: start.Add(time.Hour)},
+				{StartTime: start.In(time.FixedZone("UTC+3", 3*60*60)), EndTime: start.Add(time.Hour)},
+			},
+			Username: "alice",
+		}},
+		{"organizer invited", storage.Poll{
+			Options:  []storage.PollOption{{StartTime: start, EndTime: start.Add(time.Hour)}},
+			Invitees: []string{"bob", "alice"},
+			Username: "alice",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Check(&test.poll); err == nil {
+				t.Error("Check: expected error")
+			}
+		})
+	}
+}
@@ -3,46 +3,182 @@ package queue
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Content types of the messages, the AMQP content-type property.
+// The messages of version 1 were gob-encoded without a content type.
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeGob  = "application/x-gob"
+)
+
+// Versions of the messages written by this package. A version is increased
+// when a field changes its meaning, added fields keep the version.
+const (
+	AlertEventVersion = 2
+	InvitationVersion = 2
+)
+
+var ErrContentType = errors.New("unsupported content type")
+
 type Conf struct {
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
 	User     string `yaml:"user"`
 	Password string `yaml:"password"`
 	Name     string `yaml:"name"`
+	// InvitationName is the queue of the invitations to the attendees.
+	InvitationName string `yaml:"invitation_name"`
 }
 
+// AlertEvent is a reminder of an event to its owner.
+//
+//nolint:tagliatelle
 type AlertEvent struct {
-	ID          uuid.UUID
-	Title       string
-	Description string
-	StartTime   time.Time
-	EndTime     time.Time
-	Username    string
+	Version int `json:"version"`
+	// ID is the ID of the reminder, the same if it is sent again. It is
+	// uuid.Nil in messages of version 1.
+	ID          uuid.UUID `json:"id"`
+	EventID     uuid.UUID `json:"event_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	// AllDay events take the dates of their UTC midnights, see storage.Event.
+	AllDay bool `json:"all_day,omitempty"`
+	// TimeZone is the IANA name of the time zone in which the times are shown.
+	TimeZone string `json:"time_zone,omitempty"`
+	Username string `json:"username"`
+	// Recipient is the address the reminder is sent to through the channel,
+	// the email or the webhook URL of the user. It is empty in messages of
+	// version 1.
+	Recipient string `json:"recipient,omitempty"`
+	// Channel is the way the reminder reaches the user: email or webhook,
+	// email if empty.
+	Channel string `json:"channel,omitempty"`
+	// NotifyBefore is the offset of the reminder before the start.
+	NotifyBefore time.Duration `json:"notify_before,omitempty"`
+}
+
+// alertEventV1 are the fields of the gob-encoded alert messages.
+type alertEventV1 struct {
+	ID           uuid.UUID
+	EventID      uuid.UUID
+	Title        string
+	Description  string
+	StartTime    time.Time
+	EndTime      time.Time
+	TimeZone     string
+	Username     string
+	Channel      string
+	NotifyBefore time.Duration
 }
 
+// EncodeAlertEvent returns the message of the current version, of
+// ContentTypeJSON.
 func EncodeAlertEvent(event *AlertEvent) ([]byte, error) {
-	buffer := new(bytes.Buffer)
-	encoder := gob.NewEncoder(buffer)
-	if err := encoder.Encode(event); err != nil {
-		return nil, err
+	msg := *event
+	msg.Version = AlertEventVersion
+	body, err := json.Marshal(&msg)
+	if err != nil {
+		return nil, fmt.Errorf("encode alert event: %w", err)
 	}
 
-	return buffer.Bytes(), nil
+	return body, nil
 }
 
-func DecodeAlertEvent(bodyMsg []byte) (*AlertEvent, error) {
-	var event AlertEvent
+// DecodeAlertEvent decodes the message of any version. Fields unknown to
+// this version are ignored.
+func DecodeAlertEvent(contentType string, bodyMsg []byte) (*AlertEvent, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		var event AlertEvent
+		if err := json.Unmarshal(bodyMsg, &event); err != nil {
+			return nil, fmt.Errorf("decode alert event: %w", err)
+		}
+		return &event, nil
+	case "", ContentTypeGob:
+		var old alertEventV1
+		if err := gob.NewDecoder(bytes.NewBuffer(bodyMsg)).Decode(&old); err != nil {
+			return nil, fmt.Errorf("decode alert event: %w", err)
+		}
+		event := AlertEvent{
+			Version:      1,
+			ID:           old.ID,
+			EventID:      old.EventID,
+			Title:        old.Title,
+			Description:  old.Description,
+			StartTime:    old.StartTime,
+			EndTime:      old.EndTime,
+			TimeZone:     old.TimeZone,
+			Username:     old.Username,
+			Recipient:    "",
+			Channel:      old.Channel,
+			NotifyBefore: old.NotifyBefore,
+		}
+		// The first messages had the ID of the event as their ID.
+		if event.EventID == uuid.Nil {
+			event.ID, event.EventID = uuid.Nil, old.ID
+		}
+		return &event, nil
+	default:
+		return nil, fmt.Errorf("decode alert event: %w: %q", ErrContentType, contentType)
+	}
+}
+
+// Invitation is an iTIP message to the attendees of an event to be sent by
+// email, iMIP.
+type Invitation struct {
+	Version int       `json:"version"`
+	ID      uuid.UUID `json:"id"`
+	// Method is the iTIP method: REQUEST or CANCEL.
+	Method     string   `json:"method"`
+	Subject    string   `json:"subject"`
+	Organizer  string   `json:"organizer"`
+	Recipients []string `json:"recipients"`
+	// Text is the plain text description of the event.
+	Text string `json:"text"`
+	// Calendar is the VCALENDAR object of the message.
+	Calendar string `json:"calendar"`
+}
+
+// EncodeInvitation returns the message of the current version, of
+// ContentTypeJSON.
+func EncodeInvitation(invitation *Invitation) ([]byte, error) {
+	msg := *invitation
+	msg.Version = InvitationVersion
+	body, err := json.Marshal(&msg)
+	if err != nil {
+		return nil, fmt.Errorf("encode invitation: %w", err)
+	}
+
+	return body, nil
+}
 
-	buffer := bytes.NewBuffer(bodyMsg)
-	dec := gob.NewDecoder(buffer)
-	if err := dec.Decode(&event); err != nil {
-		return nil, err
+// DecodeInvitation decodes the message of any version. Fields unknown to
+// this version are ignored.
+func DecodeInvitation(contentType string, bodyMsg []byte) (*Invitation, error) {
+	var invitation Invitation
+	switch contentType {
+	case ContentTypeJSON:
+		if err := json.Unmarshal(bodyMsg, &invitation); err != nil {
+			return nil, fmt.Errorf("decode invitation: %w", err)
+		}
+	case "", ContentTypeGob:
+		// Version 1 has the same fields but the version.
+		if err := gob.NewDecoder(bytes.NewBuffer(bodyMsg)).Decode(&invitation); err != nil {
+			return nil, fmt.Errorf("decode invitation: %w", err)
+		}
+		invitation.Version = 1
+	default:
+		return nil, fmt.Errorf("decode invitation: %w: %q", ErrContentType, contentType)
 	}
 
-	return &event, nil
+	return &invitation, nil
 }
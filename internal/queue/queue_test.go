@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAlertEvent(t *testing.T) {
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := AlertEvent{
+		ID:           uuid.New(),
+		EventID:      uuid.New(),
+		Title:        "Stand-up",
+		StartTime:    start,
+		EndTime:      start.Add(15 * time.Minute),
+		TimeZone:     "Europe/Berlin",
+		Username:     "bob",
+		Recipient:    "bob@example.com",
+		Channel:      "email",
+		NotifyBefore: 10 * time.Minute,
+	}
+	body, err := EncodeAlertEvent(&event)
+	if err != nil {
+		t.Fatalf("EncodeAlertEvent: %v", err)
+	}
+	decoded, err := DecodeAlertEvent(ContentTypeJSON, body)
+	if err != nil {
+		t.Fatalf("DecodeAlertEvent: %v", err)
+	}
+	event.Version = AlertEventVersion
+	if *decoded != event {
+		t.Errorf("expected %+v, got %+v", event, *decoded)
+	}
+
+	newer := []byte(`{"version":3,"id":"` + event.ID.String() + `","title":"Stand-up","priority":"high"}`)
+	decoded, err = DecodeAlertEvent(ContentTypeJSON, newer)
+	if err != nil {
+		t.Fatalf("DecodeAlertEvent of a newer version: %v", err)
+	}
+	if decoded.Version != 3 || decoded.ID != event.ID || decoded.Title != "Stand-up" {
+		t.Errorf("unexpected newer alert event %+v", *decoded)
+	}
+
+	if _, err := DecodeAlertEvent("text/plain", body); !errors.Is(err, ErrContentType) {
+		t.Errorf("expected ErrContentType, got %v", err)
+	}
+}
+
+func TestDecodeAlertEvent_V1(t *testing.T) {
+	// The alert events of version 1 were gob-encoded with the ID of the event.
+	type alertEvent struct {
+		ID          uuid.UUID
+		Title       string
+		Description string
+		StartTime   time.Time
+		EndTime     time.Time
+		TimeZone    string
+		Username    string
+	}
+	old := alertEvent{
+		ID:        uuid.New(),
+		Title:     "Stand-up",
+		StartTime: time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, time.January, 6, 9, 15, 0, 0, time.UTC),
+		TimeZone:  "Europe/Berlin",
+		Username:  "bob",
+	}
+	buffer := new(bytes.Buffer)
+	if err := gob.NewEncoder(buffer).Encode(&old); err != nil {
+		t.Fatalf("gob: %v", err)
+	}
+
+	event, err := DecodeAlertEvent("", buffer.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeAlertEvent: %v", err)
+	}
+	if event.Version != 1 || event.ID != uuid.Nil || event.EventID != old.ID {
+		t.Errorf("expected version 1 of event %s, got %+v", old.ID, *event)
+	}
+	if event.Title != old.Title || !event.StartTime.Equal(old.StartTime) || event.TimeZone != old.TimeZone {
+		t.Errorf("fields not decoded: %+v", *event)
+	}
+}
+
+func TestInvitation(t *testing.T) {
+	invitation := Invitation{
+		ID:         uuid.New(),
+		Method:     "REQUEST",
+		Subject:    "Invitation: Planning",
+		Organizer:  "alice@example.com",
+		Recipients: []string{"bob@example.com"},
+		Text:       "Planning",
+		Calendar:   "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	}
+	body, err := EncodeInvitation(&invitation)
+	if err != nil {
+		t.Fatalf("EncodeInvitation: %v", err)
+	}
+	decoded, err := DecodeInvitation(ContentTypeJSON, body)
+	if err != nil {
+		t.Fatalf("DecodeInvitation: %v", err)
+	}
+	if decoded.Version != InvitationVersion || decoded.ID != invitation.ID || decoded.Calendar != invitation.Calendar {
+		t.Errorf("unexpected invitation %+v", *decoded)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := gob.NewEncoder(buffer).Encode(&invitation); err != nil {
+		t.Fatalf("gob: %v", err)
+	}
+	decoded, err = DecodeInvitation("", buffer.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeInvitation of version 1: %v", err)
+	}
+	if decoded.Version != 1 || decoded.ID != invitation.ID || decoded.Recipients[0] != "bob@example.com" {
+		t.Errorf("unexpected invitation of version 1 %+v", *decoded)
+	}
+}
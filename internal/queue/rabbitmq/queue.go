@@ -44,7 +44,8 @@ func (q *Queue) ConnectAndCreate(url, name string) error {
 	return nil
 }
 
-func (q *Queue) SendMsg(ctx context.Context, body []byte) error {
+// SendMsg publishes the body with the content type, one of the queue.ContentType.
+func (q *Queue) SendMsg(ctx context.Context, contentType string, body []byte) error {
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
 	err := q.ch.PublishWithContext(
@@ -54,7 +55,8 @@ func (q *Queue) SendMsg(ctx context.Context, body []byte) error {
 		false,        // mandatory
 		false,        // immediate
 		amqp.Publishing{
-			Body: body,
+			ContentType: contentType,
+			Body:        body,
 		})
 	if err != nil {
 		return err
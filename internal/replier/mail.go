@@ -0,0 +1,109 @@
+package replier
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"github.com/mrvin/calendar/internal/icalendar"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/pkg/ical"
+)
+
+var (
+	ErrNoCalendar     = errors.New("no text/calendar part")
+	ErrNoResponse     = errors.New("no response in the reply")
+	ErrSenderMismatch = errors.New("reply not from the attendee")
+)
+
+// ParseMessage returns the responses in the iTIP REPLY attached to the email
+// message, iMIP. Only the responses of the sender of the message are
+// returned, as RFC 6047 asks to check.
+func ParseMessage(r io.Reader) ([]storage.Reply, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	body, err := findCalendar(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := ical.Decode(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode calendar: %w", err)
+	}
+	replies, err := icalendar.Replies(cal)
+	if err != nil {
+		return nil, fmt.Errorf("replies: %w", err)
+	}
+	if len(replies) == 0 {
+		return nil, ErrNoResponse
+	}
+
+	fromSender := replies[:0]
+	for _, reply := range replies {
+		if strings.EqualFold(reply.Email, from.Address) {
+			fromSender = append(fromSender, reply)
+		}
+	}
+	if len(fromSender) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrSenderMismatch, from.Address)
+	}
+
+	return fromSender, nil
+}
+
+// findCalendar returns the decoded content of the first text/calendar part
+// of the entity, looking into multipart ones.
+func findCalendar(contentType, encoding string, body io.Reader) (io.Reader, error) {
+	if contentType == "" {
+		return nil, ErrNoCalendar
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("content type: %w", err)
+	}
+
+	switch {
+	case mediaType == "text/calendar" || mediaType == "application/ics":
+		return decodeBody(encoding, body), nil
+	case strings.HasPrefix(mediaType, "multipart/"):
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil, ErrNoCalendar
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read part: %w", err)
+			}
+			cal, err := findCalendar(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if !errors.Is(err, ErrNoCalendar) {
+				return cal, err
+			}
+		}
+	default:
+		return nil, ErrNoCalendar
+	}
+}
+
+func decodeBody(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
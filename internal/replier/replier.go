@@ -0,0 +1,123 @@
+// Package replier applies the responses of the attendees to the invitations,
+// iTIP REPLY messages received by email, to the stored events. The messages
+// are read from a Maildir directory filled in by the mail server.
+package replier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type ReplyApplier interface {
+	ApplyReply(ctx context.Context, reply *storage.Reply) error
+}
+
+type Replier struct {
+	st      ReplyApplier
+	maildir string
+}
+
+func New(st ReplyApplier, maildir string) *Replier {
+	return &Replier{
+		st:      st,
+		maildir: maildir,
+	}
+}
+
+// Run processes the Maildir every period until a termination signal.
+func (r *Replier) Run(ctx context.Context, period time.Duration) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if _, err := r.ProcessMaildir(ctx); err != nil {
+			slog.Error("Process maildir", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			slog.Info("Stop replier")
+			return
+		}
+	}
+}
+
+// ProcessMaildir applies the replies in the new messages of the Maildir and
+// moves the messages to cur, as seen. Messages which are not replies or reply
+// to unknown events are moved too, with a warning; a message failed with
+// a storage error stays in new to be retried. It returns the number of the
+// processed messages.
+func (r *Replier) ProcessMaildir(ctx context.Context) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(r.maildir, "new"))
+	if err != nil {
+		return 0, fmt.Errorf("read maildir: %w", err)
+	}
+
+	var errs []error
+	processed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := filepath.Join(r.maildir, "new", entry.Name())
+		if err := r.processMessage(ctx, name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		// Messages in cur carry the info suffix, S is for seen.
+		if err := os.Rename(name, filepath.Join(r.maildir, "cur", entry.Name()+":2,S")); err != nil {
+			errs = append(errs, fmt.Errorf("move to cur: %w", err))
+			continue
+		}
+		processed++
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// processMessage applies the replies in the message. It returns an error
+// only if the message has to be processed again.
+func (r *Replier) processMessage(ctx context.Context, name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("open message: %w", err)
+	}
+	defer file.Close()
+
+	replies, err := ParseMessage(file)
+	if err != nil {
+		slog.Warn("Skip message", slog.String("message", filepath.Base(name)), slog.String("error", err.Error()))
+		return nil
+	}
+	for _, reply := range replies {
+		err := r.st.ApplyReply(ctx, &reply)
+		switch {
+		case err == nil:
+			slog.Info("Apply reply",
+				slog.String("uid", reply.UID),
+				slog.String("attendee", reply.Email),
+				slog.String("status", string(reply.Status)),
+			)
+		case errors.Is(err, storage.ErrEventNotFound) ||
+			errors.Is(err, storage.ErrAttendeeNotFound) ||
+			errors.Is(err, storage.ErrOutdatedReply):
+			slog.Warn("Skip reply", slog.String("uid", reply.UID), slog.String("error", err.Error()))
+		default:
+			return fmt.Errorf("apply reply: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package replier
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+// newMaildir returns a Maildir with the fixture messages in new.
+func newMaildir(t *testing.T, fixtures ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "new", fixture), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	return names
+}
+
+func TestProcessMaildir(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := storage.Event{
+		Title:     "Planning",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		UID:       "planning@example.com",
+		Username:  "alice",
+		Attendees: []storage.Attendee{{Email: "bob@example.com"}, {Email: "carol@example.com"}},
+	}
+	id, err := st.CreateEvent(ctx, &event)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	fixtures := []string{"accepted.eml", "declined.eml", "plain.eml", "spoofed.eml", "unknown-event.eml"}
+	dir := newMaildir(t, fixtures...)
+	processed, err := New(st, dir).ProcessMaildir(ctx)
+	if err != nil {
+		t.Fatalf("ProcessMaildir: %v", err)
+	}
+	if processed != len(fixtures) {
+		t.Errorf("processed %d messages, want %d", processed, len(fixtures))
+	}
+
+	got, err := st.GetEvent(ctx, "alice", id)
+	if err != nil {
+		t.Fatalf("GetEvent: %v", err)
+	}
+	want := []storage.Attendee{
+		{Email: "bob@example.com", Status: storage.PartStatAccepted},
+		{Email: "carol@example.com", Status: storage.PartStatDeclined},
+	}
+	if !slices.Equal(got.Attendees, want) {
+		t.Errorf("attendees: have %v, want %v", got.Attendees, want)
+	}
+
+	if names := listDir(t, filepath.Join(dir, "new")); len(names) != 0 {
+		t.Errorf("messages left in new: %v", names)
+	}
+	if names := listDir(t, filepath.Join(dir, "cur")); !slices.Contains(names, "accepted.eml:2,S") || len(names) != len(fixtures) {
+		t.Errorf("messages in cur: %v", names)
+	}
+}
+
+type failingApplier struct{}
+
+func (failingApplier) ApplyReply(context.Context, *storage.Reply) error {
+	return errors.New("connection refused")
+}
+
+func TestProcessMaildir_Retry(t *testing.T) {
+	dir := newMaildir(t, "accepted.eml", "plain.eml")
+	processed, err := New(failingApplier{}, dir).ProcessMaildir(context.Background())
+	if err == nil {
+		t.Error("expected the storage error")
+	}
+	if processed != 1 {
+		t.Errorf("processed %d messages, want 1", processed)
+	}
+	if names := listDir(t, filepath.Join(dir, "new")); !slices.Equal(names, []string{"accepted.eml"}) {
+		t.Errorf("messages left in new: %v", names)
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []storage.Reply
+		err     error
+	}{
+		{"accepted.eml", []storage.Reply{{UID: "planning@example.com", Email: "Bob@Example.com", Status: storage.PartStatAccepted}}, nil},
+		{"declined.eml", []storage.Reply{{UID: "planning@example.com", Email: "carol@example.com", Status: storage.PartStatDeclined}}, nil},
+		{"spoofed.eml", nil, ErrSenderMismatch},
+		{"plain.eml", nil, ErrNoCalendar},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			replies, err := ParseMessage(file)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error: have %v, want %v", err, test.err)
+			}
+			if !slices.EqualFunc(replies, test.want, func(a, b storage.Reply) bool {
+				return a.UID == b.UID && a.Email == b.Email && a.Status == b.Status && a.Sequence == b.Sequence
+			}) {
+				t.Errorf("replies: have %+v, want %+v", replies, test.want)
+			}
+		})
+	}
+}
@@ -5,29 +5,87 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/icalendar"
 	"github.com/mrvin/calendar/internal/queue"
-	"github.com/mrvin/calendar/internal/queue/rabbitmq"
 	"github.com/mrvin/calendar/internal/storage"
+	"go.opentelemetry.io/otel/metric"
 )
 
-type EventsLister interface {
-	ListEventsToNotify(ctx context.Context, start, end time.Time) ([]storage.Event, error)
+type NotificationsLister interface {
+	ListEventsToNotify(ctx context.Context, start, end time.Time) ([]storage.Notification, error)
+	RecordNotification(ctx context.Context, notification *storage.Notification) error
+	MarkNotificationSent(ctx context.Context, id uuid.UUID) error
+	NotifiedUntil(ctx context.Context) (*time.Time, error)
+	DeleteSentNotifications(ctx context.Context, before time.Time) (int, error)
 }
 
+// Queue takes the messages to the sender.
+type Queue interface {
+	SendMsg(ctx context.Context, contentType string, body []byte) error
+}
+
+type InvitationsLister interface {
+	ListInvitations(ctx context.Context, limit int) ([]storage.Invitation, error)
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
+}
+
+// maxCatchUp bounds how late the reminders missed while the scheduler was
+// down are sent.
+const maxCatchUp = 24 * time.Hour
+
+// invitationPeriod is the period of sending the invitations to the attendees.
+const invitationPeriod = time.Minute
+
+// invitationBatch is the number of invitations read from the storage at once.
+const invitationBatch = 100
+
 type App struct {
-	lister      EventsLister
-	qm          rabbitmq.Queue
+	lister      NotificationsLister
+	qm          Queue
+	invitations InvitationsLister
+	qmInvite    Queue
 	schedPeriod int
+	elector     Elector
+	// replica names the instance of the scheduler in the logs and metrics.
+	replica string
+	leading atomic.Bool
+	cleaner EventsCleaner
+	// retentionDays are the days the events are kept after they end unless
+	// their user sets otherwise, forever if 0.
+	retentionDays int
+	deletedEvents metric.Int64Counter
+	// notifiedFrom is the start of the next window of reminders, zero until
+	// it is read from the storage.
+	notifiedFrom time.Time
 }
 
-func New(lister EventsLister, qm rabbitmq.Queue, schedPeriod int) *App {
+func New(
+	lister NotificationsLister,
+	qm Queue,
+	invitations InvitationsLister,
+	qmInvite Queue,
+	schedPeriod int,
+	elector Elector,
+	replica string,
+	cleaner EventsCleaner,
+	retentionDays int,
+) *App {
+	//nolint:exhaustruct
 	return &App{
-		lister:      lister,
-		qm:          qm,
-		schedPeriod: schedPeriod,
+		lister:        lister,
+		qm:            qm,
+		invitations:   invitations,
+		qmInvite:      qmInvite,
+		schedPeriod:   schedPeriod,
+		elector:       elector,
+		replica:       replica,
+		cleaner:       cleaner,
+		retentionDays: retentionDays,
 	}
 }
 
@@ -39,43 +97,247 @@ func (a *App) Run(ctx context.Context) {
 		syscall.SIGQUIT,
 	)
 
-	schedPeriod := time.Duration(a.schedPeriod) * time.Minute
-	ticker := time.NewTicker(schedPeriod)
+	if err := a.registerLeaderMetric(); err != nil {
+		slog.Warn("Failed to register leader metric: " + err.Error())
+	}
+	if err := a.registerRetentionMetric(); err != nil {
+		slog.Warn("Failed to register retention metric: " + err.Error())
+	}
+
+	ticker := time.NewTicker(a.period())
+	inviteTicker := time.NewTicker(invitationPeriod)
+	leaderTicker := time.NewTicker(leaderPeriod)
+	cleanupTicker := time.NewTicker(cleanupPeriod)
+	a.handle(ctx, jobElection, time.Now())
 	for {
 		select {
+		case <-leaderTicker.C:
+			a.handle(ctx, jobElection, time.Now())
+		case <-inviteTicker.C:
+			a.handle(ctx, jobInvitations, time.Now())
 		case <-ticker.C:
-			start := time.Now()
-			events, err := a.lister.ListEventsToNotify(ctx, start, start.Add(schedPeriod))
-			if err != nil {
-				slog.Error("List events to notify", slog.String("error", err.Error()))
-				continue
-			}
-			for _, event := range events {
-				//TODO: generate new UUID
-				alertEvent := queue.AlertEvent{
-					ID:          event.ID,
-					Title:       event.Title,
-					Description: event.Description,
-					StartTime:   event.StartTime,
-					EndTime:     event.EndTime,
-					Username:    event.Username,
-				}
-				byteAlertEvent, err := queue.EncodeAlertEvent(&alertEvent)
-				if err != nil {
-					slog.Error("Encode alert event", slog.String("error", err.Error()))
-					continue
-				}
-
-				if err := a.qm.SendMsg(ctx, byteAlertEvent); err != nil {
-					slog.Error("Send alert message", slog.String("error", err.Error()))
-					continue
-				}
-				slog.Info("Put alert message in queue", slog.String("eventID", event.ID.String()))
-			}
+			a.handle(ctx, jobNotifications, time.Now())
+		case <-cleanupTicker.C:
+			a.handle(ctx, jobCleanup, time.Now())
 		case <-ctx.Done():
 			ticker.Stop()
+			inviteTicker.Stop()
+			leaderTicker.Stop()
+			cleanupTicker.Stop()
+			if err := a.elector.Resign(context.WithoutCancel(ctx)); err != nil {
+				slog.Error("Resign leadership", slog.String("error", err.Error()))
+			}
 			slog.Info("Stop scheduler")
 			return
 		}
 	}
 }
+
+// job is the work due on a tick of the scheduler.
+type job int
+
+const (
+	jobElection job = iota
+	jobInvitations
+	jobNotifications
+	jobCleanup
+)
+
+// handle elects the leader and, if the replica leads, does the job at now.
+func (a *App) handle(ctx context.Context, job job, now time.Time) {
+	// Only the leader dispatches. As it takes the lead, it catches up from
+	// where the previous leader stopped.
+	if a.elect(ctx) {
+		a.notifiedFrom = time.Time{}
+		a.sendNotifications(ctx, now, a.period())
+		a.sendInvitations(ctx)
+	}
+	if !a.leading.Load() {
+		return
+	}
+	switch job {
+	case jobElection:
+	case jobInvitations:
+		a.sendInvitations(ctx)
+	case jobNotifications:
+		a.sendNotifications(ctx, now, a.period())
+	case jobCleanup:
+		a.deleteExpiredEvents(ctx, now)
+		a.deleteSentNotifications(ctx, now)
+	}
+}
+
+// period is the period of sending the reminders.
+func (a *App) period() time.Duration {
+	return time.Duration(a.schedPeriod) * time.Minute
+}
+
+// sendNotifications puts the reminders due since the previous pass, up to
+// schedPeriod ahead of now, in the queue. Each reminder of an occurrence is
+// recorded before it is sent and marked sent after, so that it is sent once
+// under the same message ID, and a failed pass is repeated on the next tick.
+func (a *App) sendNotifications(ctx context.Context, now time.Time, schedPeriod time.Duration) {
+	if a.notifiedFrom.IsZero() {
+		until, err := a.lister.NotifiedUntil(ctx)
+		if err != nil {
+			slog.Error("Get notified until", slog.String("error", err.Error()))
+			return
+		}
+		a.notifiedFrom = now
+		if until != nil && until.Before(now) {
+			a.notifiedFrom = *until
+		}
+	}
+	start := a.notifiedFrom
+	if oldest := now.Add(-maxCatchUp); start.Before(oldest) {
+		start = oldest
+	}
+
+	notifications, err := a.lister.ListEventsToNotify(ctx, start, now.Add(schedPeriod))
+	if err != nil {
+		slog.Error("List events to notify", slog.String("error", err.Error()))
+		return
+	}
+	// Each reminder of an event is a separate message.
+	for i := range notifications {
+		notification := &notifications[i]
+		notification.CreatedAt = now
+		if err := a.lister.RecordNotification(ctx, notification); err != nil {
+			slog.Error("Record notification", slog.String("error", err.Error()))
+			return
+		}
+		if notification.Status == storage.NotificationSent {
+			continue
+		}
+		event := &notification.Event
+		alertEvent := queue.AlertEvent{
+			Version:      queue.AlertEventVersion,
+			ID:           notification.ID,
+			EventID:      event.ID,
+			Title:        event.Title,
+			Description:  event.Description,
+			StartTime:    event.StartTime,
+			EndTime:      event.EndTime,
+			AllDay:       event.AllDay,
+			TimeZone:     event.TimeZone,
+			Username:     event.Username,
+			Recipient:    notification.Recipient(),
+			Channel:      string(notification.Reminder.Channel),
+			NotifyBefore: notification.Reminder.Offset,
+		}
+		byteAlertEvent, err := queue.EncodeAlertEvent(&alertEvent)
+		if err != nil {
+			slog.Error("Encode alert event", slog.String("error", err.Error()))
+			return
+		}
+		if err := a.qm.SendMsg(ctx, queue.ContentTypeJSON, byteAlertEvent); err != nil {
+			slog.Error("Send alert message", slog.String("error", err.Error()))
+			return
+		}
+		if err := a.lister.MarkNotificationSent(ctx, notification.ID); err != nil {
+			slog.Error("Mark notification sent", slog.String("error", err.Error()))
+			return
+		}
+		slog.Info("Put alert message in queue",
+			slog.String("eventID", event.ID.String()),
+			slog.String("channel", alertEvent.Channel),
+		)
+	}
+	// The windows overlap by a period: events created since the previous
+	// pass may have reminders due in its window.
+	a.notifiedFrom = now
+}
+
+// deleteSentNotifications deletes the records of the sent reminders too old
+// to be caught up.
+func (a *App) deleteSentNotifications(ctx context.Context, now time.Time) {
+	n, err := a.lister.DeleteSentNotifications(ctx, now.Add(-maxCatchUp))
+	if err != nil {
+		slog.Error("Delete sent notifications", slog.String("error", err.Error()))
+		return
+	}
+	if n > 0 {
+		slog.Info("Deleted sent notifications", slog.Int("count", n))
+	}
+}
+
+// sendInvitations puts the stored invitations in the queue as iTIP messages
+// and deletes them from the storage.
+func (a *App) sendInvitations(ctx context.Context) {
+	for {
+		invitations, err := a.invitations.ListInvitations(ctx, invitationBatch)
+		if err != nil {
+			slog.Error("List invitations", slog.String("error", err.Error()))
+			return
+		}
+		for _, invitation := range invitations {
+			msg := queue.Invitation{
+				Version:    queue.InvitationVersion,
+				ID:         invitation.ID,
+				Method:     string(invitation.Method),
+				Subject:    invitationSubject(&invitation),
+				Organizer:  invitation.Organizer,
+				Recipients: invitation.Recipients,
+				Text:       invitationText(&invitation.Event),
+				Calendar:   icalendar.ITIP(&invitation, time.Now()).String(),
+			}
+			byteInvitation, err := queue.EncodeInvitation(&msg)
+			if err != nil {
+				slog.Error("Encode invitation", slog.String("error", err.Error()))
+				return
+			}
+			if err := a.qmInvite.SendMsg(ctx, queue.ContentTypeJSON, byteInvitation); err != nil {
+				slog.Error("Send invitation message", slog.String("error", err.Error()))
+				return
+			}
+			if err := a.invitations.DeleteInvitation(ctx, invitation.ID); err != nil {
+				slog.Error("Delete invitation", slog.String("error", err.Error()))
+				return
+			}
+			slog.Info("Put invitation message in queue",
+				slog.String("eventID", invitation.Event.ID.String()),
+				slog.String("method", msg.Method),
+			)
+		}
+		if len(invitations) < invitationBatch {
+			return
+		}
+	}
+}
+
+func invitationSubject(invitation *storage.Invitation) string {
+	if invitation.Method == storage.MethodCancel {
+		return "Cancelled: " + invitation.Event.Title
+	}
+	if invitation.Event.Sequence > 0 {
+		return "Updated invitation: " + invitation.Event.Title
+	}
+
+	return "Invitation: " + invitation.Event.Title
+}
+
+const timeLayout = "Mon, 02 Jan 2006 15:04 MST"
+
+// invitationText describes the event for email clients without calendar
+// support, in its time zone.
+func invitationText(event *storage.Event) string {
+	loc, err := storage.LoadLocation(event.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	text := event.Title + "\n"
+	if event.AllDay {
+		text += event.StartTime.UTC().Format(time.DateOnly) + " - " + event.EndTime.UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	} else {
+		text += event.StartTime.In(loc).Format(timeLayout) + " - " + event.EndTime.In(loc).Format(timeLayout)
+	}
+	if event.RRule != "" {
+		text += "\nRepeats: " + event.RRule
+	}
+	text += "\nOrganizer: " + event.Organizer + "\n"
+	if event.Description != "" {
+		text += "\n" + event.Description + "\n"
+	}
+
+	return text
+}
@@ -0,0 +1,256 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/queue"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+var errQueueDown = errors.New("queue is down")
+
+// fakeQueue keeps the sent messages, failing the sends while fail is set.
+type fakeQueue struct {
+	t      *testing.T
+	fail   bool
+	sent   []*queue.AlertEvent
+	failed []*queue.AlertEvent
+}
+
+func (q *fakeQueue) SendMsg(_ context.Context, contentType string, body []byte) error {
+	q.t.Helper()
+
+	msg, err := queue.DecodeAlertEvent(contentType, body)
+	if err != nil {
+		q.t.Fatalf("DecodeAlertEvent: %v", err)
+	}
+	if q.fail {
+		q.failed = append(q.failed, msg)
+		return errQueueDown
+	}
+	q.sent = append(q.sent, msg)
+
+	return nil
+}
+
+func (q *fakeQueue) titles() []string {
+	titles := make([]string, len(q.sent))
+	for i, msg := range q.sent {
+		titles[i] = msg.Title
+	}
+
+	return titles
+}
+
+// sentReminder is a reminder sent at the offset from the base time.
+type sentReminder struct {
+	title  string
+	offset time.Duration
+	at     time.Duration
+}
+
+// tick is a pass of the scheduler at the offset from the base time, after a
+// restart if restart is set.
+type tick struct {
+	at        time.Duration
+	restart   bool
+	queueDown bool
+}
+
+func TestSendNotifications(t *testing.T) {
+	base := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	const schedPeriod = time.Minute
+
+	tests := []struct {
+		name string
+		// events start at the offsets from the base time, with a reminder
+		// 10 minutes before by email unless reminders are given.
+		events    map[string]time.Duration
+		reminders []storage.Reminder
+		// allDay are the titles of the all-day events, of the date of their
+		// start.
+		allDay []string
+		ticks  []tick
+		want   []string
+		// wantReminders are checked if given.
+		wantReminders []sentReminder
+	}{
+		{
+			name:   "each reminder once across ticks",
+			events: map[string]time.Duration{"Stand-up": 30 * time.Minute, "Review": 50 * time.Minute},
+			ticks:  minuteTicks(0, time.Hour),
+			want:   []string{"Stand-up", "Review"},
+		},
+		{
+			name:   "catch up after downtime within a day",
+			events: map[string]time.Duration{"Stand-up": 11 * time.Minute, "Review": 3 * time.Hour},
+			ticks:  []tick{{at: time.Minute}, {at: 23 * time.Hour, restart: true}, {at: 23*time.Hour + time.Minute}},
+			want:   []string{"Stand-up", "Review"},
+		},
+		{
+			name:   "skip reminders older than a day",
+			events: map[string]time.Duration{"Stand-up": 11 * time.Minute, "Review": 3 * time.Hour},
+			ticks:  []tick{{at: time.Minute}, {at: 30 * time.Hour, restart: true}},
+			want:   []string{"Stand-up"},
+		},
+		{
+			name:   "retry after the queue fails",
+			events: map[string]time.Duration{"Stand-up": 11 * time.Minute},
+			ticks:  []tick{{at: time.Minute, queueDown: true}, {at: 2 * time.Minute}, {at: 3 * time.Minute}},
+			want:   []string{"Stand-up"},
+		},
+		{
+			name:   "retry after the queue fails and a restart",
+			events: map[string]time.Duration{"Stand-up": 11 * time.Minute},
+			ticks:  []tick{{at: time.Minute, queueDown: true}, {at: 2 * time.Hour, restart: true}},
+			want:   []string{"Stand-up"},
+		},
+		{
+			name:   "each reminder of an event separately",
+			events: map[string]time.Duration{"Stand-up": 30 * time.Minute, "Holiday": 15 * time.Hour},
+			reminders: []storage.Reminder{
+				{Offset: 10*time.Minute + 30*time.Second, Channel: storage.ChannelEmail},
+				{Offset: 25*time.Minute + 30*time.Second, Channel: storage.ChannelWebhook},
+			},
+			allDay: []string{"Holiday"},
+			ticks:  minuteTicks(0, 15*time.Hour),
+			want:   []string{"Stand-up", "Stand-up", "Holiday", "Holiday"},
+			// Sent by the tick before they are due. The all-day event starts
+			// at midnight on January 7.
+			wantReminders: []sentReminder{
+				{"Stand-up", 25*time.Minute + 30*time.Second, 4 * time.Minute},
+				{"Stand-up", 10*time.Minute + 30*time.Second, 19 * time.Minute},
+				{"Holiday", 25*time.Minute + 30*time.Second, 14*time.Hour + 34*time.Minute},
+				{"Holiday", 10*time.Minute + 30*time.Second, 14*time.Hour + 49*time.Minute},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			st := memory.New()
+			ctx := context.Background()
+			user := storage.User{Name: "bob", Email: "bob@example.com", WebhookURL: "https://example.com/hooks/bob"}
+			if err := st.CreateUser(ctx, &user); err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+			reminders := test.reminders
+			if reminders == nil {
+				reminders = []storage.Reminder{{Offset: 10 * time.Minute, Channel: storage.ChannelEmail}}
+			}
+			for title, start := range test.events {
+				event := storage.Event{
+					Title:     title,
+					StartTime: base.Add(start),
+					EndTime:   base.Add(start + 15*time.Minute),
+					Reminders: reminders,
+					Username:  "bob",
+				}
+				if slices.Contains(test.allDay, title) {
+					event.AllDay = true
+					event.EndTime = event.StartTime.AddDate(0, 0, 1)
+				}
+				if _, err := st.CreateEvent(ctx, &event); err != nil {
+					t.Fatalf("CreateEvent: %v", err)
+				}
+			}
+
+			q := &fakeQueue{t: t}
+			app := New(st, q, st, q, int(schedPeriod/time.Minute), nil, "test", st, 0)
+			var sent []sentReminder
+			for _, tick := range test.ticks {
+				if tick.restart {
+					app = New(st, q, st, q, int(schedPeriod/time.Minute), nil, "test", st, 0)
+				}
+				q.fail = tick.queueDown
+				n := len(q.sent)
+				app.sendNotifications(ctx, base.Add(tick.at), schedPeriod)
+				for _, msg := range q.sent[n:] {
+					sent = append(sent, sentReminder{title: msg.Title, offset: msg.NotifyBefore, at: tick.at})
+				}
+			}
+
+			if !slices.Equal(q.titles(), test.want) {
+				t.Errorf("sent %v, want %v", q.titles(), test.want)
+			}
+			if test.wantReminders != nil && !slices.Equal(sent, test.wantReminders) {
+				t.Errorf("sent reminders %v, want %v", sent, test.wantReminders)
+			}
+			for _, msg := range q.sent {
+				recipient := user.Email
+				if msg.Channel == string(storage.ChannelWebhook) {
+					recipient = user.WebhookURL
+				}
+				if msg.Recipient != recipient {
+					t.Errorf("%s: recipient %q by %s", msg.Title, msg.Recipient, msg.Channel)
+				}
+				if msg.AllDay != slices.Contains(test.allDay, msg.Title) {
+					t.Errorf("%s: all day %t", msg.Title, msg.AllDay)
+				}
+			}
+			// A retried reminder keeps its message ID.
+			for _, failed := range q.failed {
+				if !slices.ContainsFunc(q.sent, func(msg *queue.AlertEvent) bool { return msg.ID == failed.ID }) {
+					t.Errorf("%s: retried under another ID than %s", failed.Title, failed.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteSentNotifications(t *testing.T) {
+	base := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	st := memory.New()
+	ctx := context.Background()
+
+	record := func(offset time.Duration, sent bool) {
+		t.Helper()
+		notification := storage.Notification{
+			Event:     storage.Event{ID: uuid.New(), StartTime: base.Add(offset + 10*time.Minute)},
+			Reminder:  storage.Reminder{Offset: 10 * time.Minute, Channel: storage.ChannelEmail},
+			Time:      base.Add(offset),
+			CreatedAt: base.Add(offset),
+		}
+		if err := st.RecordNotification(ctx, &notification); err != nil {
+			t.Fatalf("RecordNotification: %v", err)
+		}
+		if sent {
+			if err := st.MarkNotificationSent(ctx, notification.ID); err != nil {
+				t.Fatalf("MarkNotificationSent: %v", err)
+			}
+		}
+	}
+	record(0, true)
+	record(time.Hour, false)
+	record(2*time.Hour, true)
+
+	app := New(st, nil, st, nil, 1, nil, "test", st, 0)
+	// Only the first one is sent, out of the catch-up window and not the latest.
+	app.deleteSentNotifications(ctx, base.Add(maxCatchUp+3*time.Hour))
+
+	until, err := st.NotifiedUntil(ctx)
+	if err != nil {
+		t.Fatalf("NotifiedUntil: %v", err)
+	}
+	if until == nil || !until.Equal(base.Add(time.Hour)) {
+		t.Errorf("NotifiedUntil: have %v, want the pending %v", until, base.Add(time.Hour))
+	}
+	if n, _ := st.DeleteSentNotifications(ctx, base.Add(maxCatchUp+3*time.Hour)); n != 0 {
+		t.Errorf("DeleteSentNotifications: deleted %d more, the latest must be kept", n)
+	}
+}
+
+// minuteTicks returns the ticks every minute within [from, to).
+func minuteTicks(from, to time.Duration) []tick {
+	var ticks []tick
+	for at := from; at < to; at += time.Minute {
+		ticks = append(ticks, tick{at: at})
+	}
+
+	return ticks
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Elector elects the one of the replicas of the scheduler that dispatches.
+type Elector interface {
+	// Lead reports whether the replica leads, taking the leadership if no
+	// other replica holds it.
+	Lead(ctx context.Context) (bool, error)
+	Resign(ctx context.Context) error
+}
+
+// leaderPeriod is the period of the attempts of the followers to take over,
+// the longest failover once the leader has died.
+const leaderPeriod = 5 * time.Second
+
+// elect updates whether the replica leads and reports whether it has just
+// become the leader.
+func (a *App) elect(ctx context.Context) bool {
+	leading, err := a.elector.Lead(ctx)
+	if err != nil {
+		slog.Error("Leader election", slog.String("error", err.Error()))
+	}
+	if a.leading.Swap(leading) == leading {
+		return false
+	}
+	if !leading {
+		slog.Warn("Lost leadership", slog.String("replica", a.replica))
+		return false
+	}
+	slog.Info("Became leader", slog.String("replica", a.replica))
+
+	return true
+}
+
+// registerLeaderMetric reports 1 for the leader and 0 for the followers.
+func (a *App) registerLeaderMetric() error {
+	meter := otel.Meter("scheduler")
+	_, err := meter.Int64ObservableGauge(
+		"scheduler.leader",
+		metric.WithDescription("Whether the replica dispatches the reminders and invitations"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			var value int64
+			if a.leading.Load() {
+				value = 1
+			}
+			o.Observe(value, metric.WithAttributes(attribute.String("replica", a.replica)))
+			return nil
+		}),
+	)
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+// fakeLock is the lock the replicas share, held by owner.
+type fakeLock struct {
+	owner string
+}
+
+// fakeElector takes the shared lock for the replica.
+type fakeElector struct {
+	lock     *fakeLock
+	replica  string
+	resigned bool
+}
+
+func (e *fakeElector) Lead(_ context.Context) (bool, error) {
+	if e.lock.owner == "" {
+		e.lock.owner = e.replica
+	}
+
+	return e.lock.owner == e.replica, nil
+}
+
+func (e *fakeElector) Resign(_ context.Context) error {
+	if e.lock.owner == e.replica {
+		e.lock.owner = ""
+	}
+	e.resigned = true
+
+	return nil
+}
+
+func TestHandle_LeaderElection(t *testing.T) {
+	base := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	st := memory.New()
+	ctx := context.Background()
+	if err := st.CreateUser(ctx, &storage.User{Name: "bob", Email: "bob@example.com"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	// addEvent adds an event with a reminder due at the offset from the base time.
+	addEvent := func(title string, notifyAt time.Duration) {
+		t.Helper()
+		event := storage.Event{
+			Title:     title,
+			StartTime: base.Add(notifyAt + 10*time.Minute),
+			EndTime:   base.Add(notifyAt + 12*time.Minute),
+			Reminders: []storage.Reminder{{Offset: 10 * time.Minute, Channel: storage.ChannelEmail}},
+			Username:  "bob",
+		}
+		if _, err := st.CreateEvent(ctx, &event); err != nil {
+			t.Fatalf("CreateEvent: %v", err)
+		}
+	}
+
+	lock := &fakeLock{owner: ""}
+	electorA := &fakeElector{lock: lock, replica: "a", resigned: false}
+	electorB := &fakeElector{lock: lock, replica: "b", resigned: false}
+	queueA, queueB := &fakeQueue{t: t}, &fakeQueue{t: t}
+	replicaA := New(st, queueA, st, queueA, 1, electorA, "a", st, 0)
+	replicaB := New(st, queueB, st, queueB, 1, electorB, "b", st, 0)
+	expectSent := func(step string, q *fakeQueue, want ...string) {
+		t.Helper()
+		if !slices.Equal(q.titles(), want) {
+			t.Errorf("%s: sent %v, want %v", step, q.titles(), want)
+		}
+	}
+
+	// A single replica leads and dispatches.
+	addEvent("Stand-up", 90*time.Second)
+	replicaA.handle(ctx, jobElection, base.Add(time.Minute))
+	replicaB.handle(ctx, jobElection, base.Add(time.Minute))
+	replicaB.handle(ctx, jobNotifications, base.Add(time.Minute))
+	expectSent("a leads", queueA, "Stand-up")
+	expectSent("a leads", queueB)
+
+	// A loses the lock, B takes over and catches up without sending again.
+	lock.owner = ""
+	replicaB.handle(ctx, jobElection, base.Add(2*time.Minute))
+	addEvent("Review", 270*time.Second)
+	replicaA.handle(ctx, jobNotifications, base.Add(4*time.Minute))
+	replicaB.handle(ctx, jobNotifications, base.Add(4*time.Minute))
+	expectSent("b takes over", queueA, "Stand-up")
+	expectSent("b takes over", queueB, "Review")
+	if replicaA.leading.Load() {
+		t.Error("a still leads after losing the lock")
+	}
+
+	// B releases the lock on shutdown and A takes over, catching up the
+	// reminders due meanwhile.
+	addEvent("Retro", 510*time.Second)
+	stopped, cancel := context.WithCancel(ctx)
+	cancel()
+	replicaB.Run(stopped)
+	if !electorB.resigned || lock.owner != "" {
+		t.Fatalf("b did not release the lock on shutdown: owner %q", lock.owner)
+	}
+	replicaA.handle(ctx, jobElection, base.Add(10*time.Minute))
+	expectSent("a takes over", queueA, "Stand-up", "Retro")
+	expectSent("a takes over", queueB, "Review")
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type EventsCleaner interface {
+	DeleteExpiredEvents(ctx context.Context, now time.Time, retentionDays, limit int) (int, error)
+}
+
+// cleanupPeriod is the period of deleting the expired events.
+const cleanupPeriod = time.Hour
+
+// cleanupBatch is the number of events deleted at once, so that the events
+// of the users are not locked for long.
+const cleanupBatch = 500
+
+// deleteExpiredEvents deletes the events which ended more than the retention
+// ago in batches.
+func (a *App) deleteExpiredEvents(ctx context.Context, now time.Time) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := a.cleaner.DeleteExpiredEvents(ctx, now, a.retentionDays, cleanupBatch)
+		if err != nil {
+			slog.Error("Delete expired events", slog.String("error", err.Error()))
+			break
+		}
+		total += n
+		if a.deletedEvents != nil {
+			a.deletedEvents.Add(ctx, int64(n))
+		}
+		if n < cleanupBatch {
+			break
+		}
+	}
+	if total > 0 {
+		slog.Info("Deleted expired events", slog.Int("count", total))
+	}
+}
+
+func (a *App) registerRetentionMetric() error {
+	meter := otel.Meter("scheduler")
+	counter, err := meter.Int64Counter(
+		"scheduler.retention.deleted_events",
+		metric.WithDescription("Events deleted after the retention period"),
+	)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	a.deletedEvents = counter
+
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/smtp"
 	"text/template"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
 )
 
 type Conf struct {
@@ -19,22 +22,32 @@ type Message struct {
 	To          string
 	Subject     string
 	Description string
+	StartTime   time.Time
+	EndTime     time.Time
+	// AllDay events are shown as the dates of their UTC midnights.
+	AllDay bool
+	// TimeZone is the IANA name of the time zone in which the times are
+	// shown, UTC if empty or unknown.
+	TimeZone string
 }
 
+const timeLayout = "Mon, 02 Jan 2006 15:04 MST"
+
 const emailTemplate = `From: {{.From}}
 To: {{.To}}
 Subject: {{.Subject}}
 
+{{.When}}
 {{.Description}}
 `
 
 var tempEmail = template.Must(template.New("email").Parse(emailTemplate))
 
-var Send = func(conf *Conf, msg *Message, body []byte) error {
+var Send = func(conf *Conf, from string, to []string, body []byte) error {
 	auth := smtp.PlainAuth("", conf.SenderEmail, conf.Password, conf.Host)
 	confServer := fmt.Sprintf("%s:%d", conf.Host, conf.Port)
 
-	if err := smtp.SendMail(confServer, auth, msg.From, []string{msg.To}, body); err != nil {
+	if err := smtp.SendMail(confServer, auth, from, to, body); err != nil {
 		return fmt.Errorf("SendMail: %w", err)
 	}
 
@@ -45,13 +58,31 @@ func Alert(conf *Conf, msg *Message) error {
 	var body bytes.Buffer
 
 	msg.From = conf.SenderEmail
-	if err := tempEmail.Execute(&body, msg); err != nil {
+	data := struct {
+		*Message
+		When string
+	}{msg, msg.when()}
+	if err := tempEmail.Execute(&body, data); err != nil {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
-	if err := Send(conf, msg, body.Bytes()); err != nil {
+	if err := Send(conf, msg.From, []string{msg.To}, body.Bytes()); err != nil {
 		return fmt.Errorf("send: %w", err)
 	}
 
 	return nil
 }
+
+// when returns the dates of an all-day event, the times in its time zone
+// otherwise.
+func (msg *Message) when() string {
+	if msg.AllDay {
+		return msg.StartTime.UTC().Format(time.DateOnly) + " - " + msg.EndTime.UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+	loc, err := storage.LoadLocation(msg.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	return msg.StartTime.In(loc).Format(timeLayout) + " - " + msg.EndTime.In(loc).Format(timeLayout)
+}
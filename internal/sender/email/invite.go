@@ -0,0 +1,84 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+)
+
+// Invitation is an iTIP message sent by email as in iMIP, RFC 6047: a
+// multipart/alternative message with a plain text and a text/calendar part.
+type Invitation struct {
+	From       string
+	Recipients []string
+	Subject    string
+	// Method is the iTIP method of the calendar: REQUEST or CANCEL.
+	Method string
+	// Text is the plain text part for clients without calendar support.
+	Text string
+	// Calendar is the VCALENDAR object with the METHOD property.
+	Calendar string
+}
+
+// Invite sends the invitation to each recipient in a separate message.
+func Invite(conf *Conf, invitation *Invitation) error {
+	invitation.From = conf.SenderEmail
+
+	var errs []error
+	for _, to := range invitation.Recipients {
+		body, err := invitationBody(invitation, to)
+		if err != nil {
+			return err
+		}
+		if err := Send(conf, invitation.From, []string{to}, body); err != nil {
+			errs = append(errs, fmt.Errorf("send to %q: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// invitationBody returns the message of the invitation to the recipient.
+func invitationBody(invitation *Invitation, to string) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fmt.Fprintf(&body, "From: %s\r\n", invitation.From)
+	fmt.Fprintf(&body, "To: %s\r\n", to)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", invitation.Subject))
+	fmt.Fprintf(&body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&body, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", invitation.Text},
+		{fmt.Sprintf("text/calendar; method=%s; charset=UTF-8", invitation.Method), invitation.Calendar},
+	}
+	for _, part := range parts {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("create part: %w", err)
+		}
+		qp := quotedprintable.NewWriter(partWriter)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("write part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("write part: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart: %w", err)
+	}
+
+	return body.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package email
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestInvite(t *testing.T) {
+	var sent [][]byte
+	defer func(send func(*Conf, string, []string, []byte) error) { Send = send }(Send)
+	Send = func(_ *Conf, from string, to []string, body []byte) error {
+		if from != "calendar@example.com" || len(to) != 1 {
+			t.Errorf("unexpected envelope %q %v", from, to)
+		}
+		sent = append(sent, body)
+		return nil
+	}
+
+	calendar := "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n"
+	invitation := Invitation{
+		Recipients: []string{"bob@example.com", "carol@example.com"},
+		Subject:    "Invitation: Планирование",
+		Method:     "REQUEST",
+		Text:       "Планирование\n",
+		Calendar:   calendar,
+	}
+	if err := Invite(&Conf{SenderEmail: "calendar@example.com"}, &invitation); err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if len(sent) != len(invitation.Recipients) {
+		t.Fatalf("expected a message per recipient, got %d", len(sent))
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(sent[1]))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if to := msg.Header.Get("To"); to != "carol@example.com" {
+		t.Errorf("To: have %q", to)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != invitation.Subject {
+		t.Errorf("Subject: have %q, %v", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type: have %q, %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var types, contents []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		// The reader decodes quoted-printable parts.
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		types = append(types, part.Header.Get("Content-Type"))
+		contents = append(contents, string(content))
+	}
+	wantTypes := []string{"text/plain; charset=UTF-8", "text/calendar; method=REQUEST; charset=UTF-8"}
+	if strings.Join(types, ",") != strings.Join(wantTypes, ",") {
+		t.Errorf("parts: have %v, want %v", types, wantTypes)
+	}
+	// Lines of the text are ended by CRLF as in email.
+	if len(contents) == 2 && (strings.ReplaceAll(contents[0], "\r\n", "\n") != invitation.Text || contents[1] != calendar) {
+		t.Errorf("contents: have %q", contents)
+	}
+}
@@ -0,0 +1,167 @@
+// Package sender delivers the reminders taken from the queues by email or
+// webhook and the invitations by email.
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/queue"
+	"github.com/mrvin/calendar/internal/sender/email"
+	"github.com/mrvin/calendar/internal/sender/webhook"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+var (
+	ErrUnsupportedChannel = errors.New("unsupported reminder channel")
+	ErrNoRecipient        = errors.New("alert message without recipient")
+)
+
+// Storage finds the email of the owner of the event for the reminders of
+// version 1, which carry the username only, and records the delivered
+// reminders.
+type Storage interface {
+	GetUser(ctx context.Context, name string) (*storage.User, error)
+	NotificationDelivered(ctx context.Context, id uuid.UUID) (bool, error)
+	MarkNotificationDelivered(ctx context.Context, id uuid.UUID) error
+}
+
+type Sender struct {
+	st          Storage
+	conf        *email.Conf
+	webhookConf *webhook.Conf
+}
+
+func New(st Storage, conf *email.Conf, webhookConf *webhook.Conf) *Sender {
+	return &Sender{
+		st:          st,
+		conf:        conf,
+		webhookConf: webhookConf,
+	}
+}
+
+// SendAlert decodes the reminder message of any version and sends it to the
+// owner of the event through its channel. A message taken again after its
+// delivery is dropped by its ID, but a failure between the delivery and its
+// record lets it be delivered twice: the delivery is at least once.
+func (s *Sender) SendAlert(ctx context.Context, contentType string, body []byte) error {
+	alertEvent, err := queue.DecodeAlertEvent(contentType, body)
+	if err != nil {
+		return fmt.Errorf("take alert message: %w", err)
+	}
+	slog.Info("Take alert message from queue",
+		slog.String("Reminder id", alertEvent.ID.String()),
+		slog.String("Event id", alertEvent.EventID.String()),
+		slog.Int("version", alertEvent.Version),
+	)
+	// The messages of version 1 have no ID.
+	if alertEvent.ID == uuid.Nil {
+		return s.deliverAlert(ctx, alertEvent)
+	}
+	delivered, err := s.st.NotificationDelivered(ctx, alertEvent.ID)
+	if err != nil {
+		return fmt.Errorf("check alert delivered: %w", err)
+	}
+	if delivered {
+		slog.Info("Drop delivered alert message", slog.String("Reminder id", alertEvent.ID.String()))
+		return nil
+	}
+	if err := s.deliverAlert(ctx, alertEvent); err != nil {
+		return err
+	}
+	// The record of the notification is gone if its event was deleted.
+	if err := s.st.MarkNotificationDelivered(ctx, alertEvent.ID); err != nil && !errors.Is(err, storage.ErrNotificationNotFound) {
+		return fmt.Errorf("mark alert delivered: %w", err)
+	}
+
+	return nil
+}
+
+// deliverAlert sends the reminder through its channel.
+func (s *Sender) deliverAlert(ctx context.Context, alertEvent *queue.AlertEvent) error {
+	if alertEvent.Channel == string(storage.ChannelWebhook) {
+		return s.postAlert(ctx, alertEvent)
+	}
+	if alertEvent.Channel != "" && alertEvent.Channel != string(storage.ChannelEmail) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedChannel, alertEvent.Channel)
+	}
+	recipient := alertEvent.Recipient
+	if recipient == "" {
+		// The messages of version 1 were addressed to the user.
+		user, err := s.st.GetUser(ctx, alertEvent.Username)
+		if err != nil {
+			return fmt.Errorf("recipient of event %s: %w", alertEvent.EventID, err)
+		}
+		recipient = user.Email
+	}
+	if recipient == "" {
+		return fmt.Errorf("%w: event %s", ErrNoRecipient, alertEvent.EventID)
+	}
+
+	msg := email.Message{
+		To:          recipient,
+		Subject:     alertEvent.Title,
+		Description: alertEvent.Description,
+		StartTime:   alertEvent.StartTime,
+		EndTime:     alertEvent.EndTime,
+		AllDay:      alertEvent.AllDay,
+		TimeZone:    alertEvent.TimeZone,
+	}
+	if err := email.Alert(s.conf, &msg); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+	slog.Info("Event notification sent", slog.String("subject", msg.Subject), slog.String("to", msg.To))
+
+	return nil
+}
+
+// postAlert posts the reminder to the webhook of the owner.
+func (s *Sender) postAlert(ctx context.Context, alertEvent *queue.AlertEvent) error {
+	if alertEvent.Recipient == "" {
+		return fmt.Errorf("%w: event %s", ErrNoRecipient, alertEvent.EventID)
+	}
+	msg := webhook.Message{
+		ID:           alertEvent.ID,
+		EventID:      alertEvent.EventID,
+		Title:        alertEvent.Title,
+		Description:  alertEvent.Description,
+		StartTime:    alertEvent.StartTime,
+		EndTime:      alertEvent.EndTime,
+		AllDay:       alertEvent.AllDay,
+		TimeZone:     alertEvent.TimeZone,
+		NotifyBefore: alertEvent.NotifyBefore,
+	}
+	if err := webhook.Alert(ctx, s.webhookConf, alertEvent.Recipient, &msg); err != nil {
+		return fmt.Errorf("alert: %w", err)
+	}
+	slog.Info("Event notification posted", slog.String("subject", msg.Title), slog.String("event", msg.EventID.String()))
+
+	return nil
+}
+
+// SendInvitation decodes the invitation message of any version and sends it
+// by email to the attendees.
+func (s *Sender) SendInvitation(contentType string, body []byte) error {
+	invitation, err := queue.DecodeInvitation(contentType, body)
+	if err != nil {
+		return fmt.Errorf("take invitation message: %w", err)
+	}
+	slog.Info("Take invitation message from queue", slog.String("Invitation id", invitation.ID.String()))
+
+	emailInvitation := email.Invitation{
+		Recipients: invitation.Recipients,
+		Subject:    invitation.Subject,
+		Method:     invitation.Method,
+		Text:       invitation.Text,
+		Calendar:   invitation.Calendar,
+	}
+	if err := email.Invite(s.conf, &emailInvitation); err != nil {
+		return fmt.Errorf("invite: %w", err)
+	}
+	slog.Info("Invitation sent", slog.String("method", invitation.Method), slog.Int("recipients", len(invitation.Recipients)))
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/queue"
+	"github.com/mrvin/calendar/internal/sender/email"
+	"github.com/mrvin/calendar/internal/sender/webhook"
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+// alertEventV1 are the fields of the alert messages the scheduler of
+// version 1 gob-encoded without a content type.
+type alertEventV1 struct {
+	ID           uuid.UUID
+	EventID      uuid.UUID
+	Title        string
+	Description  string
+	StartTime    time.Time
+	EndTime      time.Time
+	TimeZone     string
+	Username     string
+	Channel      string
+	NotifyBefore time.Duration
+}
+
+func TestSendAlert(t *testing.T) {
+	type sent struct {
+		to  []string
+		msg *mail.Message
+	}
+	var mails []sent
+	defer func(send func(*email.Conf, string, []string, []byte) error) { email.Send = send }(email.Send)
+	email.Send = func(_ *email.Conf, _ string, to []string, body []byte) error {
+		msg, err := mail.ReadMessage(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("ReadMessage: %v", err)
+		}
+		mails = append(mails, sent{to: to, msg: msg})
+		return nil
+	}
+
+	st := memory.New()
+	ctx := context.Background()
+	if err := st.CreateUser(ctx, &storage.User{Name: "bob", Email: "bob@example.com"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	app := New(st, &email.Conf{SenderEmail: "calendar@example.com"}, &webhook.Conf{})
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	old := alertEventV1{
+		ID:           uuid.New(),
+		EventID:      uuid.New(),
+		Title:        "Stand-up",
+		StartTime:    start,
+		EndTime:      start.Add(15 * time.Minute),
+		TimeZone:     "Europe/Berlin",
+		Username:     "bob",
+		Channel:      "email",
+		NotifyBefore: 10 * time.Minute,
+	}
+	v1 := new(bytes.Buffer)
+	if err := gob.NewEncoder(v1).Encode(&old); err != nil {
+		t.Fatalf("gob: %v", err)
+	}
+	if err := app.SendAlert(ctx, "", v1.Bytes()); err != nil {
+		t.Fatalf("SendAlert of version 1: %v", err)
+	}
+
+	v2, err := queue.EncodeAlertEvent(&queue.AlertEvent{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		Title:     "Review",
+		StartTime: start.Add(time.Hour),
+		EndTime:   start.Add(2 * time.Hour),
+		Username:  "bob",
+		Recipient: "robert@example.com",
+		Channel:   "email",
+	})
+	if err != nil {
+		t.Fatalf("EncodeAlertEvent: %v", err)
+	}
+	if err := app.SendAlert(ctx, queue.ContentTypeJSON, v2); err != nil {
+		t.Fatalf("SendAlert of version 2: %v", err)
+	}
+
+	if len(mails) != 2 {
+		t.Fatalf("sent %d emails, want 2", len(mails))
+	}
+	// The message of version 1 goes to the email of the owner.
+	if len(mails[0].to) != 1 || mails[0].to[0] != "bob@example.com" || mails[0].msg.Header.Get("To") != "bob@example.com" {
+		t.Errorf("version 1 sent to %v, want the email of the owner", mails[0].to)
+	}
+	if subject := mails[0].msg.Header.Get("Subject"); subject != old.Title {
+		t.Errorf("version 1 subject %q, want %q", subject, old.Title)
+	}
+	if len(mails[1].to) != 1 || mails[1].to[0] != "robert@example.com" {
+		t.Errorf("version 2 sent to %v, want its recipient", mails[1].to)
+	}
+
+	// Nothing is sent if the owner is gone.
+	old.Username = "carol"
+	v1.Reset()
+	if err := gob.NewEncoder(v1).Encode(&old); err != nil {
+		t.Fatalf("gob: %v", err)
+	}
+	if err := app.SendAlert(ctx, "", v1.Bytes()); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("SendAlert of an unknown owner: have %v, want ErrUserNotFound", err)
+	}
+	if len(mails) != 2 {
+		t.Errorf("sent %d emails, want 2", len(mails))
+	}
+}
+
+func TestSendAlert_DropsDelivered(t *testing.T) {
+	sent := 0
+	defer func(send func(*email.Conf, string, []string, []byte) error) { email.Send = send }(email.Send)
+	email.Send = func(*email.Conf, string, []string, []byte) error {
+		sent++
+		return nil
+	}
+
+	st := memory.New()
+	ctx := context.Background()
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	//nolint:exhaustruct
+	notification := storage.Notification{
+		Event:    storage.Event{ID: uuid.New(), Title: "Stand-up", StartTime: start, EndTime: start.Add(15 * time.Minute)},
+		Reminder: storage.Reminder{Offset: 10 * time.Minute, Channel: storage.ChannelEmail},
+		Time:     start.Add(-10 * time.Minute),
+	}
+	if err := st.RecordNotification(ctx, &notification); err != nil {
+		t.Fatalf("RecordNotification: %v", err)
+	}
+	body, err := queue.EncodeAlertEvent(&queue.AlertEvent{
+		ID:        notification.ID,
+		EventID:   notification.Event.ID,
+		Title:     notification.Event.Title,
+		StartTime: notification.Event.StartTime,
+		EndTime:   notification.Event.EndTime,
+		Recipient: "bob@example.com",
+		Channel:   string(storage.ChannelEmail),
+	})
+	if err != nil {
+		t.Fatalf("EncodeAlertEvent: %v", err)
+	}
+
+	app := New(st, &email.Conf{}, &webhook.Conf{})
+	// The queue delivers the message again.
+	for range 2 {
+		if err := app.SendAlert(ctx, queue.ContentTypeJSON, body); err != nil {
+			t.Fatalf("SendAlert: %v", err)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("sent %d emails, want 1", sent)
+	}
+	if delivered, err := st.NotificationDelivered(ctx, notification.ID); err != nil || !delivered {
+		t.Errorf("NotificationDelivered: have %v %v, want true", delivered, err)
+	}
+}
+
+func TestSendAlert_Dates(t *testing.T) {
+	var bodies []string
+	defer func(send func(*email.Conf, string, []string, []byte) error) { email.Send = send }(email.Send)
+	email.Send = func(_ *email.Conf, _ string, _ []string, body []byte) error {
+		bodies = append(bodies, string(body))
+		return nil
+	}
+
+	app := New(memory.New(), &email.Conf{SenderEmail: "calendar@example.com"}, &webhook.Conf{})
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		alertEvent queue.AlertEvent
+		want       string
+	}{
+		{
+			queue.AlertEvent{StartTime: day.Add(9 * time.Hour), EndTime: day.Add(10 * time.Hour), TimeZone: "Europe/Berlin"},
+			"Mon, 06 Jan 2025 10:00 CET - Mon, 06 Jan 2025 11:00 CET",
+		},
+		{
+			queue.AlertEvent{StartTime: day, EndTime: day.AddDate(0, 0, 1), AllDay: true, TimeZone: "America/New_York"},
+			"2025-01-06 - 2025-01-06",
+		},
+		{
+			queue.AlertEvent{StartTime: day, EndTime: day.AddDate(0, 0, 3), AllDay: true, TimeZone: "Asia/Tokyo"},
+			"2025-01-06 - 2025-01-08",
+		},
+	}
+	for i, test := range tests {
+		test.alertEvent.ID = uuid.New()
+		test.alertEvent.Title = "Holiday"
+		test.alertEvent.Recipient = "bob@example.com"
+		body, err := queue.EncodeAlertEvent(&test.alertEvent)
+		if err != nil {
+			t.Fatalf("EncodeAlertEvent: %v", err)
+		}
+		if err := app.SendAlert(context.Background(), queue.ContentTypeJSON, body); err != nil {
+			t.Fatalf("SendAlert: %v", err)
+		}
+		if !strings.Contains(bodies[i], "\n"+test.want+"\n") {
+			t.Errorf("email %d:\n%s\nwant %q", i, bodies[i], test.want)
+		}
+	}
+}
+
+func TestSendAlert_Webhook(t *testing.T) {
+	type post struct {
+		key string
+		msg webhook.Message
+	}
+	posts := make(chan post, 2)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		var msg webhook.Message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Errorf("unmarshal body: %v", err)
+		}
+		posts <- post{key: req.Header.Get(webhook.IdempotencyKeyHeader), msg: msg}
+		// The webhook fails from the second delivery.
+		if calls.Add(1) > 1 {
+			res.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	app := New(memory.New(), &email.Conf{}, &webhook.Conf{})
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	alertEvent := queue.AlertEvent{
+		ID:           uuid.New(),
+		EventID:      uuid.New(),
+		Title:        "Stand-up",
+		StartTime:    start,
+		EndTime:      start.Add(15 * time.Minute),
+		Username:     "bob",
+		Recipient:    server.URL,
+		Channel:      string(storage.ChannelWebhook),
+		NotifyBefore: 10 * time.Minute,
+	}
+	body, err := queue.EncodeAlertEvent(&alertEvent)
+	if err != nil {
+		t.Fatalf("EncodeAlertEvent: %v", err)
+	}
+	if err := app.SendAlert(context.Background(), queue.ContentTypeJSON, body); err != nil {
+		t.Fatalf("SendAlert: %v", err)
+	}
+	posted := <-posts
+	if posted.key != alertEvent.ID.String() {
+		t.Errorf("idempotency key %q, want the message ID %s", posted.key, alertEvent.ID)
+	}
+	if posted.msg.EventID != alertEvent.EventID || posted.msg.Title != alertEvent.Title ||
+		!posted.msg.StartTime.Equal(start) || posted.msg.NotifyBefore != alertEvent.NotifyBefore {
+		t.Errorf("posted %+v", posted.msg)
+	}
+
+	// A failed delivery is an error, the message is delivered again with
+	// the same key.
+	if err := app.SendAlert(context.Background(), queue.ContentTypeJSON, body); !errors.Is(err, webhook.ErrStatus) {
+		t.Errorf("SendAlert to a failing webhook: have %v, want ErrStatus", err)
+	}
+	if posted := <-posts; posted.key != alertEvent.ID.String() {
+		t.Errorf("idempotency key %q, want the message ID %s", posted.key, alertEvent.ID)
+	}
+
+	// Nothing is posted without a webhook.
+	alertEvent.Recipient = ""
+	if body, err = queue.EncodeAlertEvent(&alertEvent); err != nil {
+		t.Fatalf("EncodeAlertEvent: %v", err)
+	}
+	if err := app.SendAlert(context.Background(), queue.ContentTypeJSON, body); !errors.Is(err, ErrNoRecipient) {
+		t.Errorf("SendAlert without webhook: have %v, want ErrNoRecipient", err)
+	}
+}
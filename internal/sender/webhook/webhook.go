@@ -0,0 +1,85 @@
+// Package webhook delivers the reminders by HTTP POST to the webhooks of the
+// users.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader carries the ID of the message, the same each time a
+// reminder is delivered again, so that the receiver can drop duplicates.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const defaultTimeout = 10
+
+var ErrStatus = errors.New("unexpected webhook response status")
+
+type Conf struct {
+	// Timeout bounds a delivery in seconds, 10 if zero.
+	Timeout int `yaml:"timeout"`
+}
+
+// Message is the JSON body posted to the webhook.
+//
+//nolint:tagliatelle
+type Message struct {
+	ID           uuid.UUID     `json:"id"`
+	EventID      uuid.UUID     `json:"event_id"`
+	Title        string        `json:"title"`
+	Description  string        `json:"description,omitempty"`
+	StartTime    time.Time     `json:"start_time"`
+	EndTime      time.Time     `json:"end_time"`
+	AllDay       bool          `json:"all_day,omitempty"`
+	TimeZone     string        `json:"time_zone,omitempty"`
+	NotifyBefore time.Duration `json:"notify_before"`
+}
+
+var Post = func(ctx context.Context, conf *Conf, url, idempotencyKey string, body []byte) error {
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", ErrStatus, res.Status)
+	}
+
+	return nil
+}
+
+// Alert posts the reminder to the webhook url with its ID as the
+// idempotency key.
+func Alert(ctx context.Context, conf *Conf, url string, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+	if err := Post(ctx, conf, url, msg.ID.String(), body); err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+
+	return nil
+}
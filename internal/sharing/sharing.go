@@ -0,0 +1,82 @@
+// Package sharing decides whose events a user acts on: their own ones or
+// those another user granted them access to.
+package sharing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// ErrAccessDenied is reported to users who see the events of the owner but
+// lack the role needed for the action.
+var ErrAccessDenied = errors.New("access denied")
+
+type Source interface {
+	GetGrant(ctx context.Context, owner, grantee string) (*storage.Grant, error)
+}
+
+type EventSource interface {
+	Source
+	GetEventOwner(ctx context.Context, id uuid.UUID) (string, error)
+}
+
+// GetRole returns the role of the user on the events of the owner: RoleOwner
+// on their own events, empty if the owner did not share them.
+func GetRole(ctx context.Context, src Source, username, owner string) (storage.Role, error) {
+	if username == owner {
+		return storage.RoleOwner, nil
+	}
+	grant, err := src.GetGrant(ctx, owner, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrGrantNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get grant: %w", err)
+	}
+
+	return grant.Role, nil
+}
+
+// Check returns nil if the user has the needed role on the events of the
+// owner. It reports storage.ErrUserNotFound rather than ErrAccessDenied to
+// users who cannot see the events, so that users cannot be probed.
+func Check(ctx context.Context, src Source, username, owner string, need storage.Role) error {
+	role, err := GetRole(ctx, src, username, owner)
+	if err != nil {
+		return err
+	}
+	if role.Allows(need) {
+		return nil
+	}
+	if role.Allows(storage.RoleViewer) {
+		return fmt.Errorf("%w: %s role needed", ErrAccessDenied, need)
+	}
+
+	return fmt.Errorf("%w: %q", storage.ErrUserNotFound, owner)
+}
+
+// EventOwner returns the username to access the event with: the owner of the
+// event if the user has the needed role, the user otherwise, who may still
+// see the event as an attendee.
+func EventOwner(ctx context.Context, src EventSource, username string, id uuid.UUID, need storage.Role) (string, error) {
+	owner, err := src.GetEventOwner(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("get event owner: %w", err)
+	}
+	role, err := GetRole(ctx, src, username, owner)
+	if err != nil {
+		return "", err
+	}
+	if role.Allows(need) {
+		return owner, nil
+	}
+	if role.Allows(storage.RoleViewer) {
+		return "", fmt.Errorf("%w: %s role needed", ErrAccessDenied, need)
+	}
+
+	return username, nil
+}
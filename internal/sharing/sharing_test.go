@@ -0,0 +1,80 @@
+package sharing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+	"github.com/mrvin/calendar/internal/storage/memory"
+)
+
+func TestEventOwner(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	for _, name := range []string{"lead", "report", "peer", "stranger"} {
+		if err := st.CreateUser(ctx, &storage.User{Name: name, Email: name + "@example.com"}); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	for _, grant := range []storage.Grant{
+		{Owner: "report", Grantee: "lead", Role: storage.RoleEditor},
+		{Owner: "report", Grantee: "peer", Role: storage.RoleViewer},
+		{Owner: "report", Grantee: "stranger", Role: storage.RoleFreeBusy},
+	} {
+		if err := st.SetGrant(ctx, &grant); err != nil {
+			t.Fatalf("SetGrant: %v", err)
+		}
+	}
+	//nolint:exhaustruct
+	event := storage.Event{Title: "1:1", Username: "report", StartTime: start, EndTime: start.Add(time.Hour)}
+	id, err := st.CreateEvent(ctx, &event)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	tests := []struct {
+		username string
+		need     storage.Role
+		want     string
+		wantErr  error
+	}{
+		{"report", storage.RoleOwner, "report", nil},
+		{"lead", storage.RoleEditor, "report", nil},
+		{"lead", storage.RoleOwner, "", ErrAccessDenied},
+		{"peer", storage.RoleViewer, "report", nil},
+		{"peer", storage.RoleEditor, "", ErrAccessDenied},
+		// Without access to the events the user is left with their own.
+		{"stranger", storage.RoleViewer, "stranger", nil},
+	}
+	for _, test := range tests {
+		got, err := EventOwner(ctx, st, test.username, id, test.need)
+		if !errors.Is(err, test.wantErr) || got != test.want {
+			t.Errorf("EventOwner(%s, %s): expected %q (%v), got %q (%v)", test.username, test.need, test.want, test.wantErr, got, err)
+		}
+	}
+	if _, err := st.GetEvent(ctx, "stranger", id); !errors.Is(err, storage.ErrEventNotFound) {
+		t.Errorf("GetEvent by stranger: expected %v, got %v", storage.ErrEventNotFound, err)
+	}
+
+	if err := Check(ctx, st, "stranger", "report", storage.RoleViewer); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("Check stranger: expected %v, got %v", storage.ErrUserNotFound, err)
+	}
+	if err := Check(ctx, st, "stranger", "report", storage.RoleFreeBusy); err != nil {
+		t.Errorf("Check stranger free/busy: %v", err)
+	}
+
+	if err := st.DeleteUser(ctx, "lead"); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	grants, err := st.ListGrants(ctx, "report")
+	if err != nil {
+		t.Fatalf("ListGrants: %v", err)
+	}
+	if len(grants) != 2 || grants[0].Grantee != "peer" || grants[1].Grantee != "stranger" {
+		t.Errorf("ListGrants after deleting the grantee: got %v", grants)
+	}
+}
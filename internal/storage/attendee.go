@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PartStat is the participation status of an attendee, PARTSTAT of RFC 5545.
+type PartStat string
+
+const (
+	PartStatNeedsAction PartStat = "needs-action"
+	PartStatAccepted    PartStat = "accepted"
+	PartStatDeclined    PartStat = "declined"
+	PartStatTentative   PartStat = "tentative"
+)
+
+// Attendee is a person invited to an event. Attendees are identified by
+// email; those with an account also have a Username and see the event among
+// their own.
+type Attendee struct {
+	Username string   `json:"username,omitempty"`
+	Email    string   `json:"email"`
+	Status   PartStat `json:"status"`
+	// Availability flags the invitation of a user outside of their work
+	// schedule, see Event.CheckAvailability.
+	Availability Availability `json:"availability,omitempty"`
+}
+
+// Reply is the response of an attendee to an invitation received by email,
+// an iTIP REPLY.
+type Reply struct {
+	// UID is the iCalendar UID of the event, see Event.CalendarUID.
+	UID string
+	// RecurrenceID is set in a reply to a single occurrence.
+	RecurrenceID *time.Time
+	Email        string
+	Status       PartStat
+	// Sequence is the revision of the event the attendee responds to.
+	Sequence int
+}
+
+// CalendarUID returns the iCalendar UID of the event: the imported one, if
+// any, or the event ID. An override of an occurrence shares the UID of its
+// series.
+func (e *Event) CalendarUID() string {
+	if e.UID != "" {
+		return e.UID
+	}
+	if e.ParentID != nil {
+		return e.ParentID.String()
+	}
+
+	return e.ID.String()
+}
+
+// RepliedBy reports whether the reply is to the event: to the series or to
+// the override of the occurrence.
+func (e *Event) RepliedBy(reply *Reply) bool {
+	if e.CalendarUID() != reply.UID {
+		return false
+	}
+	if reply.RecurrenceID == nil {
+		return e.ParentID == nil
+	}
+
+	return e.ParentID != nil && e.RecurrenceID != nil && e.RecurrenceID.Equal(*reply.RecurrenceID)
+}
+
+// AttendeeByEmail returns the attendee with the email or nil.
+func (e *Event) AttendeeByEmail(email string) *Attendee {
+	for i := range e.Attendees {
+		if strings.EqualFold(e.Attendees[i].Email, email) {
+			return &e.Attendees[i]
+		}
+	}
+
+	return nil
+}
+
+// Attendee returns the attendee with the username or nil.
+func (e *Event) Attendee(username string) *Attendee {
+	for i := range e.Attendees {
+		if e.Attendees[i].Username == username {
+			return &e.Attendees[i]
+		}
+	}
+
+	return nil
+}
+
+// Rescheduled reports whether the event takes place at other times than old.
+func (e *Event) Rescheduled(old *Event) bool {
+	return !e.StartTime.Equal(old.StartTime) || !e.EndTime.Equal(old.EndTime) ||
+		e.AllDay != old.AllDay || e.RRule != old.RRule
+}
+
+// OccurrenceAt returns the occurrence of the recurring event starting at
+// recurrenceID as a single event, like an override of it.
+func (e *Event) OccurrenceAt(recurrenceID time.Time) Event {
+	occurrence := *e
+	occurrence.StartTime = recurrenceID
+	occurrence.EndTime = recurrenceID.Add(e.EndTime.Sub(e.StartTime))
+	occurrence.RRule = ""
+	occurrence.ExDates = nil
+	occurrence.ParentID = &e.ID
+	occurrence.RecurrenceID = &recurrenceID
+
+	return occurrence
+}
+
+// MergeAttendees returns the attendees, without duplicate emails, keeping the
+// status of those already in old unless the event is rescheduled. The others
+// need to respond.
+func MergeAttendees(old, attendees []Attendee, rescheduled bool) []Attendee {
+	if len(attendees) == 0 {
+		return nil
+	}
+	merged := make([]Attendee, 0, len(attendees))
+	for _, attendee := range attendees {
+		if slices.ContainsFunc(merged, func(a Attendee) bool { return a.Email == attendee.Email }) {
+			continue
+		}
+		attendee.Status = PartStatNeedsAction
+		if !rescheduled {
+			if i := slices.IndexFunc(old, func(a Attendee) bool { return a.Email == attendee.Email }); i != -1 {
+				attendee.Status = old[i].Status
+			}
+		}
+		merged = append(merged, attendee)
+	}
+
+	return merged
+}
+
+// KeepAttendees sets the attendees of the event changed from old: those of
+// old if not given, keeping their statuses unless the event is rescheduled.
+func (e *Event) KeepAttendees(old *Event) {
+	attendees := e.Attendees
+	if attendees == nil {
+		attendees = old.Attendees
+	}
+	e.Attendees = MergeAttendees(old.Attendees, attendees, e.Rescheduled(old))
+}
+
+// ParsePartStat parses the response of an attendee: accepted, declined
+// or tentative.
+func ParsePartStat(s string) (PartStat, error) {
+	status := PartStat(s)
+	if status != PartStatAccepted && status != PartStatDeclined && status != PartStatTentative {
+		return "", fmt.Errorf("invalid attendee status %q", s)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingLink is a public page on which anyone may book a slot in the events
+// of the user without an account.
+//
+//nolint:tagliatelle
+type BookingLink struct {
+	ID uuid.UUID `json:"id"`
+	// Slug names the page of the link, unique among the links of all users.
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	// Duration is the length of the booked events.
+	Duration time.Duration `json:"duration"`
+	// Buffer is kept free of busy events before and after a booked event.
+	Buffer time.Duration `json:"buffer,omitempty"`
+	// DayStart and DayEnd are the working hours in the form 15:04, the end of
+	// the day is 24:00.
+	DayStart string `json:"day_start"`
+	DayEnd   string `json:"day_end"`
+	// Weekdays are the working days as in RRULE BYDAY, every day if empty.
+	Weekdays []string `json:"weekdays,omitempty"`
+	// TimeZone is the IANA name of the time zone of the working hours, the
+	// time zone of the user if empty.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Lookahead is how far ahead of the current time the slots may start.
+	Lookahead time.Duration `json:"lookahead"`
+	CreatedAt time.Time     `json:"created_at"`
+	Username  string        `json:"-"`
+}
+
+// WithBuffer returns a copy of the event which also lasts the buffer before
+// and after it, to check it for conflicts.
+func (e *Event) WithBuffer(buffer time.Duration) *Event {
+	buffered := *e
+	buffered.StartTime = e.StartTime.Add(-buffer)
+	buffered.EndTime = e.EndTime.Add(buffer)
+
+	return &buffered
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Transparency tells whether an event blocks the time of its user.
+type Transparency string
+
+const (
+	TransparencyBusy Transparency = "busy"
+	TransparencyFree Transparency = "free"
+)
+
+// ConflictPolicy tells what happens to an event which overlaps busy events
+// of its user.
+type ConflictPolicy string
+
+const (
+	// ConflictReject fails with ErrDateBusy.
+	ConflictReject ConflictPolicy = "reject"
+	// ConflictWarn stores the event and reports the busy events in Event.Conflicts.
+	ConflictWarn ConflictPolicy = "warn"
+	// ConflictAllow stores the event without checking.
+	ConflictAllow ConflictPolicy = "allow"
+)
+
+// ConflictError lists the busy events overlapped by a rejected event.
+type ConflictError struct {
+	IDs []uuid.UUID
+}
+
+func (e *ConflictError) Error() string {
+	ids := make([]string, len(e.IDs))
+	for i, id := range e.IDs {
+		ids[i] = id.String()
+	}
+
+	return fmt.Sprintf("%s: conflicts with %s", ErrDateBusy, strings.Join(ids, ", "))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrDateBusy
+}
+
+// IsBusy reports whether the event blocks the time of its user, the default.
+func (e *Event) IsBusy() bool {
+	return e.Transparency != TransparencyFree
+}
+
+// Resolve applies the policy to the busy events overlapped by the event.
+func (p ConflictPolicy) Resolve(event *Event, conflicts []uuid.UUID) error {
+	event.Conflicts = nil
+	if len(conflicts) == 0 {
+		return nil
+	}
+	switch p {
+	case ConflictWarn:
+		event.Conflicts = conflicts
+		return nil
+	case ConflictAllow:
+		return nil
+	case ConflictReject:
+	}
+
+	return &ConflictError{IDs: conflicts}
+}
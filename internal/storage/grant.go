@@ -0,0 +1,43 @@
+package storage
+
+import "time"
+
+// Role is the access of a user to the events of another one, the roles are
+// ordered from the widest to the narrowest.
+type Role string
+
+const (
+	// RoleOwner manages the events as their owner does.
+	RoleOwner Role = "owner"
+	// RoleEditor creates, changes and deletes the events.
+	RoleEditor Role = "editor"
+	// RoleViewer sees the events.
+	RoleViewer Role = "viewer"
+	// RoleFreeBusy sees only when the owner is busy.
+	RoleFreeBusy Role = "freebusy"
+)
+
+var roleRanks = map[Role]int{ //nolint:gochecknoglobals
+	RoleFreeBusy: 1,
+	RoleViewer:   2,
+	RoleEditor:   3,
+	RoleOwner:    4,
+}
+
+// Allows reports whether the role includes the needed one. The empty role,
+// no access, allows nothing.
+func (r Role) Allows(need Role) bool {
+	rank, ok := roleRanks[r]
+
+	return ok && rank >= roleRanks[need]
+}
+
+// Grant shares the events of Owner with Grantee.
+//
+//nolint:tagliatelle
+type Grant struct {
+	Owner     string    `json:"owner"`
+	Grantee   string    `json:"grantee"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Method is the iTIP method of a message to attendees, RFC 5546.
+type Method string
+
+const (
+	MethodRequest Method = "REQUEST"
+	MethodCancel  Method = "CANCEL"
+)
+
+// Invitation is an iTIP message about a change of an event to some of its
+// attendees. It is stored with the change and waits to be sent by email.
+type Invitation struct {
+	ID     uuid.UUID
+	Method Method
+	// Event is the event as of the change, its Organizer is the username of
+	// the owner.
+	Event Event
+	// Organizer is the email of the owner of the event.
+	Organizer  string
+	Recipients []string
+	CreatedAt  time.Time
+}
+
+// Invitations returns the messages to the attendees about the change of old
+// to event: old is nil for a new event, event is nil for a deleted one.
+// Attendees get a request if they are added or the event changes, and a
+// cancellation if they are removed or the event is deleted.
+func Invitations(old, event *Event, organizer string) []Invitation {
+	var invitations []Invitation
+	add := func(method Method, event *Event, recipients []string) {
+		if len(recipients) == 0 {
+			return
+		}
+		snapshot := *event
+		snapshot.Attendees = slices.Clone(event.Attendees)
+		snapshot.Organizer = event.Username
+		snapshot.Conflicts = nil
+		invitations = append(invitations, Invitation{
+			ID:         uuid.New(),
+			Method:     method,
+			Event:      snapshot,
+			Organizer:  organizer,
+			Recipients: recipients,
+			CreatedAt:  time.Now(),
+		})
+	}
+
+	switch {
+	case event == nil:
+		add(MethodCancel, old, emails(old.Attendees, nil))
+	case old == nil:
+		add(MethodRequest, event, emails(event.Attendees, nil))
+	default:
+		add(MethodCancel, event, emails(old.Attendees, event.Attendees))
+		if event.changed(old) {
+			add(MethodRequest, event, emails(event.Attendees, nil))
+		} else {
+			add(MethodRequest, event, emails(event.Attendees, old.Attendees))
+		}
+	}
+
+	return invitations
+}
+
+// SetSequence sets the sequence of the event changed from old, incremented
+// if the event is rescheduled.
+func (e *Event) SetSequence(old *Event) {
+	e.Sequence = old.Sequence
+	if e.Rescheduled(old) || !slices.EqualFunc(e.ExDates, old.ExDates, time.Time.Equal) {
+		e.Sequence++
+	}
+}
+
+// changed reports whether the attendees have to be told about the change of
+// the event from old.
+func (e *Event) changed(old *Event) bool {
+	return e.Rescheduled(old) || e.Title != old.Title || e.Description != old.Description ||
+		e.TimeZone != old.TimeZone || e.Sequence != old.Sequence
+}
+
+// emails returns the emails of the attendees who are not in except.
+func emails(attendees, except []Attendee) []string {
+	var result []string
+	for _, attendee := range attendees {
+		if !slices.ContainsFunc(except, func(a Attendee) bool { return a.Email == attendee.Email }) {
+			result = append(result, attendee.Email)
+		}
+	}
+
+	return result
+}
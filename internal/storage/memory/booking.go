@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateBookingLink(_ context.Context, link *storage.BookingLink) (uuid.UUID, error) {
+	link.ID = uuid.New()
+	link.CreatedAt = time.Now()
+
+	s.muBookingLinks.Lock()
+	defer s.muBookingLinks.Unlock()
+
+	if err := s.checkBookingSlug(link); err != nil {
+		return uuid.Nil, err
+	}
+	s.storeBookingLink(link)
+
+	return link.ID, nil
+}
+
+func (s *Storage) GetBookingLink(_ context.Context, username string, id uuid.UUID) (*storage.BookingLink, error) {
+	s.muBookingLinks.RLock()
+	defer s.muBookingLinks.RUnlock()
+
+	link, ok := s.mBookingLinks[id]
+	if !ok || link.Username != username {
+		return nil, fmt.Errorf("%w: %s", storage.ErrBookingLinkNotFound, id)
+	}
+
+	return &link, nil
+}
+
+func (s *Storage) GetBookingLinkBySlug(_ context.Context, slug string) (*storage.BookingLink, error) {
+	s.muBookingLinks.RLock()
+	defer s.muBookingLinks.RUnlock()
+
+	for _, link := range s.mBookingLinks {
+		if link.Slug == slug {
+			return &link, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", storage.ErrBookingLinkNotFound, slug)
+}
+
+func (s *Storage) ListBookingLinks(_ context.Context, username string) ([]storage.BookingLink, error) {
+	links := make([]storage.BookingLink, 0)
+
+	s.muBookingLinks.RLock()
+	for _, link := range s.mBookingLinks {
+		if link.Username == username {
+			links = append(links, link)
+		}
+	}
+	s.muBookingLinks.RUnlock()
+	slices.SortFunc(links, func(a, b storage.BookingLink) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return links, nil
+}
+
+func (s *Storage) UpdateBookingLink(_ context.Context, username string, id uuid.UUID, link *storage.BookingLink) error {
+	s.muBookingLinks.Lock()
+	defer s.muBookingLinks.Unlock()
+
+	oldLink, ok := s.mBookingLinks[id]
+	if !ok || oldLink.Username != username {
+		return fmt.Errorf("%w: %s", storage.ErrBookingLinkNotFound, id)
+	}
+	link.ID = id
+	link.CreatedAt = oldLink.CreatedAt
+	link.Username = username
+	if err := s.checkBookingSlug(link); err != nil {
+		return err
+	}
+	s.storeBookingLink(link)
+
+	return nil
+}
+
+func (s *Storage) DeleteBookingLink(_ context.Context, username string, id uuid.UUID) error {
+	s.muBookingLinks.Lock()
+	defer s.muBookingLinks.Unlock()
+
+	if link, ok := s.mBookingLinks[id]; !ok || link.Username != username {
+		return fmt.Errorf("%w: %s", storage.ErrBookingLinkNotFound, id)
+	}
+	delete(s.mBookingLinks, id)
+
+	return nil
+}
+
+// checkBookingSlug returns storage.ErrBookingLinkExists if another link has
+// the slug. Must be called with muBookingLinks held.
+func (s *Storage) checkBookingSlug(link *storage.BookingLink) error {
+	for id, other := range s.mBookingLinks {
+		if id != link.ID && other.Slug == link.Slug {
+			return fmt.Errorf("%w: %q", storage.ErrBookingLinkExists, link.Slug)
+		}
+	}
+
+	return nil
+}
+
+// storeBookingLink saves a copy of the link. Must be called with
+// muBookingLinks held.
+func (s *Storage) storeBookingLink(link *storage.BookingLink) {
+	stored := *link
+	stored.Weekdays = slices.Clone(link.Weekdays)
+	s.mBookingLinks[stored.ID] = stored
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateCalendar(_ context.Context, calendar *storage.Calendar) (uuid.UUID, error) {
+	calendar.ID = uuid.New()
+	calendar.CreatedAt = time.Now()
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	if err := s.checkCalendarName(calendar); err != nil {
+		return uuid.Nil, err
+	}
+	s.mCalendars[calendar.ID] = *calendar
+
+	return calendar.ID, nil
+}
+
+func (s *Storage) GetCalendar(_ context.Context, username string, id uuid.UUID) (*storage.Calendar, error) {
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+
+	calendar, err := s.getCalendar(username, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar, nil
+}
+
+func (s *Storage) ListCalendars(_ context.Context, username string) ([]storage.Calendar, error) {
+	calendars := make([]storage.Calendar, 0)
+
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+	for _, calendar := range s.mCalendars {
+		if calendar.Username == username {
+			calendars = append(calendars, calendar)
+		}
+	}
+	slices.SortFunc(calendars, func(a, b storage.Calendar) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return calendars, nil
+}
+
+func (s *Storage) UpdateCalendar(_ context.Context, username string, id uuid.UUID, calendar *storage.Calendar) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	oldCalendar, err := s.getCalendar(username, id)
+	if err != nil {
+		return err
+	}
+	calendar.ID = id
+	calendar.CreatedAt = oldCalendar.CreatedAt
+	calendar.Username = username
+	if err := s.checkCalendarName(calendar); err != nil {
+		return err
+	}
+	s.mCalendars[id] = *calendar
+
+	return nil
+}
+
+func (s *Storage) DeleteCalendar(_ context.Context, username string, id uuid.UUID) error {
+	organizer := s.userSettings(username).Email
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	if _, err := s.getCalendar(username, id); err != nil {
+		return err
+	}
+	for eventID, event := range s.mEvents {
+		if event.CalendarID == nil || *event.CalendarID != id {
+			continue
+		}
+		delete(s.mEvents, eventID)
+		// Overrides, in the calendar of their series, are cancelled with it.
+		if event.ParentID == nil {
+			s.invite(&event, nil, organizer)
+		}
+	}
+	delete(s.mCalendars, id)
+
+	return nil
+}
+
+// getCalendar returns the calendar of the user. Must be called with muEvents held.
+func (s *Storage) getCalendar(username string, id uuid.UUID) (storage.Calendar, error) {
+	calendar, ok := s.mCalendars[id]
+	if !ok || calendar.Username != username {
+		return storage.Calendar{}, fmt.Errorf("%w: %s", storage.ErrCalendarNotFound, id)
+	}
+
+	return calendar, nil
+}
+
+// checkCalendarName returns storage.ErrCalendarExists if the user has another
+// calendar with the name. Must be called with muEvents held.
+func (s *Storage) checkCalendarName(calendar *storage.Calendar) error {
+	for id, other := range s.mCalendars {
+		if id != calendar.ID && other.Username == calendar.Username && other.Name == calendar.Name {
+			return fmt.Errorf("%w: %q", storage.ErrCalendarExists, calendar.Name)
+		}
+	}
+
+	return nil
+}
+
+// checkCalendar returns storage.ErrCalendarNotFound if the event refers to
+// a calendar the owner of the event does not have. Must be called with
+// muEvents held.
+func (s *Storage) checkCalendar(event *storage.Event) error {
+	if event.CalendarID == nil {
+		return nil
+	}
+	_, err := s.getCalendar(event.Username, *event.CalendarID)
+
+	return err
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func TestCalendars(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	reminder := 15 * time.Minute
+	work := storage.Calendar{Name: "Work", Color: "#0b8043", NotifyBefore: &reminder, Username: "alice"}
+	workID, err := s.CreateCalendar(ctx, &work)
+	if err != nil {
+		t.Fatalf("CreateCalendar failed: %v", err)
+	}
+	if _, err := s.CreateCalendar(ctx, &storage.Calendar{Name: "Work", Username: "alice"}); !errors.Is(err, storage.ErrCalendarExists) {
+		t.Errorf("Expected ErrCalendarExists, got %v", err)
+	}
+	personalID, err := s.CreateCalendar(ctx, &storage.Calendar{Name: "Personal", Username: "alice"})
+	if err != nil {
+		t.Fatalf("CreateCalendar failed: %v", err)
+	}
+	bobsID, err := s.CreateCalendar(ctx, &storage.Calendar{Name: "Work", Username: "bob"})
+	if err != nil {
+		t.Fatalf("CreateCalendar of another user failed: %v", err)
+	}
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	newEvent := func(title string, calendarID *uuid.UUID, offset time.Duration) *storage.Event {
+		return &storage.Event{
+			Title:      title,
+			Username:   "alice",
+			StartTime:  start.Add(offset),
+			EndTime:    start.Add(offset + time.Hour),
+			CalendarID: calendarID,
+		}
+	}
+	review := newEvent("Review", &workID, 0)
+	if _, err := s.CreateEvent(ctx, review); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if len(review.Reminders) != 1 || review.Reminders[0] != (storage.Reminder{Offset: reminder, Channel: storage.ChannelEmail}) {
+		t.Errorf("Expected the reminder of the calendar, got %v", review.Reminders)
+	}
+	if _, err := s.CreateEvent(ctx, newEvent("Gym", &personalID, 2*time.Hour)); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, newEvent("Lunch", nil, 4*time.Hour)); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, newEvent("Foreign", &bobsID, 6*time.Hour)); !errors.Is(err, storage.ErrCalendarNotFound) {
+		t.Errorf("Expected ErrCalendarNotFound for the calendar of another user, got %v", err)
+	}
+
+	end := start.AddDate(0, 0, 1)
+	events, _ := s.ListCalendarEvents(ctx, "alice", []uuid.UUID{workID}, start, end)
+	if len(events) != 1 || events[0].Title != "Review" {
+		t.Errorf("Expected the events of the work calendar, got %+v", events)
+	}
+	events, _ = s.ListCalendarEvents(ctx, "alice", []uuid.UUID{workID, personalID}, start, end)
+	if len(events) != 2 {
+		t.Errorf("Expected the events of both calendars, got %d", len(events))
+	}
+
+	// Deleting a calendar deletes its events.
+	if err := s.DeleteCalendar(ctx, "bob", workID); !errors.Is(err, storage.ErrCalendarNotFound) {
+		t.Errorf("Expected ErrCalendarNotFound, got %v", err)
+	}
+	if err := s.DeleteCalendar(ctx, "alice", workID); err != nil {
+		t.Fatalf("DeleteCalendar failed: %v", err)
+	}
+	if events, _ = s.ListEvents(ctx, "alice", start, end); len(events) != 2 {
+		t.Errorf("Expected 2 events left, got %d", len(events))
+	}
+	calendars, _ := s.ListCalendars(ctx, "alice")
+	if len(calendars) != 1 || calendars[0].ID != personalID {
+		t.Errorf("Expected the personal calendar left, got %+v", calendars)
+	}
+}
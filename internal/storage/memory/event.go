@@ -1,8 +1,11 @@
 package memory
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,47 +13,140 @@ import (
 )
 
 func (s *Storage) CreateEvent(_ context.Context, event *storage.Event) (uuid.UUID, error) {
-	event.ID = uuid.New()
+	return s.createEvent(event, nil)
+}
+
+func (s *Storage) Book(_ context.Context, event *storage.Event, buffer time.Duration) (uuid.UUID, error) {
+	return s.createEvent(event, &buffer)
+}
+
+// createEvent stores the new event. The event of a booking, with a buffer,
+// is rejected on conflict whatever the conflict policy of the user.
+func (s *Storage) createEvent(event *storage.Event, buffer *time.Duration) (uuid.UUID, error) {
+	user, err := s.prepareEvent(event)
+	if err != nil {
+		return uuid.Nil, err
+	}
 
 	s.muEvents.Lock()
 	defer s.muEvents.Unlock()
 
-	for _, existEvent := range s.mEvents {
-		if existEvent.Username == event.Username {
-			if existEvent.StartTime.Before(event.EndTime) && existEvent.EndTime.After(event.StartTime) {
-				return uuid.Nil, storage.ErrDateBusy
-			}
-		}
+	if err := s.insertEvent(user, event, buffer); err != nil {
+		return uuid.Nil, err
 	}
-	s.mEvents[event.ID] = *event
 
 	return event.ID, nil
 }
 
+// prepareEvent fills in the new event with the defaults of the user before
+// insertEvent and returns the settings of the user.
+func (s *Storage) prepareEvent(event *storage.Event) (storage.User, error) {
+	event.ID = uuid.New()
+	user := s.userSettings(event.Username)
+	if event.TimeZone == "" {
+		event.TimeZone = user.TimeZone
+	}
+	attendees, err := s.resolveAttendees(event.Attendees)
+	if err != nil {
+		return user, err
+	}
+	event.Attendees = storage.MergeAttendees(nil, attendees, true)
+	event.Resources = storage.SortResources(event.Resources)
+
+	return user, nil
+}
+
+// insertEvent checks and stores the event prepared by prepareEvent, as in
+// createEvent. Must be called with muEvents held.
+func (s *Storage) insertEvent(user storage.User, event *storage.Event, buffer *time.Duration) error {
+	if err := s.checkUID(event.Username, event.UID); err != nil {
+		return err
+	}
+	if event.CalendarID != nil {
+		calendar, err := s.getCalendar(event.Username, *event.CalendarID)
+		if err != nil {
+			return err
+		}
+		if event.Reminders == nil {
+			event.Reminders = calendar.DefaultReminders()
+		}
+	}
+	if buffer != nil {
+		if err := s.checkBusy(storage.ConflictReject, event.WithBuffer(*buffer), nil); err != nil {
+			return err
+		}
+	} else if err := s.checkBusy(user.ConflictPolicy, event, nil); err != nil {
+		return err
+	}
+	if err := s.checkResources(event, nil); err != nil {
+		return err
+	}
+	s.store(event)
+	s.invite(nil, event, user.Email)
+
+	return nil
+}
+
 func (s *Storage) GetEvent(_ context.Context, username string, id uuid.UUID) (*storage.Event, error) {
 	s.muEvents.RLock()
 	event, ok := s.mEvents[id]
 	s.muEvents.RUnlock()
-	if !ok || event.Username != username {
+	if !ok || event.Username != username && event.Attendee(username) == nil {
 		return nil, fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
 	}
+	if event.Username != username {
+		event.Organizer = event.Username
+	}
 
 	return &event, nil
 }
 
-func (s *Storage) DeleteEvent(_ context.Context, username string, id uuid.UUID) error {
+func (s *Storage) GetEventOwner(_ context.Context, id uuid.UUID) (string, error) {
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+
+	event, ok := s.mEvents[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
+	}
+
+	return event.Username, nil
+}
+
+func (s *Storage) DeleteEvent(_ context.Context, username string, id uuid.UUID, revision int64) error {
+	organizer := s.userSettings(username).Email
+
 	s.muEvents.Lock()
 	defer s.muEvents.Unlock()
 
-	if event, ok := s.mEvents[id]; !ok || event.Username != username {
+	event, ok := s.mEvents[id]
+	if !ok || event.Username != username {
 		return fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
 	}
+	if revision != 0 && revision != event.Revision {
+		return fmt.Errorf("%w: %s", storage.ErrEventChanged, id)
+	}
 	delete(s.mEvents, id)
+	if event.ParentID != nil {
+		s.touch(*event.ParentID)
+	}
+	s.invite(&event, nil, organizer)
+	for overrideID, override := range s.mEvents {
+		if override.ParentID != nil && *override.ParentID == id {
+			delete(s.mEvents, overrideID)
+		}
+	}
 
 	return nil
 }
 
 func (s *Storage) UpdateEvent(_ context.Context, username string, id uuid.UUID, event *storage.Event) error {
+	user := s.userSettings(username)
+	var err error
+	if event.Attendees, err = s.resolveAttendees(event.Attendees); err != nil {
+		return err
+	}
+
 	s.muEvents.Lock()
 	defer s.muEvents.Unlock()
 
@@ -58,32 +154,496 @@ func (s *Storage) UpdateEvent(_ context.Context, username string, id uuid.UUID,
 	if !ok || oldEvent.Username != username {
 		return fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
 	}
+	if event.Revision != 0 && event.Revision != oldEvent.Revision {
+		return fmt.Errorf("%w: %s", storage.ErrEventChanged, id)
+	}
 
-	for eventID, existEvent := range s.mEvents {
-		if existEvent.Username == username && eventID != id {
-			if existEvent.StartTime.Before(event.EndTime) && existEvent.EndTime.After(event.StartTime) {
-				return storage.ErrDateBusy
-			}
+	return s.updateEvent(user, &oldEvent, event)
+}
+
+func (s *Storage) UpdateEventOccurrence(
+	_ context.Context,
+	username string,
+	id uuid.UUID,
+	recurrenceID time.Time,
+	scope storage.Scope,
+	event *storage.Event,
+) error {
+	user := s.userSettings(username)
+	policy := user.ConflictPolicy
+	var err error
+	if event.Attendees, err = s.resolveAttendees(event.Attendees); err != nil {
+		return err
+	}
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	series, override, err := s.getOccurrence(username, id, recurrenceID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case scope == storage.ScopeAll || scope == storage.ScopeFollowing && recurrenceID.Equal(series.StartTime):
+		return s.updateEvent(user, series, event)
+	case scope == storage.ScopeThis:
+		reference := series.OccurrenceAt(recurrenceID)
+		if override != nil {
+			reference = *override
+		}
+		event.KeepAttendees(&reference)
+		event.KeepResources(&reference)
+		series.Exclude(recurrenceID)
+		event.ID = uuid.New()
+		if override != nil {
+			event.ID = override.ID
+		}
+		event.RRule = ""
+		event.ExDates = nil
+		event.ParentID = &id
+		event.RecurrenceID = &recurrenceID
+		event.UID = series.UID
+		event.CalendarID = series.CalendarID
+		if event.TimeZone == "" {
+			event.TimeZone = series.TimeZone
+		}
+		event.Username = username
+		event.SetSequence(&reference)
+		changed := map[uuid.UUID]*storage.Event{id: series, event.ID: nil}
+		if err := s.checkBusy(policy, event, changed); err != nil {
+			return err
+		}
+		if err := s.checkResources(event, changed); err != nil {
+			return err
+		}
+		series.Revision++
+		s.mEvents[id] = *series
+		s.store(event)
+		s.invite(&reference, event, user.Email)
+	case scope == storage.ScopeFollowing:
+		rule, err := series.RuleFrom(recurrenceID)
+		if err != nil {
+			return fmt.Errorf("split series: %w", err)
+		}
+		if event.RRule == "" {
+			event.RRule = rule
+		}
+		reference := series.OccurrenceAt(recurrenceID)
+		reference.RRule = rule
+		event.KeepAttendees(&reference)
+		event.KeepResources(&reference)
+		oldSeries := *series
+		if err := series.TruncateBefore(recurrenceID); err != nil {
+			return fmt.Errorf("split series: %w", err)
+		}
+		series.Sequence++
+		event.ID = uuid.New()
+		event.ExDates = nil
+		event.ParentID = nil
+		event.RecurrenceID = nil
+		event.UID = ""
+		if event.CalendarID == nil {
+			event.CalendarID = series.CalendarID
+		}
+		if event.TimeZone == "" {
+			event.TimeZone = series.TimeZone
+		}
+		event.Username = username
+		if err := s.checkCalendar(event); err != nil {
+			return err
+		}
+		changed := s.followingOverrides(id, recurrenceID)
+		changed[id] = series
+		if err := s.checkBusy(policy, event, changed); err != nil {
+			return err
+		}
+		if err := s.checkResources(event, changed); err != nil {
+			return err
+		}
+		s.apply(changed)
+		s.store(event)
+		s.invite(&oldSeries, series, user.Email)
+		s.invite(nil, event, user.Email)
+	default:
+		return fmt.Errorf("unknown scope %q", scope)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteEventOccurrence(
+	_ context.Context,
+	username string,
+	id uuid.UUID,
+	recurrenceID time.Time,
+	scope storage.Scope,
+) error {
+	organizer := s.userSettings(username).Email
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	series, override, err := s.getOccurrence(username, id, recurrenceID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case scope == storage.ScopeAll || scope == storage.ScopeFollowing && recurrenceID.Equal(series.StartTime):
+		changed := s.followingOverrides(id, series.StartTime)
+		changed[id] = nil
+		s.apply(changed)
+		s.invite(series, nil, organizer)
+	case scope == storage.ScopeThis:
+		occurrence := series.OccurrenceAt(recurrenceID)
+		if override != nil {
+			occurrence = *override
+		}
+		series.Exclude(recurrenceID)
+		series.Sequence++
+		occurrence.Sequence = series.Sequence
+		series.Revision++
+		s.mEvents[id] = *series
+		if override != nil {
+			delete(s.mEvents, override.ID)
+		}
+		s.invite(&occurrence, nil, organizer)
+	case scope == storage.ScopeFollowing:
+		oldSeries := *series
+		if err := series.TruncateBefore(recurrenceID); err != nil {
+			return fmt.Errorf("split series: %w", err)
 		}
+		series.Sequence++
+		changed := s.followingOverrides(id, recurrenceID)
+		changed[id] = series
+		s.apply(changed)
+		s.invite(&oldSeries, series, organizer)
+	default:
+		return fmt.Errorf("unknown scope %q", scope)
 	}
 
-	s.mEvents[id] = *event
+	return nil
+}
+
+func (s *Storage) UpdateAttendeeStatus(_ context.Context, username string, id uuid.UUID, status storage.PartStat) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	event, ok := s.mEvents[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
+	}
+	event.Attendees = slices.Clone(event.Attendees)
+	attendee := event.Attendee(username)
+	if attendee == nil {
+		return fmt.Errorf("%w: %q: %s", storage.ErrAttendeeNotFound, username, id)
+	}
+	attendee.Status = status
+	s.mEvents[id] = event
 
 	return nil
 }
 
+func (s *Storage) ApplyReply(_ context.Context, reply *storage.Reply) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	err := fmt.Errorf("%w: uid %q", storage.ErrEventNotFound, reply.UID)
+	updated := false
+	for id, event := range s.mEvents {
+		if !event.RepliedBy(reply) {
+			continue
+		}
+		event.Attendees = slices.Clone(event.Attendees)
+		attendee := event.AttendeeByEmail(reply.Email)
+		switch {
+		case attendee == nil:
+			if !errors.Is(err, storage.ErrOutdatedReply) {
+				err = fmt.Errorf("%w: %q: uid %q", storage.ErrAttendeeNotFound, reply.Email, reply.UID)
+			}
+		case reply.Sequence < event.Sequence:
+			err = fmt.Errorf("%w: uid %q sequence %d", storage.ErrOutdatedReply, reply.UID, reply.Sequence)
+		default:
+			attendee.Status = reply.Status
+			s.mEvents[id] = event
+			updated = true
+		}
+	}
+	if updated {
+		return nil
+	}
+
+	return err
+}
+
 func (s *Storage) ListEvents(_ context.Context, username string, start, end time.Time) ([]storage.Event, error) {
+	return s.listEvents(username, start, end, func(event *storage.Event) bool {
+		return event.Username == username || event.Organizer != ""
+	})
+}
+
+func (s *Storage) ListCalendarEvents(
+	_ context.Context,
+	username string,
+	calendarIDs []uuid.UUID,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	return s.listEvents(username, start, end, func(event *storage.Event) bool {
+		return event.Username == username && event.CalendarID != nil && slices.Contains(calendarIDs, *event.CalendarID)
+	})
+}
+
+// listEvents returns the occurrences in [start, end) of the events selected
+// by the filter, those the user is invited to marked with the organizer.
+func (s *Storage) listEvents(username string, start, end time.Time, filter func(event *storage.Event) bool) ([]storage.Event, error) {
 	events := make([]storage.Event, 0)
 
 	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
 	for _, event := range s.mEvents {
-		if event.Username == username {
-			if event.StartTime.Before(end) && event.EndTime.After(start) {
-				events = append(events, event)
+		if event.Username != username && event.Attendee(username) != nil {
+			event.Organizer = event.Username
+		}
+		if filter(&event) {
+			occurrences, err := event.Occurrences(start, end)
+			if err != nil {
+				return nil, fmt.Errorf("list events: %w", err)
 			}
+			events = append(events, occurrences...)
+		}
+	}
+
+	return events, nil
+}
+
+func (s *Storage) ListSeries(_ context.Context, username string) ([]storage.Event, error) {
+	events := make([]storage.Event, 0)
+
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+	for _, event := range s.mEvents {
+		if event.Username == username {
+			events = append(events, event)
 		}
 	}
-	s.muEvents.RUnlock()
 
 	return events, nil
 }
+
+// updateEvent replaces the fields of oldEvent given by the user.
+// Must be called with muEvents held.
+func (s *Storage) updateEvent(user storage.User, oldEvent, event *storage.Event) error {
+	event.ID = oldEvent.ID
+	if event.ExDates == nil {
+		event.ExDates = oldEvent.ExDates
+	}
+	event.ParentID = oldEvent.ParentID
+	event.RecurrenceID = oldEvent.RecurrenceID
+	event.UID = oldEvent.UID
+	if event.TimeZone == "" {
+		event.TimeZone = oldEvent.TimeZone
+	}
+	event.Username = oldEvent.Username
+	if oldEvent.ParentID != nil {
+		event.CalendarID = oldEvent.CalendarID
+	}
+	event.KeepAttendees(oldEvent)
+	event.KeepResources(oldEvent)
+	event.SetSequence(oldEvent)
+	if err := s.checkCalendar(event); err != nil {
+		return err
+	}
+	changed := map[uuid.UUID]*storage.Event{oldEvent.ID: nil}
+	if err := s.checkBusy(user.ConflictPolicy, event, changed); err != nil {
+		return err
+	}
+	if err := s.checkResources(event, changed); err != nil {
+		return err
+	}
+	s.store(event)
+	if event.ParentID != nil {
+		s.touch(*event.ParentID)
+	}
+	// Overrides of occurrences follow their series to another calendar.
+	for id, override := range s.mEvents {
+		if override.ParentID != nil && *override.ParentID == event.ID {
+			override.CalendarID = event.CalendarID
+			s.mEvents[id] = override
+		}
+	}
+	s.invite(oldEvent, event, user.Email)
+
+	return nil
+}
+
+// getOccurrence returns the recurring event and the override of its occurrence
+// starting at recurrenceID, if any. Must be called with muEvents held.
+func (s *Storage) getOccurrence(username string, id uuid.UUID, recurrenceID time.Time) (*storage.Event, *storage.Event, error) {
+	series, ok := s.mEvents[id]
+	if !ok || series.Username != username {
+		return nil, nil, fmt.Errorf("%w: %s", storage.ErrEventNotFound, id)
+	}
+	for _, event := range s.mEvents {
+		if event.ParentID != nil && *event.ParentID == id && event.RecurrenceID.Equal(recurrenceID) {
+			return &series, &event, nil
+		}
+	}
+	ok, err := series.HasOccurrence(recurrenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get occurrence: %w", err)
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s %s", storage.ErrOccurrenceNotFound, id, recurrenceID)
+	}
+
+	return &series, nil, nil
+}
+
+// followingOverrides returns the overrides of the series occurrences starting
+// at or after recurrenceID marked for deletion. Must be called with muEvents held.
+func (s *Storage) followingOverrides(id uuid.UUID, recurrenceID time.Time) map[uuid.UUID]*storage.Event {
+	changed := make(map[uuid.UUID]*storage.Event)
+	for overrideID, event := range s.mEvents {
+		if event.ParentID != nil && *event.ParentID == id && !event.RecurrenceID.Before(recurrenceID) {
+			changed[overrideID] = nil
+		}
+	}
+
+	return changed
+}
+
+// apply stores the changed events, deleting those mapped to nil.
+// Must be called with muEvents held.
+func (s *Storage) apply(changed map[uuid.UUID]*storage.Event) {
+	for id, event := range changed {
+		if event == nil {
+			delete(s.mEvents, id)
+			continue
+		}
+		event.Revision++
+		s.mEvents[id] = *event
+	}
+}
+
+// checkUID returns storage.ErrDuplicateUID if the user already has a series
+// with the uid. Must be called with muEvents held.
+func (s *Storage) checkUID(username, uid string) error {
+	if uid == "" {
+		return nil
+	}
+	for _, event := range s.mEvents {
+		if event.Username == username && event.ParentID == nil && event.UID == uid {
+			return fmt.Errorf("%w: %q", storage.ErrDuplicateUID, uid)
+		}
+	}
+
+	return nil
+}
+
+// checkBusy applies the conflict policy to the busy events of the user
+// overlapped by the event, unless the options allow the conflict. The events
+// in changed are checked in their new state, those mapped to nil are skipped.
+// Must be called with muEvents held.
+func (s *Storage) checkBusy(policy storage.ConflictPolicy, event *storage.Event, changed map[uuid.UUID]*storage.Event) error {
+	if policy == storage.ConflictAllow {
+		return policy.Resolve(event, nil)
+	}
+
+	var conflicts []uuid.UUID
+	for eventID, existEvent := range s.mEvents {
+		if newEvent, ok := changed[eventID]; ok {
+			if newEvent == nil {
+				continue
+			}
+			existEvent = *newEvent
+		}
+		if existEvent.Username != event.Username {
+			continue
+		}
+		busy, err := s.opts.Conflicts(&existEvent, event)
+		if err != nil {
+			return fmt.Errorf("check overlap: %w", err)
+		}
+		if busy {
+			conflicts = append(conflicts, eventID)
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return policy.Resolve(event, conflicts)
+}
+
+// checkResources returns storage.ErrResourceNotFound for an unknown resource
+// of the event and a storage.ResourceBusyError if other events reserve one
+// of its resources at the same time. The events in changed are checked as in
+// checkBusy. Must be called with muEvents held.
+func (s *Storage) checkResources(event *storage.Event, changed map[uuid.UUID]*storage.Event) error {
+	for _, resourceID := range event.Resources {
+		if _, ok := s.mResources[resourceID]; !ok {
+			return fmt.Errorf("%w: %s", storage.ErrResourceNotFound, resourceID)
+		}
+	}
+
+	for _, resourceID := range event.Resources {
+		var conflicts []uuid.UUID
+		for eventID, existEvent := range s.mEvents {
+			if newEvent, ok := changed[eventID]; ok {
+				if newEvent == nil {
+					continue
+				}
+				existEvent = *newEvent
+			}
+			if !slices.Contains(existEvent.Resources, resourceID) {
+				continue
+			}
+			busy, err := storage.ReservationConflicts(&existEvent, event)
+			if err != nil {
+				return fmt.Errorf("check reservation: %w", err)
+			}
+			if busy {
+				conflicts = append(conflicts, eventID)
+			}
+		}
+		if conflicts != nil {
+			slices.SortFunc(conflicts, func(a, b uuid.UUID) int {
+				return bytes.Compare(a[:], b[:])
+			})
+			return &storage.ResourceBusyError{ResourceID: resourceID, IDs: conflicts}
+		}
+	}
+
+	return nil
+}
+
+// store saves the event without the fields which are not stored, checking
+// the availability of the invited users. Must be called with muEvents held.
+func (s *Storage) store(event *storage.Event) {
+	s.checkAvailability(event)
+	stored := *event
+	stored.Attendees = slices.Clone(event.Attendees)
+	stored.Resources = slices.Clone(event.Resources)
+	stored.Reminders = slices.Clone(event.Reminders)
+	stored.Organizer = ""
+	stored.Conflicts = nil
+	stored.Revision = s.mEvents[stored.ID].Revision + 1
+	event.Revision = stored.Revision
+	s.mEvents[stored.ID] = stored
+}
+
+// touch increments the revision of the stored event. Must be called with
+// muEvents held.
+func (s *Storage) touch(id uuid.UUID) {
+	if event, ok := s.mEvents[id]; ok {
+		event.Revision++
+		s.mEvents[id] = event
+	}
+}
+
+// invite queues the invitations to the attendees about the change of old to
+// event. Must be called with muEvents held.
+func (s *Storage) invite(old, event *storage.Event, organizer string) {
+	s.invitations = append(s.invitations, storage.Invitations(old, event, organizer)...)
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -181,6 +182,36 @@ func TestUpdateEvent_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateEvent_StaleRevision(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	now := time.Now()
+	event := &storage.Event{
+		Title:     "Original Title",
+		Username:  "testuser",
+		StartTime: now,
+		EndTime:   now.Add(1 * time.Hour),
+	}
+	eventID, _ := s.CreateEvent(ctx, event)
+	read, _ := s.GetEvent(ctx, "testuser", eventID)
+
+	first := *read
+	first.Title = "First"
+	if err := s.UpdateEvent(ctx, "testuser", eventID, &first); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	second := *read
+	second.Title = "Second"
+	if err := s.UpdateEvent(ctx, "testuser", eventID, &second); !errors.Is(err, storage.ErrEventChanged) {
+		t.Fatalf("UpdateEvent with stale revision: expected %v, got %v", storage.ErrEventChanged, err)
+	}
+	retrieved, _ := s.GetEvent(ctx, "testuser", eventID)
+	if retrieved.Title != "First" || retrieved.Revision != first.Revision {
+		t.Errorf("Event changed by stale update: %q revision %d", retrieved.Title, retrieved.Revision)
+	}
+}
+
 func TestUpdateEvent_TimingConflict(t *testing.T) {
 	s := New()
 	ctx := context.Background()
@@ -246,7 +277,7 @@ func TestDeleteEvent_Success(t *testing.T) {
 	}
 	eventID, _ := s.CreateEvent(ctx, event)
 
-	err := s.DeleteEvent(ctx, "testuser", eventID)
+	err := s.DeleteEvent(ctx, "testuser", eventID, 0)
 	if err != nil {
 		t.Fatalf("DeleteEvent failed: %v", err)
 	}
@@ -262,7 +293,7 @@ func TestDeleteEvent_NotFound(t *testing.T) {
 	s := New()
 	ctx := context.Background()
 
-	err := s.DeleteEvent(ctx, "testuser", uuid.New())
+	err := s.DeleteEvent(ctx, "testuser", uuid.New(), 0)
 	if !errors.Is(err, storage.ErrEventNotFound) {
 		t.Errorf("Expected ErrEventNotFound, got %v", err)
 	}
@@ -376,7 +407,7 @@ func TestConcurrentCreateAndDelete(t *testing.T) {
 			time.Sleep(10 * time.Millisecond)
 
 			// Try to delete
-			if err := s.DeleteEvent(ctx, "testuser", eventID); err != nil {
+			if err := s.DeleteEvent(ctx, "testuser", eventID, 0); err != nil {
 				errChan <- err
 			}
 		}(i)
@@ -434,3 +465,701 @@ func TestConcurrentUpdateSameEvent(t *testing.T) {
 		t.Errorf("Expected all updates to succeed, got %d/%d", successCount, numUpdaters)
 	}
 }
+
+func TestListEvents_ExpandsRecurrence(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := &storage.Event{
+		Title:     "Stand-up",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+	}
+	if _, err := s.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+
+	// Second week: Monday, Wednesday, Friday
+	events, err := s.ListEvents(ctx, "testuser", start.AddDate(0, 0, 7), start.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 occurrences, got %d", len(events))
+	}
+	for _, occurrence := range events {
+		if occurrence.ID != event.ID {
+			t.Errorf("Occurrence must keep event ID")
+		}
+		if occurrence.EndTime.Sub(occurrence.StartTime) != 15*time.Minute {
+			t.Errorf("Occurrence must keep event duration")
+		}
+	}
+}
+
+func TestCreateEvent_RecurringConflict(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event1 := &storage.Event{
+		Title:     "Stand-up",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(30 * time.Minute),
+		RRule:     "FREQ=DAILY",
+	}
+	if _, err := s.CreateEvent(ctx, event1); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+
+	// Overlaps the occurrence a month later
+	event2 := &storage.Event{
+		Title:     "Review",
+		Username:  "testuser",
+		StartTime: start.AddDate(0, 1, 0).Add(15 * time.Minute),
+		EndTime:   start.AddDate(0, 1, 0).Add(time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, event2); !errors.Is(err, storage.ErrDateBusy) {
+		t.Errorf("Expected ErrDateBusy, got %v", err)
+	}
+
+	// Fits between occurrences
+	event3 := &storage.Event{
+		Title:     "Lunch",
+		Username:  "testuser",
+		StartTime: start.AddDate(0, 1, 0).Add(3 * time.Hour),
+		EndTime:   start.AddDate(0, 1, 0).Add(4 * time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, event3); err != nil {
+		t.Errorf("CreateEvent between occurrences failed: %v", err)
+	}
+}
+
+func TestCreateEvent_AllDayConflict(t *testing.T) {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	birthday := storage.Event{
+		Title:     "Birthday",
+		Username:  "testuser",
+		StartTime: day,
+		EndTime:   day.AddDate(0, 0, 1),
+		AllDay:    true,
+	}
+	meeting := storage.Event{
+		Title:     "Meeting",
+		Username:  "testuser",
+		StartTime: day.Add(10 * time.Hour),
+		EndTime:   day.Add(11 * time.Hour),
+	}
+
+	s := New()
+	ctx := context.Background()
+	if _, err := s.CreateEvent(ctx, &birthday); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, &meeting); !errors.Is(err, storage.ErrDateBusy) {
+		t.Errorf("Expected ErrDateBusy, got %v", err)
+	}
+
+	s = New(storage.WithIgnoreAllDayConflicts(true))
+	if _, err := s.CreateEvent(ctx, &birthday); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, &meeting); err != nil {
+		t.Errorf("CreateEvent over all-day event failed: %v", err)
+	}
+}
+
+func TestCreateEvent_AllDayConflictInTimeZone(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	if err := s.CreateUser(ctx, &storage.User{Name: "testuser", TimeZone: "Europe/Moscow"}); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	holiday := &storage.Event{
+		Title:     "Holiday",
+		Username:  "testuser",
+		StartTime: day,
+		EndTime:   day.AddDate(0, 0, 1),
+		AllDay:    true,
+	}
+	if _, err := s.CreateEvent(ctx, holiday); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+
+	moscow, err := storage.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	// 01:00 MSK on January 6 is 22:00 UTC on January 5.
+	start := time.Date(2025, time.January, 6, 1, 0, 0, 0, moscow)
+	early := &storage.Event{
+		Title:     "Early meeting",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, early); !errors.Is(err, storage.ErrDateBusy) {
+		t.Errorf("Expected ErrDateBusy, got %v", err)
+	}
+	// 02:00 MSK on January 7 is 23:00 UTC on January 6.
+	start = time.Date(2025, time.January, 7, 2, 0, 0, 0, moscow)
+	next := &storage.Event{
+		Title:     "Meeting next day",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, next); err != nil {
+		t.Errorf("CreateEvent the day after failed: %v", err)
+	}
+}
+
+func TestListEvents_KeepsWallClockInTimeZone(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	if err := s.CreateUser(ctx, &storage.User{Name: "testuser", TimeZone: "Europe/Berlin"}); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	// 09:00 CET, the day before the switch to summer time.
+	start := time.Date(2025, time.March, 29, 8, 0, 0, 0, time.UTC)
+	event := &storage.Event{
+		Title:     "Stand-up",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     "FREQ=DAILY;COUNT=2",
+	}
+	id, err := s.CreateEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	stored, err := s.GetEvent(ctx, "testuser", id)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if stored.TimeZone != "Europe/Berlin" {
+		t.Errorf("Expected default time zone of the user, got %q", stored.TimeZone)
+	}
+
+	events, err := s.ListEvents(ctx, "testuser", start, start.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	// 09:00 CEST
+	want := time.Date(2025, time.March, 30, 7, 0, 0, 0, time.UTC)
+	if len(events) != 2 || !slices.ContainsFunc(events, func(event storage.Event) bool { return event.StartTime.Equal(want) }) {
+		t.Errorf("Expected the second occurrence at %s, got %+v", want, events)
+	}
+}
+
+func TestCreateEvent_Transparency(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.March, 14, 9, 0, 0, 0, time.UTC)
+	focus := &storage.Event{
+		Title:     "Focus",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(4 * time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, focus); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	webinar := &storage.Event{
+		Title:        "Webinar",
+		Username:     "testuser",
+		StartTime:    start.Add(time.Hour),
+		EndTime:      start.Add(2 * time.Hour),
+		Transparency: storage.TransparencyFree,
+	}
+	if _, err := s.CreateEvent(ctx, webinar); err != nil {
+		t.Errorf("CreateEvent of free event failed: %v", err)
+	}
+
+	meeting := &storage.Event{
+		Title:     "Meeting",
+		Username:  "testuser",
+		StartTime: start.Add(time.Hour),
+		EndTime:   start.Add(2 * time.Hour),
+	}
+	_, err := s.CreateEvent(ctx, meeting)
+	var conflictErr *storage.ConflictError
+	if !errors.As(err, &conflictErr) || !errors.Is(err, storage.ErrDateBusy) ||
+		len(conflictErr.IDs) != 1 || conflictErr.IDs[0] != focus.ID {
+		t.Errorf("Expected conflict with the busy event only, got %v", err)
+	}
+}
+
+func TestCreateEvent_ConflictPolicy(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+	if err := s.CreateUser(ctx, &storage.User{Name: "testuser", ConflictPolicy: storage.ConflictWarn}); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	start := time.Date(2025, time.March, 14, 9, 0, 0, 0, time.UTC)
+	event1 := &storage.Event{
+		Title:     "Event 1",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, event1); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	event2 := &storage.Event{
+		Title:     "Event 2",
+		Username:  "testuser",
+		StartTime: start.Add(30 * time.Minute),
+		EndTime:   start.Add(90 * time.Minute),
+	}
+	id, err := s.CreateEvent(ctx, event2)
+	if err != nil {
+		t.Fatalf("CreateEvent with warn policy failed: %v", err)
+	}
+	if len(event2.Conflicts) != 1 || event2.Conflicts[0] != event1.ID {
+		t.Errorf("Expected conflict with %s, got %v", event1.ID, event2.Conflicts)
+	}
+	stored, err := s.GetEvent(ctx, "testuser", id)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if stored.Conflicts != nil {
+		t.Errorf("Conflicts must not be stored, got %v", stored.Conflicts)
+	}
+
+	if err := s.UpdateUserSettings(ctx, &storage.User{Name: "testuser", ConflictPolicy: storage.ConflictAllow}); err != nil {
+		t.Fatalf("UpdateUserSettings failed: %v", err)
+	}
+	event2.Title = "Event 2, moved"
+	if err := s.UpdateEvent(ctx, "testuser", id, event2); err != nil || event2.Conflicts != nil {
+		t.Errorf("UpdateEvent with allow policy: %v, conflicts %v", err, event2.Conflicts)
+	}
+}
+
+func createDailySeries(t *testing.T, s *Storage, start time.Time, rule string) uuid.UUID {
+	t.Helper()
+	event := &storage.Event{
+		Title:     "Stand-up",
+		Username:  "testuser",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     rule,
+	}
+	id, err := s.CreateEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+
+	return id
+}
+
+func TestUpdateEventOccurrence_This(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	id := createDailySeries(t, s, start, "FREQ=DAILY;COUNT=5")
+
+	recurrenceID := start.AddDate(0, 0, 2)
+	moved := &storage.Event{
+		Title:     "Moved stand-up",
+		StartTime: recurrenceID.Add(2 * time.Hour),
+		EndTime:   recurrenceID.Add(2*time.Hour + 15*time.Minute),
+	}
+	if err := s.UpdateEventOccurrence(ctx, "testuser", id, recurrenceID, storage.ScopeThis, moved); err != nil {
+		t.Fatalf("UpdateEventOccurrence failed: %v", err)
+	}
+	// The override can be edited again through the series
+	moved.Title = "Moved again"
+	if err := s.UpdateEventOccurrence(ctx, "testuser", id, recurrenceID, storage.ScopeThis, moved); err != nil {
+		t.Fatalf("UpdateEventOccurrence of override failed: %v", err)
+	}
+
+	events, _ := s.ListEvents(ctx, "testuser", start, start.AddDate(0, 0, 5))
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 occurrences, got %d", len(events))
+	}
+	for _, event := range events {
+		if event.RecurrenceID == nil || !event.RecurrenceID.Equal(recurrenceID) {
+			continue
+		}
+		if event.Title != "Moved again" || !event.StartTime.Equal(moved.StartTime) {
+			t.Errorf("Occurrence not overridden: %+v", event)
+		}
+		if event.ParentID == nil || *event.ParentID != id {
+			t.Errorf("Override must refer to series")
+		}
+	}
+}
+
+func TestDeleteEventOccurrence_This(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	id := createDailySeries(t, s, start, "FREQ=DAILY;COUNT=5")
+
+	recurrenceID := start.AddDate(0, 0, 1)
+	if err := s.DeleteEventOccurrence(ctx, "testuser", id, recurrenceID, storage.ScopeThis); err != nil {
+		t.Fatalf("DeleteEventOccurrence failed: %v", err)
+	}
+	events, _ := s.ListEvents(ctx, "testuser", start, start.AddDate(0, 0, 5))
+	if len(events) != 4 {
+		t.Errorf("Expected 4 occurrences, got %d", len(events))
+	}
+
+	// The freed slot can be booked
+	event := &storage.Event{
+		Title:     "Dentist",
+		Username:  "testuser",
+		StartTime: recurrenceID,
+		EndTime:   recurrenceID.Add(time.Hour),
+	}
+	if _, err := s.CreateEvent(ctx, event); err != nil {
+		t.Errorf("CreateEvent in cancelled occurrence failed: %v", err)
+	}
+
+	err := s.DeleteEventOccurrence(ctx, "testuser", id, recurrenceID, storage.ScopeThis)
+	if !errors.Is(err, storage.ErrOccurrenceNotFound) {
+		t.Errorf("Expected ErrOccurrenceNotFound, got %v", err)
+	}
+}
+
+func TestDeleteEventOccurrence_Following(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	id := createDailySeries(t, s, start, "FREQ=DAILY")
+
+	if err := s.DeleteEventOccurrence(ctx, "testuser", id, start.AddDate(0, 0, 3), storage.ScopeFollowing); err != nil {
+		t.Fatalf("DeleteEventOccurrence failed: %v", err)
+	}
+	events, _ := s.ListEvents(ctx, "testuser", start, start.AddDate(0, 1, 0))
+	if len(events) != 3 {
+		t.Errorf("Expected 3 occurrences, got %d", len(events))
+	}
+}
+
+func TestUpdateEventOccurrence_Following(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	id := createDailySeries(t, s, start, "FREQ=DAILY;COUNT=10")
+
+	recurrenceID := start.AddDate(0, 0, 4)
+	later := &storage.Event{
+		Title:     "Late stand-up",
+		StartTime: recurrenceID.Add(time.Hour),
+		EndTime:   recurrenceID.Add(time.Hour + 15*time.Minute),
+	}
+	if err := s.UpdateEventOccurrence(ctx, "testuser", id, recurrenceID, storage.ScopeFollowing, later); err != nil {
+		t.Fatalf("UpdateEventOccurrence failed: %v", err)
+	}
+
+	events, _ := s.ListEvents(ctx, "testuser", start, start.AddDate(0, 1, 0))
+	if len(events) != 10 {
+		t.Fatalf("Expected 10 occurrences, got %d", len(events))
+	}
+	early, late := 0, 0
+	for _, event := range events {
+		switch event.ID {
+		case id:
+			early++
+		case later.ID:
+			late++
+		}
+	}
+	if early != 4 || late != 6 {
+		t.Errorf("Expected 4 + 6 occurrences, got %d + %d", early, late)
+	}
+}
+
+func TestAttendees(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	for _, user := range []storage.User{
+		{Name: "alice", Email: "alice@example.com"},
+		{Name: "bob", Email: "bob@example.com"},
+	} {
+		if err := s.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := &storage.Event{
+		Title:     "Planning",
+		Username:  "alice",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Attendees: []storage.Attendee{{Username: "bob"}, {Email: "carol@example.com"}, {Email: "bob@example.com"}},
+	}
+	id, err := s.CreateEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, &storage.Event{
+		Title:     "Unknown attendee",
+		Username:  "alice",
+		StartTime: start.Add(2 * time.Hour),
+		EndTime:   start.Add(3 * time.Hour),
+		Attendees: []storage.Attendee{{Username: "nobody"}},
+	}); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	events, _ := s.ListEvents(ctx, "bob", start, start.AddDate(0, 0, 1))
+	if len(events) != 1 || events[0].ID != id || events[0].Organizer != "alice" {
+		t.Fatalf("Expected the invitation from alice, got %+v", events)
+	}
+	want := []storage.Attendee{
+		{Username: "bob", Email: "bob@example.com", Status: storage.PartStatNeedsAction},
+		{Email: "carol@example.com", Status: storage.PartStatNeedsAction},
+	}
+	if !slices.Equal(events[0].Attendees, want) {
+		t.Errorf("Expected attendees %v, got %v", want, events[0].Attendees)
+	}
+
+	if err := s.UpdateAttendeeStatus(ctx, "bob", id, storage.PartStatAccepted); err != nil {
+		t.Fatalf("UpdateAttendeeStatus failed: %v", err)
+	}
+	if err := s.UpdateAttendeeStatus(ctx, "alice", id, storage.PartStatAccepted); !errors.Is(err, storage.ErrAttendeeNotFound) {
+		t.Errorf("Expected ErrAttendeeNotFound, got %v", err)
+	}
+
+	// Attendees are kept with their responses if not given.
+	renamed := &storage.Event{Title: "Quarter planning", StartTime: start, EndTime: start.Add(time.Hour)}
+	if err := s.UpdateEvent(ctx, "alice", id, renamed); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	got, err := s.GetEvent(ctx, "bob", id)
+	if err != nil {
+		t.Fatalf("GetEvent by attendee failed: %v", err)
+	}
+	if got.Attendee("bob").Status != storage.PartStatAccepted {
+		t.Errorf("Expected accepted after renaming, got %q", got.Attendee("bob").Status)
+	}
+
+	// Rescheduling asks the attendees again.
+	moved := &storage.Event{Title: "Quarter planning", StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)}
+	if err := s.UpdateEvent(ctx, "alice", id, moved); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	got, _ = s.GetEvent(ctx, "alice", id)
+	if got.Attendee("bob").Status != storage.PartStatNeedsAction || got.Organizer != "" {
+		t.Errorf("Expected needs-action after rescheduling, got %+v", got)
+	}
+}
+
+func TestInvitations(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	alice := storage.User{Name: "alice", Email: "alice@example.com"}
+	if err := s.CreateUser(ctx, &alice); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := &storage.Event{
+		Title:     "Standup",
+		Username:  "alice",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     "FREQ=DAILY",
+		Attendees: []storage.Attendee{{Email: "bob@example.com"}},
+	}
+	id, err := s.CreateEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	// Adding an attendee only invites them.
+	if err := s.UpdateEvent(ctx, "alice", id, &storage.Event{
+		Title:     "Standup",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     "FREQ=DAILY",
+		Attendees: []storage.Attendee{{Email: "bob@example.com"}, {Email: "carol@example.com"}},
+	}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	// Removing an occurrence cancels it for everyone.
+	recurrenceID := start.AddDate(0, 0, 1)
+	if err := s.DeleteEventOccurrence(ctx, "alice", id, recurrenceID, storage.ScopeThis); err != nil {
+		t.Fatalf("DeleteEventOccurrence failed: %v", err)
+	}
+	if err := s.DeleteEvent(ctx, "alice", id, 0); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+
+	invitations, err := s.ListInvitations(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListInvitations failed: %v", err)
+	}
+	type sent struct {
+		method     storage.Method
+		recipients []string
+		sequence   int
+	}
+	want := []sent{
+		{storage.MethodRequest, []string{"bob@example.com"}, 0},
+		{storage.MethodRequest, []string{"carol@example.com"}, 0},
+		{storage.MethodCancel, []string{"bob@example.com", "carol@example.com"}, 1},
+		{storage.MethodCancel, []string{"bob@example.com", "carol@example.com"}, 1},
+	}
+	if len(invitations) != len(want) {
+		t.Fatalf("Expected %d invitations, got %+v", len(want), invitations)
+	}
+	for i, invitation := range invitations {
+		got := sent{invitation.Method, invitation.Recipients, invitation.Event.Sequence}
+		if got.method != want[i].method || !slices.Equal(got.recipients, want[i].recipients) || got.sequence != want[i].sequence {
+			t.Errorf("Invitation %d: expected %v, got %v", i, want[i], got)
+		}
+		if invitation.Organizer != "alice@example.com" || invitation.Event.Organizer != "alice" {
+			t.Errorf("Invitation %d: unexpected organizer %q %q", i, invitation.Organizer, invitation.Event.Organizer)
+		}
+	}
+	if rid := invitations[2].Event.RecurrenceID; rid == nil || !rid.Equal(recurrenceID) {
+		t.Errorf("Expected the cancellation of the occurrence, got %v", rid)
+	}
+
+	if err := s.DeleteInvitation(ctx, invitations[0].ID); err != nil {
+		t.Fatalf("DeleteInvitation failed: %v", err)
+	}
+	if err := s.DeleteInvitation(ctx, invitations[0].ID); !errors.Is(err, storage.ErrInvitationNotFound) {
+		t.Errorf("Expected ErrInvitationNotFound, got %v", err)
+	}
+	if invitations, _ = s.ListInvitations(ctx, 10); len(invitations) != len(want)-1 {
+		t.Errorf("Expected %d invitations after delete, got %d", len(want)-1, len(invitations))
+	}
+}
+
+func TestApplyReply(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	event := &storage.Event{
+		Title:     "Standup",
+		Username:  "alice",
+		StartTime: start,
+		EndTime:   start.Add(15 * time.Minute),
+		RRule:     "FREQ=DAILY",
+		Attendees: []storage.Attendee{{Email: "bob@example.com"}},
+	}
+	id, err := s.CreateEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	// The override of the second occurrence is a new revision of it.
+	recurrenceID := start.AddDate(0, 0, 1)
+	if err := s.UpdateEventOccurrence(ctx, "alice", id, recurrenceID, storage.ScopeThis, &storage.Event{
+		Title:     "Standup",
+		StartTime: recurrenceID.Add(time.Hour),
+		EndTime:   recurrenceID.Add(time.Hour + 15*time.Minute),
+	}); err != nil {
+		t.Fatalf("UpdateEventOccurrence failed: %v", err)
+	}
+
+	uid := id.String()
+	tests := []struct {
+		name  string
+		reply storage.Reply
+		err   error
+	}{
+		{"series", storage.Reply{UID: uid, Email: "BOB@example.com", Status: storage.PartStatAccepted}, nil},
+		{"outdated occurrence", storage.Reply{UID: uid, RecurrenceID: &recurrenceID, Email: "bob@example.com", Status: storage.PartStatDeclined}, storage.ErrOutdatedReply},
+		{"occurrence", storage.Reply{UID: uid, RecurrenceID: &recurrenceID, Email: "bob@example.com", Status: storage.PartStatDeclined, Sequence: 1}, nil},
+		{"unknown attendee", storage.Reply{UID: uid, Email: "carol@example.com", Status: storage.PartStatAccepted}, storage.ErrAttendeeNotFound},
+		{"unknown event", storage.Reply{UID: "other", Email: "bob@example.com", Status: storage.PartStatAccepted}, storage.ErrEventNotFound},
+	}
+	for _, test := range tests {
+		if err := s.ApplyReply(ctx, &test.reply); !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	events, _ := s.ListEvents(ctx, "alice", start, start.AddDate(0, 0, 2))
+	statuses := make(map[time.Time]storage.PartStat)
+	for _, event := range events {
+		statuses[*event.RecurrenceID] = event.Attendees[0].Status
+	}
+	if statuses[start] != storage.PartStatAccepted || statuses[recurrenceID] != storage.PartStatDeclined {
+		t.Errorf("Expected the series accepted and the occurrence declined, got %v", statuses)
+	}
+}
+
+func TestDeleteExpiredEvents(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	if err := s.CreateUser(ctx, &storage.User{Name: "carol", RetentionDays: 10}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	create := func(username string, start time.Time, rule string) uuid.UUID {
+		t.Helper()
+		event := storage.Event{
+			Title:     "Event",
+			Username:  username,
+			StartTime: start,
+			EndTime:   start.Add(30 * time.Minute),
+			RRule:     rule,
+		}
+		id, err := s.CreateEvent(ctx, &event)
+		if err != nil {
+			t.Fatalf("CreateEvent: %v", err)
+		}
+		return id
+	}
+	day := func(days, hour int) time.Time {
+		return now.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+	}
+
+	old := create("bob", day(-40, 10), "")
+	recent := create("bob", day(-10, 10), "")
+	forever := create("bob", day(-100, 6), "FREQ=DAILY")
+	moved := create("bob", day(-60, 12), "FREQ=DAILY;COUNT=3")
+	carols := create("carol", day(-20, 10), "")
+	override := storage.Event{
+		Title:     "Moved",
+		StartTime: day(-5, 14),
+		EndTime:   day(-5, 14).Add(30 * time.Minute),
+	}
+	if err := s.UpdateEventOccurrence(ctx, "bob", moved, day(-60, 12), storage.ScopeThis, &override); err != nil {
+		t.Fatalf("UpdateEventOccurrence: %v", err)
+	}
+
+	if n, err := s.DeleteExpiredEvents(ctx, now, 30, 1); err != nil || n != 1 {
+		t.Fatalf("expected 1 deleted event in the first batch, got %d: %v", n, err)
+	}
+	if n, err := s.DeleteExpiredEvents(ctx, now, 30, 10); err != nil || n != 1 {
+		t.Fatalf("expected 1 deleted event in the second batch, got %d: %v", n, err)
+	}
+	if n, err := s.DeleteExpiredEvents(ctx, now, 30, 10); err != nil || n != 0 {
+		t.Fatalf("expected no expired events left, got %d: %v", n, err)
+	}
+
+	for _, id := range []uuid.UUID{old, carols} {
+		if _, err := s.GetEventOwner(ctx, id); !errors.Is(err, storage.ErrEventNotFound) {
+			t.Errorf("expected expired event %s deleted, got %v", id, err)
+		}
+	}
+	for _, id := range []uuid.UUID{recent, forever, moved} {
+		if _, err := s.GetEventOwner(ctx, id); err != nil {
+			t.Errorf("expected event %s kept: %v", id, err)
+		}
+	}
+	if n, err := s.DeleteExpiredEvents(ctx, now, 0, 10); err != nil || n != 0 {
+		t.Errorf("expected events kept forever without retention, got %d deleted: %v", n, err)
+	}
+}
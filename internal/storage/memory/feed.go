@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateFeed(_ context.Context, feed *storage.Feed) (uuid.UUID, error) {
+	feed.ID = uuid.New()
+	feed.CreatedAt = time.Now()
+
+	s.muFeeds.Lock()
+	s.mFeeds[feed.ID] = *feed
+	s.muFeeds.Unlock()
+
+	return feed.ID, nil
+}
+
+func (s *Storage) GetFeedByTokenHash(_ context.Context, tokenHash string) (*storage.Feed, error) {
+	s.muFeeds.RLock()
+	defer s.muFeeds.RUnlock()
+	for _, feed := range s.mFeeds {
+		if feed.TokenHash == tokenHash {
+			return &feed, nil
+		}
+	}
+
+	return nil, storage.ErrFeedNotFound
+}
+
+func (s *Storage) ListFeeds(_ context.Context, username string) ([]storage.Feed, error) {
+	feeds := make([]storage.Feed, 0)
+
+	s.muFeeds.RLock()
+	for _, feed := range s.mFeeds {
+		if feed.Username == username {
+			feeds = append(feeds, feed)
+		}
+	}
+	s.muFeeds.RUnlock()
+	slices.SortFunc(feeds, func(a, b storage.Feed) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return feeds, nil
+}
+
+func (s *Storage) UpdateFeedToken(_ context.Context, username string, id uuid.UUID, tokenHash string) error {
+	s.muFeeds.Lock()
+	defer s.muFeeds.Unlock()
+
+	feed, ok := s.mFeeds[id]
+	if !ok || feed.Username != username {
+		return fmt.Errorf("%w: %s", storage.ErrFeedNotFound, id)
+	}
+	feed.TokenHash = tokenHash
+	s.mFeeds[id] = feed
+
+	return nil
+}
+
+func (s *Storage) DeleteFeed(_ context.Context, username string, id uuid.UUID) error {
+	s.muFeeds.Lock()
+	defer s.muFeeds.Unlock()
+
+	if feed, ok := s.mFeeds[id]; !ok || feed.Username != username {
+		return fmt.Errorf("%w: %s", storage.ErrFeedNotFound, id)
+	}
+	delete(s.mFeeds, id)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+type grantKey struct {
+	owner   string
+	grantee string
+}
+
+func (s *Storage) SetGrant(_ context.Context, grant *storage.Grant) error {
+	s.muUsers.Lock()
+	defer s.muUsers.Unlock()
+
+	if _, ok := s.mUsers[grant.Owner]; !ok {
+		return fmt.Errorf("%w: %q", storage.ErrUserNotFound, grant.Owner)
+	}
+	if _, ok := s.mUsers[grant.Grantee]; !ok {
+		return fmt.Errorf("grantee: %w: %q", storage.ErrUserNotFound, grant.Grantee)
+	}
+	key := grantKey{owner: grant.Owner, grantee: grant.Grantee}
+	if old, ok := s.mGrants[key]; ok {
+		grant.CreatedAt = old.CreatedAt
+	} else {
+		grant.CreatedAt = time.Now()
+	}
+	s.mGrants[key] = *grant
+
+	return nil
+}
+
+func (s *Storage) GetGrant(_ context.Context, owner, grantee string) (*storage.Grant, error) {
+	s.muUsers.RLock()
+	defer s.muUsers.RUnlock()
+
+	grant, ok := s.mGrants[grantKey{owner: owner, grantee: grantee}]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q to %q", storage.ErrGrantNotFound, owner, grantee)
+	}
+
+	return &grant, nil
+}
+
+func (s *Storage) ListGrants(_ context.Context, owner string) ([]storage.Grant, error) {
+	return s.listGrants(func(key grantKey) bool { return key.owner == owner }), nil
+}
+
+func (s *Storage) ListGrantsTo(_ context.Context, grantee string) ([]storage.Grant, error) {
+	return s.listGrants(func(key grantKey) bool { return key.grantee == grantee }), nil
+}
+
+func (s *Storage) listGrants(filter func(key grantKey) bool) []storage.Grant {
+	s.muUsers.RLock()
+	defer s.muUsers.RUnlock()
+
+	grants := make([]storage.Grant, 0)
+	for key, grant := range s.mGrants {
+		if filter(key) {
+			grants = append(grants, grant)
+		}
+	}
+	slices.SortFunc(grants, func(a, b storage.Grant) int {
+		return cmp.Or(cmp.Compare(a.Owner, b.Owner), cmp.Compare(a.Grantee, b.Grantee))
+	})
+
+	return grants
+}
+
+func (s *Storage) DeleteGrant(_ context.Context, owner, grantee string) error {
+	s.muUsers.Lock()
+	defer s.muUsers.Unlock()
+
+	key := grantKey{owner: owner, grantee: grantee}
+	if _, ok := s.mGrants[key]; !ok {
+		return fmt.Errorf("%w: %q to %q", storage.ErrGrantNotFound, owner, grantee)
+	}
+	delete(s.mGrants, key)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) ListInvitations(_ context.Context, limit int) ([]storage.Invitation, error) {
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+
+	return slices.Clone(s.invitations[:min(limit, len(s.invitations))]), nil
+}
+
+func (s *Storage) DeleteInvitation(_ context.Context, id uuid.UUID) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	i := slices.IndexFunc(s.invitations, func(invitation storage.Invitation) bool { return invitation.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", storage.ErrInvitationNotFound, id)
+	}
+	s.invitations = slices.Delete(s.invitations, i, i+1)
+
+	return nil
+}
@@ -10,15 +10,49 @@ import (
 type Storage struct {
 	mUsers  map[string]storage.User
 	muUsers sync.RWMutex
+	// mGrants are guarded by muUsers, they are deleted with the users.
+	mGrants map[grantKey]storage.Grant
 
 	mEvents  map[uuid.UUID]storage.Event
 	muEvents sync.RWMutex
+	// invitations are guarded by muEvents to be queued with the changes.
+	invitations []storage.Invitation
+	// mCalendars are guarded by muEvents, the events refer to them.
+	mCalendars map[uuid.UUID]storage.Calendar
+	// mResources are guarded by muEvents, the events reserve them.
+	mResources map[uuid.UUID]storage.Resource
+	// mPolls are guarded by muEvents, finalizing creates an event.
+	mPolls map[uuid.UUID]storage.Poll
+	// mSchedules are the work schedules of the users, guarded by muEvents
+	// to be checked on inviting the users.
+	mSchedules map[string]storage.WorkSchedule
+
+	mFeeds  map[uuid.UUID]storage.Feed
+	muFeeds sync.RWMutex
+
+	mBookingLinks  map[uuid.UUID]storage.BookingLink
+	muBookingLinks sync.RWMutex
+
+	// mNotifications are the reminders recorded by the scheduler.
+	mNotifications  map[notificationKey]storage.Notification
+	muNotifications sync.Mutex
+
+	opts storage.Options
 }
 
-func New() *Storage {
+func New(opts ...storage.Option) *Storage {
 	var s Storage
+	s.opts = storage.NewOptions(opts...)
 	s.mUsers = make(map[string]storage.User)
 	s.mEvents = make(map[uuid.UUID]storage.Event)
+	s.mFeeds = make(map[uuid.UUID]storage.Feed)
+	s.mCalendars = make(map[uuid.UUID]storage.Calendar)
+	s.mGrants = make(map[grantKey]storage.Grant)
+	s.mResources = make(map[uuid.UUID]storage.Resource)
+	s.mBookingLinks = make(map[uuid.UUID]storage.BookingLink)
+	s.mPolls = make(map[uuid.UUID]storage.Poll)
+	s.mSchedules = make(map[string]storage.WorkSchedule)
+	s.mNotifications = make(map[notificationKey]storage.Notification)
 
 	return &s
 }
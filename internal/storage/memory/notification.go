@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// notificationKey identifies a reminder of an occurrence.
+type notificationKey struct {
+	eventID   uuid.UUID
+	startTime int64
+	offset    time.Duration
+	channel   storage.Channel
+}
+
+func keyOf(notification *storage.Notification) notificationKey {
+	return notificationKey{
+		eventID:   notification.Event.ID,
+		startTime: notification.Event.StartTime.UnixNano(),
+		offset:    notification.Reminder.Offset,
+		channel:   notification.Reminder.Channel,
+	}
+}
+
+func (s *Storage) ListEventsToNotify(_ context.Context, start, end time.Time) ([]storage.Notification, error) {
+	s.muEvents.RLock()
+	var notifications []storage.Notification
+	for _, event := range s.mEvents {
+		eventNotifications, err := event.Notifications(start, end)
+		if err != nil {
+			s.muEvents.RUnlock()
+			return nil, fmt.Errorf("list events to notify: %w", err)
+		}
+		notifications = append(notifications, eventNotifications...)
+	}
+	s.muEvents.RUnlock()
+
+	for i := range notifications {
+		user := s.userSettings(notifications[i].Event.Username)
+		notifications[i].Email = user.Email
+		notifications[i].WebhookURL = user.WebhookURL
+	}
+	slices.SortStableFunc(notifications, func(a, b storage.Notification) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return notifications, nil
+}
+
+// RecordNotification stores the notification as pending unless it is already
+// stored, and sets its ID and status to the stored ones.
+func (s *Storage) RecordNotification(_ context.Context, notification *storage.Notification) error {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	key := keyOf(notification)
+	if stored, ok := s.mNotifications[key]; ok {
+		notification.ID = stored.ID
+		notification.Status = stored.Status
+		return nil
+	}
+	notification.ID = uuid.New()
+	notification.Status = storage.NotificationPending
+	s.mNotifications[key] = *notification
+
+	return nil
+}
+
+func (s *Storage) MarkNotificationSent(_ context.Context, id uuid.UUID) error {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	for key, notification := range s.mNotifications {
+		if notification.ID == id {
+			notification.Status = storage.NotificationSent
+			s.mNotifications[key] = notification
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", storage.ErrNotificationNotFound, id)
+}
+
+// NotificationDelivered reports whether the message of the notification was
+// delivered, false if the notification is not stored.
+func (s *Storage) NotificationDelivered(_ context.Context, id uuid.UUID) (bool, error) {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	for _, notification := range s.mNotifications {
+		if notification.ID == id {
+			return !notification.DeliveredAt.IsZero(), nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Storage) MarkNotificationDelivered(_ context.Context, id uuid.UUID) error {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	for key, notification := range s.mNotifications {
+		if notification.ID == id {
+			if notification.DeliveredAt.IsZero() {
+				notification.DeliveredAt = time.Now()
+				s.mNotifications[key] = notification
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", storage.ErrNotificationNotFound, id)
+}
+
+// NotifiedUntil returns the notify time of the earliest pending notification
+// or the time of the latest recorded one, nil if none are recorded.
+func (s *Storage) NotifiedUntil(_ context.Context) (*time.Time, error) {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	var pending, latest *time.Time
+	for _, notification := range s.mNotifications {
+		if notification.Status == storage.NotificationPending && (pending == nil || notification.Time.Before(*pending)) {
+			pending = &notification.Time
+		}
+		if latest == nil || notification.CreatedAt.After(*latest) {
+			latest = &notification.CreatedAt
+		}
+	}
+	if pending != nil && pending.Before(*latest) {
+		return pending, nil
+	}
+
+	return latest, nil
+}
+
+// DeleteSentNotifications deletes the sent notifications due before the
+// time but the latest recorded one.
+func (s *Storage) DeleteSentNotifications(_ context.Context, before time.Time) (int, error) {
+	s.muNotifications.Lock()
+	defer s.muNotifications.Unlock()
+
+	var latest time.Time
+	for _, notification := range s.mNotifications {
+		if notification.CreatedAt.After(latest) {
+			latest = notification.CreatedAt
+		}
+	}
+	deleted := 0
+	for key, notification := range s.mNotifications {
+		if notification.Status == storage.NotificationSent && notification.Time.Before(before) &&
+			notification.CreatedAt.Before(latest) {
+			delete(s.mNotifications, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreatePoll(_ context.Context, poll *storage.Poll) (uuid.UUID, error) {
+	poll.ID = uuid.New()
+	poll.CreatedAt = time.Now()
+	poll.Votes = nil
+	poll.FinalOptionID = nil
+	poll.EventID = nil
+	for i := range poll.Options {
+		poll.Options[i].ID = uuid.New()
+		poll.Options[i].PollID = poll.ID
+	}
+	sortPollOptions(poll.Options)
+
+	s.muUsers.RLock()
+	for _, invitee := range poll.Invitees {
+		if _, ok := s.mUsers[invitee]; !ok {
+			s.muUsers.RUnlock()
+			return uuid.Nil, fmt.Errorf("invitee: %w: %q", storage.ErrUserNotFound, invitee)
+		}
+	}
+	s.muUsers.RUnlock()
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	s.storePoll(poll)
+
+	return poll.ID, nil
+}
+
+func (s *Storage) GetPoll(_ context.Context, username string, id uuid.UUID) (*storage.Poll, error) {
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+
+	poll, ok := s.mPolls[id]
+	if !ok || !poll.IsVisibleTo(username) {
+		return nil, fmt.Errorf("%w: %s", storage.ErrPollNotFound, id)
+	}
+
+	return clonePoll(&poll), nil
+}
+
+func (s *Storage) ListPolls(_ context.Context, username string) ([]storage.Poll, error) {
+	polls := make([]storage.Poll, 0)
+
+	s.muEvents.RLock()
+	for _, poll := range s.mPolls {
+		if poll.IsVisibleTo(username) {
+			poll := clonePoll(&poll)
+			poll.Votes = nil
+			polls = append(polls, *poll)
+		}
+	}
+	s.muEvents.RUnlock()
+	slices.SortFunc(polls, func(a, b storage.Poll) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return polls, nil
+}
+
+func (s *Storage) DeletePoll(_ context.Context, username string, id uuid.UUID) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	if poll, ok := s.mPolls[id]; !ok || poll.Username != username {
+		return fmt.Errorf("%w: %s", storage.ErrPollNotFound, id)
+	}
+	delete(s.mPolls, id)
+
+	return nil
+}
+
+func (s *Storage) SetVotes(_ context.Context, username string, id uuid.UUID, votes []storage.PollVote) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	poll, ok := s.mPolls[id]
+	if !ok || !slices.Contains(poll.Invitees, username) {
+		return fmt.Errorf("%w: %s", storage.ErrPollNotFound, id)
+	}
+	if poll.IsFinalized() {
+		return fmt.Errorf("%w: %s", storage.ErrPollFinalized, id)
+	}
+	for _, vote := range votes {
+		if poll.Option(vote.OptionID) == nil {
+			return fmt.Errorf("%w: %s", storage.ErrPollOptionNotFound, vote.OptionID)
+		}
+	}
+
+	poll.Votes = slices.DeleteFunc(slices.Clone(poll.Votes), func(vote storage.PollVote) bool {
+		return vote.Username == username
+	})
+	for _, vote := range votes {
+		vote.Username = username
+		poll.Votes = append(poll.Votes, vote)
+	}
+	s.storePoll(&poll)
+
+	return nil
+}
+
+func (s *Storage) FinalizePoll(_ context.Context, username string, id, optionID uuid.UUID, event *storage.Event) (uuid.UUID, error) {
+	user, err := s.prepareEvent(event)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	poll, ok := s.mPolls[id]
+	if !ok || poll.Username != username {
+		return uuid.Nil, fmt.Errorf("%w: %s", storage.ErrPollNotFound, id)
+	}
+	if poll.IsFinalized() {
+		return uuid.Nil, fmt.Errorf("%w: %s", storage.ErrPollFinalized, id)
+	}
+	if poll.Option(optionID) == nil {
+		return uuid.Nil, fmt.Errorf("%w: %s", storage.ErrPollOptionNotFound, optionID)
+	}
+	if err := s.insertEvent(user, event, nil); err != nil {
+		return uuid.Nil, err
+	}
+	poll.FinalOptionID = &optionID
+	poll.EventID = &event.ID
+	s.storePoll(&poll)
+
+	return event.ID, nil
+}
+
+// storePoll saves a copy of the poll with its votes in order. Must be called
+// with muEvents held.
+func (s *Storage) storePoll(poll *storage.Poll) {
+	stored := clonePoll(poll)
+	slices.SortStableFunc(stored.Votes, func(a, b storage.PollVote) int {
+		if c := strings.Compare(a.Username, b.Username); c != 0 {
+			return c
+		}
+		return slices.IndexFunc(stored.Options, func(o storage.PollOption) bool { return o.ID == a.OptionID }) -
+			slices.IndexFunc(stored.Options, func(o storage.PollOption) bool { return o.ID == b.OptionID })
+	})
+	s.mPolls[stored.ID] = *stored
+}
+
+func clonePoll(poll *storage.Poll) *storage.Poll {
+	cloned := *poll
+	cloned.Options = slices.Clone(poll.Options)
+	cloned.Invitees = slices.Clone(poll.Invitees)
+	cloned.Votes = slices.Clone(poll.Votes)
+
+	return &cloned
+}
+
+func sortPollOptions(options []storage.PollOption) {
+	slices.SortStableFunc(options, func(a, b storage.PollOption) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+}
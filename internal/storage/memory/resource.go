@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateResource(_ context.Context, resource *storage.Resource) (uuid.UUID, error) {
+	resource.ID = uuid.New()
+	resource.CreatedAt = time.Now()
+
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	if err := s.checkResourceName(resource); err != nil {
+		return uuid.Nil, err
+	}
+	s.storeResource(resource)
+
+	return resource.ID, nil
+}
+
+func (s *Storage) GetResource(_ context.Context, id uuid.UUID) (*storage.Resource, error) {
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+
+	resource, ok := s.mResources[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", storage.ErrResourceNotFound, id)
+	}
+
+	return &resource, nil
+}
+
+func (s *Storage) ListResources(_ context.Context, kind string, minCapacity int) ([]storage.Resource, error) {
+	resources := make([]storage.Resource, 0)
+
+	s.muEvents.RLock()
+	defer s.muEvents.RUnlock()
+	for _, resource := range s.mResources {
+		if (kind == "" || resource.Kind == kind) && resource.Capacity >= minCapacity {
+			resources = append(resources, resource)
+		}
+	}
+	slices.SortFunc(resources, func(a, b storage.Resource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return resources, nil
+}
+
+func (s *Storage) UpdateResource(_ context.Context, id uuid.UUID, resource *storage.Resource) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	oldResource, ok := s.mResources[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", storage.ErrResourceNotFound, id)
+	}
+	resource.ID = id
+	resource.CreatedAt = oldResource.CreatedAt
+	if err := s.checkResourceName(resource); err != nil {
+		return err
+	}
+	s.storeResource(resource)
+
+	return nil
+}
+
+func (s *Storage) DeleteResource(_ context.Context, id uuid.UUID) error {
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	if _, ok := s.mResources[id]; !ok {
+		return fmt.Errorf("%w: %s", storage.ErrResourceNotFound, id)
+	}
+	delete(s.mResources, id)
+	for eventID, event := range s.mEvents {
+		if slices.Contains(event.Resources, id) {
+			event.Resources = slices.DeleteFunc(slices.Clone(event.Resources), func(resourceID uuid.UUID) bool {
+				return resourceID == id
+			})
+			s.mEvents[eventID] = event
+		}
+	}
+
+	return nil
+}
+
+// checkResourceName returns storage.ErrResourceExists if another resource
+// has the name of the resource. Must be called with muEvents held.
+func (s *Storage) checkResourceName(resource *storage.Resource) error {
+	for _, other := range s.mResources {
+		if other.ID != resource.ID && other.Name == resource.Name {
+			return fmt.Errorf("%w: %q", storage.ErrResourceExists, resource.Name)
+		}
+	}
+
+	return nil
+}
+
+// storeResource saves a copy of the resource. Must be called with muEvents held.
+func (s *Storage) storeResource(resource *storage.Resource) {
+	stored := *resource
+	stored.Attributes = maps.Clone(resource.Attributes)
+	s.mResources[stored.ID] = stored
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func TestResources(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	room := storage.Resource{Name: "Everest", Kind: "room", Capacity: 8, Attributes: map[string]string{"floor": "3"}}
+	roomID, err := s.CreateResource(ctx, &room)
+	if err != nil {
+		t.Fatalf("CreateResource failed: %v", err)
+	}
+	if _, err := s.CreateResource(ctx, &storage.Resource{Name: "Everest", Kind: "room"}); !errors.Is(err, storage.ErrResourceExists) {
+		t.Errorf("Expected ErrResourceExists, got %v", err)
+	}
+	projectorID, err := s.CreateResource(ctx, &storage.Resource{Name: "Projector 1", Kind: "projector"})
+	if err != nil {
+		t.Fatalf("CreateResource failed: %v", err)
+	}
+	rooms, err := s.ListResources(ctx, "room", 6)
+	if err != nil || len(rooms) != 1 || rooms[0].ID != roomID {
+		t.Errorf("ListResources: expected the room, got %v (%v)", rooms, err)
+	}
+
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	newEvent := func(username string, offset time.Duration, resources ...uuid.UUID) *storage.Event {
+		return &storage.Event{
+			Title:     "Meeting",
+			Username:  username,
+			StartTime: start.Add(offset),
+			EndTime:   start.Add(offset + time.Hour),
+			Resources: resources,
+		}
+	}
+	planning := newEvent("alice", 0, roomID, projectorID, roomID)
+	planningID, err := s.CreateEvent(ctx, planning)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if len(planning.Resources) != 2 {
+		t.Errorf("Expected the resources without duplicates, got %v", planning.Resources)
+	}
+
+	// Neither another user nor a free event may take the booked room.
+	overlapping := newEvent("bob", 30*time.Minute, roomID)
+	overlapping.Transparency = storage.TransparencyFree
+	_, err = s.CreateEvent(ctx, overlapping)
+	var busyErr *storage.ResourceBusyError
+	if !errors.As(err, &busyErr) || busyErr.ResourceID != roomID || len(busyErr.IDs) != 1 || busyErr.IDs[0] != planningID {
+		t.Errorf("Expected the room booked by %s, got %v", planningID, err)
+	}
+	if _, err := s.CreateEvent(ctx, newEvent("bob", time.Hour, roomID)); err != nil {
+		t.Errorf("CreateEvent right after the booking failed: %v", err)
+	}
+	if _, err := s.CreateEvent(ctx, newEvent("bob", 0, uuid.New())); !errors.Is(err, storage.ErrResourceNotFound) {
+		t.Errorf("Expected ErrResourceNotFound, got %v", err)
+	}
+
+	// A daily series cannot take the room already booked on its third day.
+	thirdDay := newEvent("carol", 48*time.Hour, roomID)
+	if _, err := s.CreateEvent(ctx, thirdDay); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	daily := newEvent("alice", 24*time.Hour, roomID)
+	daily.RRule = "FREQ=DAILY;COUNT=5"
+	if _, err := s.CreateEvent(ctx, daily); !errors.Is(err, storage.ErrResourceBusy) {
+		t.Errorf("Expected ErrResourceBusy for the series, got %v", err)
+	}
+
+	// An update keeps the resources unless they are given.
+	update := newEvent("alice", -15*time.Minute)
+	if err := s.UpdateEvent(ctx, "alice", planningID, update); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	if len(update.Resources) != 2 {
+		t.Errorf("Expected the resources kept, got %v", update.Resources)
+	}
+
+	if err := s.DeleteResource(ctx, roomID); err != nil {
+		t.Fatalf("DeleteResource failed: %v", err)
+	}
+	event, err := s.GetEvent(ctx, "alice", planningID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if len(event.Resources) != 1 || event.Resources[0] != projectorID {
+		t.Errorf("Expected the reservation of the deleted room cancelled, got %v", event.Resources)
+	}
+}
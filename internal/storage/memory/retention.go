@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) DeleteExpiredEvents(_ context.Context, now time.Time, retentionDays, limit int) (int, error) {
+	s.muUsers.RLock()
+	defer s.muUsers.RUnlock()
+	s.muEvents.Lock()
+	defer s.muEvents.Unlock()
+
+	// An occurrence may be moved past the end of its series.
+	overridesEnd := make(map[uuid.UUID]time.Time)
+	for _, event := range s.mEvents {
+		if event.ParentID != nil && event.EndTime.After(overridesEnd[*event.ParentID]) {
+			overridesEnd[*event.ParentID] = event.EndTime
+		}
+	}
+
+	expired := make(map[uuid.UUID]bool)
+	for id, event := range s.mEvents {
+		if len(expired) == limit {
+			break
+		}
+		if event.ParentID != nil {
+			continue
+		}
+		days := retentionDays
+		if user := s.mUsers[event.Username]; user.RetentionDays > 0 {
+			days = user.RetentionDays
+		}
+		if days == 0 {
+			continue
+		}
+		seriesEnd, ok, err := event.SeriesEnd()
+		if err != nil {
+			return 0, fmt.Errorf("delete expired events: %w", err)
+		}
+		if overridesEnd[id].After(seriesEnd) {
+			seriesEnd = overridesEnd[id]
+		}
+		if ok && seriesEnd.Before(now.AddDate(0, 0, -days)) {
+			expired[id] = true
+		}
+	}
+	for id, event := range s.mEvents {
+		if expired[id] || (event.ParentID != nil && expired[*event.ParentID]) {
+			delete(s.mEvents, id)
+		}
+	}
+
+	return len(expired), nil
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/mrvin/calendar/internal/storage"
 )
@@ -15,7 +16,12 @@ func (s *Storage) CreateUser(_ context.Context, user *storage.User) error {
 	if ok {
 		return fmt.Errorf("%w: %q", storage.ErrUserExists, user.Name)
 	}
-	s.mUsers[user.Name] = *user
+	stored := *user
+	stored.WorkSchedule = storage.WorkSchedule{} //nolint:exhaustruct
+	s.mUsers[user.Name] = stored
+	s.muEvents.Lock()
+	s.mSchedules[user.Name] = cloneWorkSchedule(&user.WorkSchedule)
+	s.muEvents.Unlock()
 
 	return nil
 }
@@ -28,10 +34,69 @@ func (s *Storage) GetUser(_ context.Context, name string) (*storage.User, error)
 	if !ok {
 		return nil, fmt.Errorf("%w: %q", storage.ErrUserNotFound, name)
 	}
+	s.muEvents.RLock()
+	ws := s.mSchedules[name]
+	user.WorkSchedule = cloneWorkSchedule(&ws)
+	s.muEvents.RUnlock()
 
 	return &user, nil
 }
 
+func (s *Storage) UpdateUserSettings(_ context.Context, user *storage.User) error {
+	s.muUsers.Lock()
+	defer s.muUsers.Unlock()
+
+	oldUser, ok := s.mUsers[user.Name]
+	if !ok {
+		return fmt.Errorf("%w: %q", storage.ErrUserNotFound, user.Name)
+	}
+	oldUser.TimeZone = user.TimeZone
+	oldUser.ConflictPolicy = user.ConflictPolicy
+	oldUser.ShareFreeBusy = user.ShareFreeBusy
+	oldUser.RetentionDays = user.RetentionDays
+	oldUser.WebhookURL = user.WebhookURL
+	s.mUsers[user.Name] = oldUser
+	s.muEvents.Lock()
+	s.mSchedules[user.Name] = cloneWorkSchedule(&user.WorkSchedule)
+	s.muEvents.Unlock()
+
+	return nil
+}
+
+// userSettings returns the settings of the user applied to the events,
+// the defaults if the user does not exist.
+func (s *Storage) userSettings(name string) storage.User {
+	s.muUsers.RLock()
+	defer s.muUsers.RUnlock()
+
+	return s.mUsers[name]
+}
+
+// resolveAttendees returns the attendees with the emails of those given by
+// username, storage.ErrUserNotFound if there is no such user.
+func (s *Storage) resolveAttendees(attendees []storage.Attendee) ([]storage.Attendee, error) {
+	if attendees == nil {
+		return nil, nil
+	}
+
+	s.muUsers.RLock()
+	defer s.muUsers.RUnlock()
+
+	resolved := make([]storage.Attendee, len(attendees))
+	for i, attendee := range attendees {
+		if attendee.Username != "" {
+			user, ok := s.mUsers[attendee.Username]
+			if !ok {
+				return nil, fmt.Errorf("attendee: %w: %q", storage.ErrUserNotFound, attendee.Username)
+			}
+			attendee.Email = user.Email
+		}
+		resolved[i] = attendee
+	}
+
+	return resolved, nil
+}
+
 func (s *Storage) DeleteUser(_ context.Context, name string) error {
 	s.muUsers.Lock()
 	defer s.muUsers.Unlock()
@@ -41,13 +106,87 @@ func (s *Storage) DeleteUser(_ context.Context, name string) error {
 	}
 	s.muEvents.Lock()
 	for id, event := range s.mEvents {
-		if event.Username == name {
+		switch {
+		case event.Username == name:
 			delete(s.mEvents, id)
+		case event.Attendee(name) != nil:
+			event.Attendees = slices.DeleteFunc(slices.Clone(event.Attendees), func(attendee storage.Attendee) bool {
+				return attendee.Username == name
+			})
+			s.mEvents[id] = event
 		}
 	}
+	for id, calendar := range s.mCalendars {
+		if calendar.Username == name {
+			delete(s.mCalendars, id)
+		}
+	}
+	for id, poll := range s.mPolls {
+		switch {
+		case poll.Username == name:
+			delete(s.mPolls, id)
+		case slices.Contains(poll.Invitees, name):
+			poll.Invitees = slices.DeleteFunc(slices.Clone(poll.Invitees), func(invitee string) bool {
+				return invitee == name
+			})
+			poll.Votes = slices.DeleteFunc(slices.Clone(poll.Votes), func(vote storage.PollVote) bool {
+				return vote.Username == name
+			})
+			s.mPolls[id] = poll
+		}
+	}
+	delete(s.mSchedules, name)
 	s.muEvents.Unlock()
+	s.muFeeds.Lock()
+	for id, feed := range s.mFeeds {
+		if feed.Username == name {
+			delete(s.mFeeds, id)
+		}
+	}
+	s.muFeeds.Unlock()
+	s.muBookingLinks.Lock()
+	for id, link := range s.mBookingLinks {
+		if link.Username == name {
+			delete(s.mBookingLinks, id)
+		}
+	}
+	s.muBookingLinks.Unlock()
+	for key := range s.mGrants {
+		if key.owner == name || key.grantee == name {
+			delete(s.mGrants, key)
+		}
+	}
 
 	delete(s.mUsers, name)
 
 	return nil
 }
+
+// checkAvailability flags the new invitations of the users outside of their
+// work schedules. Must be called with muEvents held.
+func (s *Storage) checkAvailability(event *storage.Event) {
+	event.CheckAvailability(func(username string) *storage.WorkSchedule {
+		ws, ok := s.mSchedules[username]
+		if !ok {
+			return nil
+		}
+		return &ws
+	})
+}
+
+// cloneWorkSchedule returns a copy of the schedule with its out-of-office
+// periods by start time.
+func cloneWorkSchedule(ws *storage.WorkSchedule) storage.WorkSchedule {
+	cloned := *ws
+	if ws.WorkingHours != nil {
+		wh := *ws.WorkingHours
+		wh.Weekdays = slices.Clone(wh.Weekdays)
+		cloned.WorkingHours = &wh
+	}
+	cloned.OutOfOffice = slices.Clone(ws.OutOfOffice)
+	slices.SortFunc(cloned.OutOfOffice, func(a, b storage.OutOfOffice) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return cloned
+}
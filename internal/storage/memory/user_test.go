@@ -157,3 +157,124 @@ func TestConcurrentCreateUsers(t *testing.T) {
 		t.Errorf("Concurrent CreateUser error: %v", err)
 	}
 }
+
+func TestWorkSchedule(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	// Monday.
+	day := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"alice", "bob"} {
+		user := storage.User{Name: name, Email: name + "@example.com", ConflictPolicy: storage.ConflictAllow}
+		if err := s.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+	bob := storage.User{
+		Name:     "bob",
+		TimeZone: "Europe/Moscow",
+		WorkSchedule: storage.WorkSchedule{
+			WorkingHours: &storage.WorkingHours{
+				Start:    "09:00",
+				End:      "18:00",
+				Weekdays: []string{"MO", "TU", "WE", "TH", "FR"},
+				TimeZone: "Europe/Moscow",
+			},
+			OutOfOffice: []storage.OutOfOffice{{StartTime: day.AddDate(0, 0, 2), EndTime: day.AddDate(0, 0, 3)}},
+		},
+	}
+	if err := bob.WorkSchedule.Check(); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if err := s.UpdateUserSettings(ctx, &bob); err != nil {
+		t.Fatalf("UpdateUserSettings failed: %v", err)
+	}
+	user, err := s.GetUser(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if user.WorkingHours == nil || user.WorkingHours.Start != "09:00" || len(user.OutOfOffice) != 1 {
+		t.Errorf("GetUser: unexpected work schedule %+v", user.WorkSchedule)
+	}
+
+	invite := func(start time.Time, allDay bool) *storage.Attendee {
+		t.Helper()
+		event := storage.Event{
+			Title:     "Sync",
+			Username:  "alice",
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			AllDay:    allDay,
+			Attendees: []storage.Attendee{{Username: "bob"}},
+		}
+		if allDay {
+			event.EndTime = start.AddDate(0, 0, 1)
+		}
+		id, err := s.CreateEvent(ctx, &event)
+		if err != nil {
+			t.Fatalf("CreateEvent failed: %v", err)
+		}
+		stored, err := s.GetEvent(ctx, "alice", id)
+		if err != nil {
+			t.Fatalf("GetEvent failed: %v", err)
+		}
+		return &stored.Attendees[0]
+	}
+	tests := []struct {
+		name   string
+		start  time.Time
+		allDay bool
+		want   storage.Availability
+	}{
+		// 10:00 in Moscow.
+		{"working hours", day.Add(7 * time.Hour), false, ""},
+		{"evening", day.Add(15 * time.Hour), false, storage.AvailabilityOutsideWorkingHours},
+		{"weekend", day.AddDate(0, 0, 5).Add(7 * time.Hour), false, storage.AvailabilityOutsideWorkingHours},
+		{"all day", day.AddDate(0, 0, 5), true, ""},
+		{"out of office", day.AddDate(0, 0, 2).Add(7 * time.Hour), false, storage.AvailabilityOutOfOffice},
+	}
+	for _, test := range tests {
+		attendee := invite(test.start, test.allDay)
+		if attendee.Availability != test.want || attendee.Status != storage.PartStatNeedsAction {
+			t.Errorf("%s: expected %q needs-action, got %q %s", test.name, test.want, attendee.Availability, attendee.Status)
+		}
+	}
+
+	// Rescheduling into the working hours clears the flag.
+	event := storage.Event{
+		Title:     "Sync",
+		Username:  "alice",
+		StartTime: day.Add(15 * time.Hour),
+		EndTime:   day.Add(16 * time.Hour),
+		Attendees: []storage.Attendee{{Username: "bob"}},
+	}
+	id, err := s.CreateEvent(ctx, &event)
+	if err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if err := s.UpdateEvent(ctx, "alice", id, &storage.Event{
+		Title:     "Sync",
+		StartTime: day.Add(8 * time.Hour),
+		EndTime:   day.Add(9 * time.Hour),
+	}); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	stored, err := s.GetEvent(ctx, "alice", id)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if stored.Attendees[0].Availability != "" {
+		t.Errorf("UpdateEvent: expected the flag cleared, got %q", stored.Attendees[0].Availability)
+	}
+
+	bob.DeclineUnavailable = true
+	if err := s.UpdateUserSettings(ctx, &bob); err != nil {
+		t.Fatalf("UpdateUserSettings failed: %v", err)
+	}
+	if attendee := invite(day.Add(15*time.Hour), false); attendee.Status != storage.PartStatDeclined {
+		t.Errorf("DeclineUnavailable: expected declined, got %s", attendee.Status)
+	}
+	if attendee := invite(day.Add(7*time.Hour), false); attendee.Status != storage.PartStatNeedsAction {
+		t.Errorf("DeclineUnavailable: expected needs-action in working hours, got %s", attendee.Status)
+	}
+}
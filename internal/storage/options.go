@@ -0,0 +1,48 @@
+package storage
+
+import "time"
+
+// Options are the settings shared by the storage implementations.
+type Options struct {
+	// IgnoreAllDayConflicts lets events overlap all-day events, such as
+	// birthdays or vacations, without ErrDateBusy.
+	IgnoreAllDayConflicts bool
+}
+
+type Option func(*Options)
+
+func WithIgnoreAllDayConflicts(ignore bool) Option {
+	return func(opts *Options) {
+		opts.IgnoreAllDayConflicts = ignore
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// Conflicts reports whether the overlap of the events makes the date busy.
+// Free events never conflict.
+func (o *Options) Conflicts(a, b *Event) (bool, error) {
+	if !a.IsBusy() || !b.IsBusy() {
+		return false, nil
+	}
+	if o.IgnoreAllDayConflicts && (a.AllDay || b.AllDay) {
+		return false, nil
+	}
+
+	return Overlaps(a, b)
+}
+
+// Date returns the midnight in UTC of the date of t in its location,
+// the form of the bounds of all-day events.
+func Date(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vote is the answer of an invitee about an option of a poll.
+type Vote string
+
+const (
+	VoteYes      Vote = "yes"
+	VoteNo       Vote = "no"
+	VoteIfNeeded Vote = "if-needed"
+)
+
+// Poll lets the invitees vote on the time of a meeting before it is
+// scheduled. The poll is finalized by creating the event of one of its
+// options.
+//
+//nolint:tagliatelle
+type Poll struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	// Options are the candidate times of the meeting by start time.
+	Options []PollOption `db:"-" json:"options"`
+	// Invitees are the usernames of the users who vote, in order.
+	Invitees []string `db:"-" json:"invitees"`
+	// Votes are returned only with a single poll, by username.
+	Votes []PollVote `db:"-" json:"votes,omitempty"`
+	// FinalOptionID is the option chosen on finalizing, nil while the poll
+	// is open. EventID is the event created for it, which may have been
+	// deleted since.
+	FinalOptionID *uuid.UUID `json:"final_option_id,omitempty"`
+	EventID       *uuid.UUID `json:"event_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Username      string     `json:"organizer"`
+}
+
+//nolint:tagliatelle
+type PollOption struct {
+	ID        uuid.UUID `json:"id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	PollID    uuid.UUID `json:"-"`
+}
+
+//nolint:tagliatelle
+type PollVote struct {
+	OptionID uuid.UUID `json:"option_id"`
+	Username string    `json:"username"`
+	Vote     Vote      `json:"vote"`
+}
+
+// IsFinalized reports whether the poll no longer takes votes.
+func (p *Poll) IsFinalized() bool {
+	return p.FinalOptionID != nil
+}
+
+// Option returns the option of the poll with the id or nil.
+func (p *Poll) Option(id uuid.UUID) *PollOption {
+	for i := range p.Options {
+		if p.Options[i].ID == id {
+			return &p.Options[i]
+		}
+	}
+
+	return nil
+}
+
+// IsVisibleTo reports whether the user organizes or is invited to the poll.
+func (p *Poll) IsVisibleTo(username string) bool {
+	return p.Username == username || slices.Contains(p.Invitees, username)
+}
@@ -0,0 +1,194 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) UpdateAttendeeStatus(ctx context.Context, username string, id uuid.UUID, status storage.PartStat) error {
+	sqlUpdateStatus := "UPDATE event_attendees SET status = $1 WHERE event_id = $2 AND username = $3"
+	res, err := s.db.Exec(ctx, sqlUpdateStatus, status, id, username)
+	if err != nil {
+		return fmt.Errorf("update attendee status: %w", err)
+	}
+	if res.RowsAffected() != 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := s.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)", id).Scan(&exists); err != nil {
+		return fmt.Errorf("update attendee status: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("update attendee status: %w: %q", storage.ErrEventNotFound, id)
+	}
+
+	return fmt.Errorf("update attendee status: %w: %q: %q", storage.ErrAttendeeNotFound, username, id)
+}
+
+// sqlRepliedEvents selects the events with the iCalendar UID $1 and, if $2
+// is not NULL, the recurrence id $2, see storage.Event.RepliedBy.
+const sqlRepliedEvents = `
+		COALESCE(NULLIF(e.uid, ''), COALESCE(e.parent_id, e.id)::text) = $1
+		AND (($2::timestamptz IS NULL AND e.parent_id IS NULL) OR (e.parent_id IS NOT NULL AND e.recurrence_id = $2))`
+
+func (s *Storage) ApplyReply(ctx context.Context, reply *storage.Reply) error {
+	sqlUpdateStatus := `
+		UPDATE event_attendees a
+		SET status = $3
+		FROM events e
+		WHERE a.event_id = e.id
+		  AND lower(a.email) = lower($4)
+		  AND e.sequence <= $5
+		  AND ` + sqlRepliedEvents
+	res, err := s.db.Exec(ctx, sqlUpdateStatus, reply.UID, reply.RecurrenceID, reply.Status, reply.Email, reply.Sequence)
+	if err != nil {
+		return fmt.Errorf("apply reply: %w", err)
+	}
+	if res.RowsAffected() != 0 {
+		return nil
+	}
+
+	sqlCheckReply := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM event_attendees a WHERE a.event_id = e.id AND lower(a.email) = lower($3)
+			))
+		FROM events e
+		WHERE ` + sqlRepliedEvents
+	var events, invited int
+	if err := s.db.QueryRow(ctx, sqlCheckReply, reply.UID, reply.RecurrenceID, reply.Email).Scan(&events, &invited); err != nil {
+		return fmt.Errorf("apply reply: %w", err)
+	}
+	switch {
+	case events == 0:
+		return fmt.Errorf("apply reply: %w: uid %q", storage.ErrEventNotFound, reply.UID)
+	case invited == 0:
+		return fmt.Errorf("apply reply: %w: %q: uid %q", storage.ErrAttendeeNotFound, reply.Email, reply.UID)
+	default:
+		return fmt.Errorf("apply reply: %w: uid %q sequence %d", storage.ErrOutdatedReply, reply.UID, reply.Sequence)
+	}
+}
+
+// resolveAttendees returns the attendees with the emails of those given by
+// username, storage.ErrUserNotFound if there is no such user.
+func resolveAttendees(ctx context.Context, db querier, attendees []storage.Attendee) ([]storage.Attendee, error) {
+	if attendees == nil {
+		return nil, nil
+	}
+
+	resolved := make([]storage.Attendee, len(attendees))
+	for i, attendee := range attendees {
+		if attendee.Username != "" {
+			err := db.QueryRow(ctx, "SELECT email FROM users WHERE name = $1", attendee.Username).Scan(&attendee.Email)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil, fmt.Errorf("attendee: %w: %q", storage.ErrUserNotFound, attendee.Username)
+				}
+				return nil, fmt.Errorf("attendee: %w", err)
+			}
+		}
+		resolved[i] = attendee
+	}
+
+	return resolved, nil
+}
+
+// loadAttendees fills in the attendees of the events.
+func loadAttendees(ctx context.Context, db querier, events []storage.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(events))
+	for i := range events {
+		ids[i] = events[i].ID
+	}
+
+	sqlListAttendees := `
+		SELECT event_id, COALESCE(username, ''), email, status, availability
+		FROM event_attendees
+		WHERE event_id = ANY($1)
+		ORDER BY event_id, position`
+	rows, err := db.Query(ctx, sqlListAttendees, ids)
+	if err != nil {
+		return fmt.Errorf("list attendees: %w", err)
+	}
+	defer rows.Close()
+
+	byEvent := make(map[uuid.UUID][]storage.Attendee)
+	for rows.Next() {
+		var eventID uuid.UUID
+		var attendee storage.Attendee
+		if err := rows.Scan(&eventID, &attendee.Username, &attendee.Email, &attendee.Status, &attendee.Availability); err != nil {
+			return fmt.Errorf("list attendees: %w", err)
+		}
+		byEvent[eventID] = append(byEvent[eventID], attendee)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list attendees: %w", err)
+	}
+	for i := range events {
+		events[i].Attendees = byEvent[events[i].ID]
+	}
+
+	return nil
+}
+
+// saveAttendees replaces the stored attendees of the event, checking the
+// availability of the invited users.
+func saveAttendees(ctx context.Context, tx pgx.Tx, event *storage.Event) error {
+	if err := checkAvailability(ctx, tx, event); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM event_attendees WHERE event_id = $1", event.ID); err != nil {
+		return fmt.Errorf("delete attendees: %w", err)
+	}
+
+	sqlInsertAttendee := `
+		INSERT INTO event_attendees (event_id, email, username, status, availability, position)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)`
+	for i, attendee := range event.Attendees {
+		if _, err := tx.Exec(ctx, sqlInsertAttendee,
+			event.ID,
+			attendee.Email,
+			attendee.Username,
+			attendee.Status,
+			attendee.Availability,
+			i,
+		); err != nil {
+			return fmt.Errorf("insert attendee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkAvailability flags the new invitations of the users outside of their
+// work schedules.
+func checkAvailability(ctx context.Context, tx pgx.Tx, event *storage.Event) error {
+	var usernames []string
+	for _, attendee := range event.Attendees {
+		if attendee.Username != "" && attendee.Status == storage.PartStatNeedsAction {
+			usernames = append(usernames, attendee.Username)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	schedules, err := workSchedules(ctx, tx, usernames)
+	if err != nil {
+		return fmt.Errorf("check availability: %w", err)
+	}
+	event.CheckAvailability(func(username string) *storage.WorkSchedule {
+		return schedules[username]
+	})
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+const bookingLinkColumns = `id, slug, title, duration, buffer, day_start, day_end, weekdays, time_zone, lookahead,
+		created_at, username`
+
+func (s *Storage) CreateBookingLink(ctx context.Context, link *storage.BookingLink) (uuid.UUID, error) {
+	sqlInsertBookingLink := `
+		INSERT INTO booking_links (
+			slug,
+			title,
+			duration,
+			buffer,
+			day_start,
+			day_end,
+			weekdays,
+			time_zone,
+			lookahead,
+			username
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, '{}'::text[]), $8, $9, $10)
+		RETURNING id, created_at`
+	if err := s.db.QueryRow(ctx, sqlInsertBookingLink,
+		link.Slug,
+		link.Title,
+		link.Duration,
+		link.Buffer,
+		link.DayStart,
+		link.DayEnd,
+		link.Weekdays,
+		link.TimeZone,
+		link.Lookahead,
+		link.Username,
+	).Scan(&link.ID, &link.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("insert booking link: %w: %q", storage.ErrBookingLinkExists, link.Slug)
+		}
+		return uuid.Nil, fmt.Errorf("insert booking link: %w", err)
+	}
+
+	return link.ID, nil
+}
+
+func (s *Storage) GetBookingLink(ctx context.Context, username string, id uuid.UUID) (*storage.BookingLink, error) {
+	sqlGetBookingLink := `
+		SELECT ` + bookingLinkColumns + `
+		FROM booking_links
+		WHERE username = $1 AND id = $2`
+
+	return s.getBookingLink(ctx, id.String(), sqlGetBookingLink, username, id)
+}
+
+func (s *Storage) GetBookingLinkBySlug(ctx context.Context, slug string) (*storage.BookingLink, error) {
+	sqlGetBookingLink := `
+		SELECT ` + bookingLinkColumns + `
+		FROM booking_links
+		WHERE slug = $1`
+
+	return s.getBookingLink(ctx, slug, sqlGetBookingLink, slug)
+}
+
+func (s *Storage) ListBookingLinks(ctx context.Context, username string) ([]storage.BookingLink, error) {
+	sqlListBookingLinks := `
+		SELECT ` + bookingLinkColumns + `
+		FROM booking_links
+		WHERE username = $1
+		ORDER BY created_at`
+	rows, err := s.db.Query(ctx, sqlListBookingLinks, username)
+	if err != nil {
+		return nil, fmt.Errorf("list booking links: %w", err)
+	}
+	links, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.BookingLink])
+	if err != nil {
+		return nil, fmt.Errorf("list booking links: %w", err)
+	}
+
+	return links, nil
+}
+
+func (s *Storage) UpdateBookingLink(ctx context.Context, username string, id uuid.UUID, link *storage.BookingLink) error {
+	sqlUpdateBookingLink := `
+		UPDATE booking_links
+		SET slug = $1,
+		    title = $2,
+		    duration = $3,
+		    buffer = $4,
+		    day_start = $5,
+		    day_end = $6,
+		    weekdays = COALESCE($7, '{}'::text[]),
+		    time_zone = $8,
+		    lookahead = $9
+		WHERE username = $10 AND id = $11`
+	res, err := s.db.Exec(ctx, sqlUpdateBookingLink,
+		link.Slug,
+		link.Title,
+		link.Duration,
+		link.Buffer,
+		link.DayStart,
+		link.DayEnd,
+		link.Weekdays,
+		link.TimeZone,
+		link.Lookahead,
+		username,
+		id,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update booking link: %w: %q", storage.ErrBookingLinkExists, link.Slug)
+		}
+		return fmt.Errorf("update booking link: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("update booking link: %w: %s", storage.ErrBookingLinkNotFound, id)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteBookingLink(ctx context.Context, username string, id uuid.UUID) error {
+	sqlDeleteBookingLink := "DELETE FROM booking_links WHERE username = $1 AND id = $2"
+	res, err := s.db.Exec(ctx, sqlDeleteBookingLink, username, id)
+	if err != nil {
+		return fmt.Errorf("delete booking link: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete booking link: %w: %s", storage.ErrBookingLinkNotFound, id)
+	}
+
+	return nil
+}
+
+// getBookingLink returns the only link selected by the query, key names it
+// in the errors.
+func (s *Storage) getBookingLink(ctx context.Context, key, query string, args ...any) (*storage.BookingLink, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get booking link: %w", err)
+	}
+	link, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.BookingLink])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get booking link: %w: %q", storage.ErrBookingLinkNotFound, key)
+		}
+		return nil, fmt.Errorf("get booking link: %w", err)
+	}
+
+	return &link, nil
+}
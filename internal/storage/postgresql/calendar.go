@@ -0,0 +1,180 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateCalendar(ctx context.Context, calendar *storage.Calendar) (uuid.UUID, error) {
+	sqlInsertCalendar := `
+		INSERT INTO calendars (
+			name,
+			color,
+			notify_before,
+			username
+		)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+	if err := s.db.QueryRow(ctx, sqlInsertCalendar,
+		calendar.Name,
+		calendar.Color,
+		calendar.NotifyBefore,
+		calendar.Username,
+	).Scan(&calendar.ID, &calendar.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("insert calendar: %w: %q", storage.ErrCalendarExists, calendar.Name)
+		}
+		return uuid.Nil, fmt.Errorf("insert calendar: %w", err)
+	}
+
+	return calendar.ID, nil
+}
+
+func (s *Storage) GetCalendar(ctx context.Context, username string, id uuid.UUID) (*storage.Calendar, error) {
+	sqlGetCalendar := `
+		SELECT id, name, color, notify_before, created_at, username
+		FROM calendars
+		WHERE username = $1 AND id = $2`
+	rows, err := s.db.Query(ctx, sqlGetCalendar, username, id)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar: %w", err)
+	}
+	calendar, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Calendar])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get calendar: %w: %q", storage.ErrCalendarNotFound, id)
+		}
+		return nil, fmt.Errorf("get calendar: %w", err)
+	}
+
+	return &calendar, nil
+}
+
+func (s *Storage) ListCalendars(ctx context.Context, username string) ([]storage.Calendar, error) {
+	sqlListCalendars := `
+		SELECT id, name, color, notify_before, created_at, username
+		FROM calendars
+		WHERE username = $1
+		ORDER BY name`
+	rows, err := s.db.Query(ctx, sqlListCalendars, username)
+	if err != nil {
+		return nil, fmt.Errorf("list calendars: %w", err)
+	}
+	calendars, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Calendar])
+	if err != nil {
+		return nil, fmt.Errorf("list calendars: %w", err)
+	}
+
+	return calendars, nil
+}
+
+func (s *Storage) UpdateCalendar(ctx context.Context, username string, id uuid.UUID, calendar *storage.Calendar) error {
+	sqlUpdateCalendar := `
+		UPDATE calendars
+		SET name = $1,
+		    color = $2,
+		    notify_before = $3
+		WHERE username = $4 AND id = $5`
+	res, err := s.db.Exec(ctx, sqlUpdateCalendar,
+		calendar.Name,
+		calendar.Color,
+		calendar.NotifyBefore,
+		username,
+		id,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update calendar: %w: %q", storage.ErrCalendarExists, calendar.Name)
+		}
+		return fmt.Errorf("update calendar: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("update calendar: %w: %q", storage.ErrCalendarNotFound, id)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCalendar(ctx context.Context, username string, id uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("delete calendar: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserEvents(ctx, tx, username); err != nil {
+		return fmt.Errorf("delete calendar: %w", err)
+	}
+	user, err := userSettings(ctx, tx, username)
+	if err != nil {
+		return fmt.Errorf("delete calendar: %w", err)
+	}
+	// Overrides, in the calendar of their series, are cancelled with it.
+	sqlListEvents := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE calendar_id = $1 AND parent_id IS NULL`
+	rows, err := tx.Query(ctx, sqlListEvents, id)
+	if err != nil {
+		return fmt.Errorf("delete calendar: list events: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		return fmt.Errorf("delete calendar: list events: %w", err)
+	}
+	if err := loadAttendees(ctx, tx, events); err != nil {
+		return fmt.Errorf("delete calendar: %w", err)
+	}
+
+	// Events are deleted by cascade.
+	res, err := tx.Exec(ctx, "DELETE FROM calendars WHERE username = $1 AND id = $2", username, id)
+	if err != nil {
+		return fmt.Errorf("delete calendar: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete calendar: %w: %q", storage.ErrCalendarNotFound, id)
+	}
+	for i := range events {
+		if err := invite(ctx, tx, &events[i], nil, user.Email); err != nil {
+			return fmt.Errorf("delete calendar: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("delete calendar: commit: %w", err)
+	}
+
+	return nil
+}
+
+// checkCalendar returns the calendar of the event, nil if none, or
+// storage.ErrCalendarNotFound if the owner of the event does not have it.
+func checkCalendar(ctx context.Context, tx pgx.Tx, event *storage.Event) (*storage.Calendar, error) {
+	if event.CalendarID == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	var calendar storage.Calendar
+	sqlGetCalendar := "SELECT notify_before FROM calendars WHERE username = $1 AND id = $2 FOR SHARE"
+	err := tx.QueryRow(ctx, sqlGetCalendar, event.Username, event.CalendarID).Scan(&calendar.NotifyBefore)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %q", storage.ErrCalendarNotFound, *event.CalendarID)
+		}
+		return nil, fmt.Errorf("get calendar: %w", err)
+	}
+
+	return &calendar, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" // 23505 = unique_violation
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,131 +12,407 @@ import (
 	"github.com/mrvin/calendar/internal/storage"
 )
 
+const eventColumns = `id, title, description, start_time, end_time, all_day, time_zone, transparency,
+		rrule, exdates, parent_id, recurrence_id, uid, sequence, revision, calendar_id, resource_ids, username`
+
 func (s *Storage) CreateEvent(ctx context.Context, event *storage.Event) (uuid.UUID, error) {
-	sqlInsertEvent := `
-		INSERT INTO events (
-			title,
-			description,
-			start_time,
-			end_time,
-			notify_before,
-			username
-		)
-		SELECT $1, $2, $3, $4, $5, $6
-        WHERE NOT EXISTS (
-            SELECT 1
-            FROM events e
-            WHERE e.username = $6
-              AND e.start_time < $4
-              AND e.end_time > $3
-        )
-		RETURNING id`
-	if err := s.db.QueryRow(ctx, sqlInsertEvent,
-		event.Title,
-		event.Description,
-		event.StartTime,
-		event.EndTime,
-		event.NotifyBefore,
-		event.Username,
-	).Scan(&event.ID); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return uuid.Nil, storage.ErrDateBusy
-		}
+	return s.createEvent(ctx, event, nil)
+}
+
+func (s *Storage) Book(ctx context.Context, event *storage.Event, buffer time.Duration) (uuid.UUID, error) {
+	return s.createEvent(ctx, event, &buffer)
+}
+
+// createEvent inserts the new event. The event of a booking, with a buffer,
+// is rejected on conflict whatever the conflict policy of the user.
+func (s *Storage) createEvent(ctx context.Context, event *storage.Event, buffer *time.Duration) (uuid.UUID, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("insert event: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := s.addEvent(ctx, tx, event, buffer); err != nil {
 		return uuid.Nil, fmt.Errorf("insert event: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("insert event: commit: %w", err)
+	}
+
 	return event.ID, nil
 }
 
+// addEvent checks and inserts the new event within the transaction, as in
+// createEvent.
+func (s *Storage) addEvent(ctx context.Context, tx pgx.Tx, event *storage.Event, buffer *time.Duration) error {
+	if err := lockUserEvents(ctx, tx, event.Username); err != nil {
+		return err
+	}
+	if err := checkUID(ctx, tx, event.Username, event.UID); err != nil {
+		return err
+	}
+	user, err := userSettings(ctx, tx, event.Username)
+	if err != nil {
+		return err
+	}
+	if event.TimeZone == "" {
+		event.TimeZone = user.TimeZone
+	}
+	attendees, err := resolveAttendees(ctx, tx, event.Attendees)
+	if err != nil {
+		return err
+	}
+	event.Attendees = storage.MergeAttendees(nil, attendees, true)
+	event.Resources = storage.SortResources(event.Resources)
+	calendar, err := checkCalendar(ctx, tx, event)
+	if err != nil {
+		return err
+	}
+	if calendar != nil && event.Reminders == nil {
+		event.Reminders = calendar.DefaultReminders()
+	}
+	if buffer != nil {
+		if err := s.checkBusy(ctx, tx, storage.ConflictReject, event.WithBuffer(*buffer), uuid.Nil); err != nil {
+			return err
+		}
+	} else if err := s.checkBusy(ctx, tx, user.ConflictPolicy, event, uuid.Nil); err != nil {
+		return err
+	}
+	if err := checkResources(ctx, tx, event, uuid.Nil); err != nil {
+		return err
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return invite(ctx, tx, nil, event, user.Email)
+}
+
 func (s *Storage) GetEvent(ctx context.Context, username string, id uuid.UUID) (*storage.Event, error) {
 	sqlGetEvent := `
-		SELECT id, title, description, start_time, end_time, notify_before, username
+		SELECT ` + eventColumns + `
 		FROM events
-		WHERE username = $1 AND id = $2`
-	var event storage.Event
-	if err := s.db.QueryRow(ctx, sqlGetEvent, username, id).Scan(
-		&event.ID,
-		&event.Title,
-		&event.Description,
-		&event.StartTime,
-		&event.EndTime,
-		&event.NotifyBefore,
-		&event.Username,
-	); err != nil {
+		WHERE id = $2
+		  AND (username = $1 OR id IN (SELECT event_id FROM event_attendees WHERE username = $1))`
+	rows, err := s.db.Query(ctx, sqlGetEvent, username, id)
+	if err != nil {
+		return nil, fmt.Errorf("get event: %q: %w", id, err)
+	}
+	event, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("get event: %w: %q", storage.ErrEventNotFound, id)
 		}
 		return nil, fmt.Errorf("get event: %q: %w", id, err)
 	}
+	events := []storage.Event{event}
+	if err := loadAttendees(ctx, s.db, events); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if err := loadReminders(ctx, s.db, events); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	setOrganizer(events, username)
+
+	return &events[0], nil
+}
+
+func (s *Storage) GetEventOwner(ctx context.Context, id uuid.UUID) (string, error) {
+	var username string
+	if err := s.db.QueryRow(ctx, "SELECT username FROM events WHERE id = $1", id).Scan(&username); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("get event owner: %w: %q", storage.ErrEventNotFound, id)
+		}
+		return "", fmt.Errorf("get event owner: %q: %w", id, err)
+	}
 
-	return &event, nil
+	return username, nil
 }
 
 func (s *Storage) UpdateEvent(ctx context.Context, username string, id uuid.UUID, event *storage.Event) error {
-	sqlUpdateEvent := `
-		UPDATE events
-		SET title = $1,
-		    description = $2,
-		    start_time = $3,
-		    end_time = $4,
-		    notify_before = $5
-		WHERE username = $6
-		  AND id = $7
-		  AND NOT EXISTS (
-		      SELECT 1
-		      FROM events e
-		      WHERE e.username = $6
-		        AND e.id != $7
-		        AND e.start_time < $4
-		        AND e.end_time > $3
-		  )`
-	res, err := s.db.Exec(ctx, sqlUpdateEvent,
-		event.Title,
-		event.Description,
-		event.StartTime,
-		event.EndTime,
-		event.NotifyBefore,
-		username,
-		id,
-	)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("update event: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserEvents(ctx, tx, username); err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	user, err := userSettings(ctx, tx, username)
+	if err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	oldEvent, err := getEvent(ctx, tx, username, id, "FOR UPDATE")
+	if err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	if event.Revision != 0 && event.Revision != oldEvent.Revision {
+		return fmt.Errorf("update event: %w: %q", storage.ErrEventChanged, id)
+	}
+	if event.Attendees, err = resolveAttendees(ctx, tx, event.Attendees); err != nil {
+		return fmt.Errorf("update event: %w", err)
+	}
+	if err := s.updateEvent(ctx, tx, user, oldEvent, event); err != nil {
 		return fmt.Errorf("update event: %w", err)
 	}
 
-	if res.RowsAffected() == 0 {
-		sqlCheckEvent := "SELECT 1 FROM events WHERE username = $1 AND id = $2"
-		if err := s.db.QueryRow(ctx, sqlCheckEvent, username, id).Scan(new(int)); err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return fmt.Errorf("update event: %q: %w", id, storage.ErrEventNotFound)
-			}
-			return fmt.Errorf("update event: %w", err)
-		}
-		return storage.ErrDateBusy
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("update event: commit: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) DeleteEvent(ctx context.Context, username string, id uuid.UUID) error {
-	sqlDeleteEvent := "DELETE FROM events WHERE username = $1 AND id = $2"
-	res, err := s.db.Exec(ctx, sqlDeleteEvent, username, id)
+func (s *Storage) DeleteEvent(ctx context.Context, username string, id uuid.UUID, revision int64) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("delete event: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	event, err := getEvent(ctx, tx, username, id, "FOR UPDATE")
+	if err != nil {
+		return fmt.Errorf("delete event: %w", err)
+	}
+	if revision != 0 && revision != event.Revision {
+		return fmt.Errorf("delete event: %w: %q", storage.ErrEventChanged, id)
+	}
+	user, err := userSettings(ctx, tx, username)
 	if err != nil {
 		return fmt.Errorf("delete event: %w", err)
 	}
-	if res.RowsAffected() == 0 {
-		return fmt.Errorf("delete event: %q: %w", id, storage.ErrEventNotFound)
+	// Overrides are deleted by cascade.
+	if _, err := tx.Exec(ctx, "DELETE FROM events WHERE id = $1", id); err != nil {
+		return fmt.Errorf("delete event: %w", err)
+	}
+	if event.ParentID != nil {
+		if err := touchEvent(ctx, tx, *event.ParentID); err != nil {
+			return fmt.Errorf("delete event: %w", err)
+		}
+	}
+	if err := invite(ctx, tx, event, nil, user.Email); err != nil {
+		return fmt.Errorf("delete event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("delete event: commit: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:cyclop
+func (s *Storage) UpdateEventOccurrence(
+	ctx context.Context,
+	username string,
+	id uuid.UUID,
+	recurrenceID time.Time,
+	scope storage.Scope,
+	event *storage.Event,
+) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("update occurrence: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserEvents(ctx, tx, username); err != nil {
+		return fmt.Errorf("update occurrence: %w", err)
+	}
+	user, err := userSettings(ctx, tx, username)
+	if err != nil {
+		return fmt.Errorf("update occurrence: %w", err)
+	}
+	series, override, err := getOccurrence(ctx, tx, username, id, recurrenceID)
+	if err != nil {
+		return fmt.Errorf("update occurrence: %w", err)
+	}
+	if event.Attendees, err = resolveAttendees(ctx, tx, event.Attendees); err != nil {
+		return fmt.Errorf("update occurrence: %w", err)
+	}
+
+	switch {
+	case scope == storage.ScopeAll || scope == storage.ScopeFollowing && recurrenceID.Equal(series.StartTime):
+		err = s.updateEvent(ctx, tx, user, series, event)
+	case scope == storage.ScopeThis:
+		series.Exclude(recurrenceID)
+		if err := updateSeries(ctx, tx, series); err != nil {
+			return fmt.Errorf("update occurrence: %w", err)
+		}
+		event.RRule = ""
+		if override != nil {
+			err = s.updateEvent(ctx, tx, user, override, event)
+			break
+		}
+		reference := series.OccurrenceAt(recurrenceID)
+		event.KeepAttendees(&reference)
+		event.KeepResources(&reference)
+		event.SetSequence(&reference)
+		event.ExDates = nil
+		event.ParentID = &id
+		event.RecurrenceID = &recurrenceID
+		event.UID = series.UID
+		event.CalendarID = series.CalendarID
+		if event.TimeZone == "" {
+			event.TimeZone = series.TimeZone
+		}
+		event.Username = username
+		if err = s.checkBusy(ctx, tx, user.ConflictPolicy, event, uuid.Nil); err == nil {
+			err = checkResources(ctx, tx, event, uuid.Nil)
+		}
+		if err == nil {
+			err = insertEvent(ctx, tx, event)
+		}
+		if err == nil {
+			err = invite(ctx, tx, &reference, event, user.Email)
+		}
+	case scope == storage.ScopeFollowing:
+		var rule string
+		if rule, err = series.RuleFrom(recurrenceID); err != nil {
+			return fmt.Errorf("update occurrence: split series: %w", err)
+		}
+		if event.RRule == "" {
+			event.RRule = rule
+		}
+		reference := series.OccurrenceAt(recurrenceID)
+		reference.RRule = rule
+		event.KeepAttendees(&reference)
+		event.KeepResources(&reference)
+		oldSeries := *series
+		if err := splitSeries(ctx, tx, series, recurrenceID); err != nil {
+			return fmt.Errorf("update occurrence: %w", err)
+		}
+		event.ExDates = nil
+		event.ParentID = nil
+		event.RecurrenceID = nil
+		event.UID = ""
+		if event.CalendarID == nil {
+			event.CalendarID = series.CalendarID
+		}
+		if event.TimeZone == "" {
+			event.TimeZone = series.TimeZone
+		}
+		event.Username = username
+		if _, err = checkCalendar(ctx, tx, event); err == nil {
+			err = s.checkBusy(ctx, tx, user.ConflictPolicy, event, uuid.Nil)
+		}
+		if err == nil {
+			err = checkResources(ctx, tx, event, uuid.Nil)
+		}
+		if err == nil {
+			err = insertEvent(ctx, tx, event)
+		}
+		if err == nil {
+			err = invite(ctx, tx, &oldSeries, series, user.Email)
+		}
+		if err == nil {
+			err = invite(ctx, tx, nil, event, user.Email)
+		}
+	default:
+		return fmt.Errorf("update occurrence: unknown scope %q", scope)
+	}
+	if err != nil {
+		return fmt.Errorf("update occurrence: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("update occurrence: commit: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteEventOccurrence(
+	ctx context.Context,
+	username string,
+	id uuid.UUID,
+	recurrenceID time.Time,
+	scope storage.Scope,
+) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("delete occurrence: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockUserEvents(ctx, tx, username); err != nil {
+		return fmt.Errorf("delete occurrence: %w", err)
+	}
+	user, err := userSettings(ctx, tx, username)
+	if err != nil {
+		return fmt.Errorf("delete occurrence: %w", err)
+	}
+	series, override, err := getOccurrence(ctx, tx, username, id, recurrenceID)
+	if err != nil {
+		return fmt.Errorf("delete occurrence: %w", err)
+	}
+
+	switch {
+	case scope == storage.ScopeAll || scope == storage.ScopeFollowing && recurrenceID.Equal(series.StartTime):
+		// Overrides are deleted by cascade.
+		if _, err = tx.Exec(ctx, "DELETE FROM events WHERE id = $1", id); err == nil {
+			err = invite(ctx, tx, series, nil, user.Email)
+		}
+	case scope == storage.ScopeThis:
+		occurrence := series.OccurrenceAt(recurrenceID)
+		if override != nil {
+			occurrence = *override
+		}
+		series.Exclude(recurrenceID)
+		series.Sequence++
+		occurrence.Sequence = series.Sequence
+		if err = updateSeries(ctx, tx, series); err == nil && override != nil {
+			_, err = tx.Exec(ctx, "DELETE FROM events WHERE id = $1", override.ID)
+		}
+		if err == nil {
+			err = invite(ctx, tx, &occurrence, nil, user.Email)
+		}
+	case scope == storage.ScopeFollowing:
+		oldSeries := *series
+		if err = splitSeries(ctx, tx, series, recurrenceID); err == nil {
+			err = invite(ctx, tx, &oldSeries, series, user.Email)
+		}
+	default:
+		return fmt.Errorf("delete occurrence: unknown scope %q", scope)
+	}
+	if err != nil {
+		return fmt.Errorf("delete occurrence: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("delete occurrence: commit: %w", err)
 	}
 
 	return nil
 }
 
 func (s *Storage) ListEvents(ctx context.Context, username string, start, end time.Time) ([]storage.Event, error) {
+	filter := "(username = $1 OR id IN (SELECT event_id FROM event_attendees WHERE username = $1))"
+
+	return s.listEvents(ctx, username, start, end, filter)
+}
+
+func (s *Storage) ListCalendarEvents(
+	ctx context.Context,
+	username string,
+	calendarIDs []uuid.UUID,
+	start, end time.Time,
+) ([]storage.Event, error) {
+	return s.listEvents(ctx, username, start, end, "username = $1 AND calendar_id = ANY($4)", calendarIDs)
+}
+
+// listEvents returns the occurrences in [start, end) of the events selected
+// by the filter with its arguments from $4 on.
+func (s *Storage) listEvents(ctx context.Context, username string, start, end time.Time, filter string, args ...any) ([]storage.Event, error) {
 	sqlListEvents := `
-		SELECT id, title, description, start_time, end_time, notify_before, username
+		SELECT ` + eventColumns + `
 		FROM events
-		WHERE username = $1 AND start_time < $3 AND end_time > $2
-		ORDER BY start_time DESC`
-	rows, err := s.db.Query(ctx, sqlListEvents, username, start, end)
+		WHERE ` + filter + `
+		  AND start_time < $3
+		  AND (series_end_time IS NULL OR series_end_time > $2)`
+	rows, err := s.db.Query(ctx, sqlListEvents, append([]any{username, start, end}, args...)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return []storage.Event{}, nil
@@ -143,41 +420,497 @@ func (s *Storage) ListEvents(ctx context.Context, username string, start, end ti
 		return nil, fmt.Errorf("list events: %w", err)
 	}
 
-	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	series, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
 	if err != nil {
 		return nil, fmt.Errorf("list events: %w", err)
 	}
+	if err := loadAttendees(ctx, s.db, series); err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	if err := loadReminders(ctx, s.db, series); err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	setOrganizer(series, username)
+
+	events := make([]storage.Event, 0, len(series))
+	for _, event := range series {
+		occurrences, err := event.Occurrences(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+		events = append(events, occurrences...)
+	}
+	slices.SortStableFunc(events, func(a, b storage.Event) int {
+		return b.StartTime.Compare(a.StartTime)
+	})
 
 	return events, nil
 }
 
-func (s *Storage) ListEventsToNotify(ctx context.Context, start, end time.Time) ([]storage.Event, error) {
+func (s *Storage) ListSeries(ctx context.Context, username string) ([]storage.Event, error) {
+	sqlListSeries := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE username = $1
+		ORDER BY start_time`
+	rows, err := s.db.Query(ctx, sqlListSeries, username)
+	if err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+	if err := loadAttendees(ctx, s.db, events); err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+	if err := loadReminders(ctx, s.db, events); err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *Storage) ListEventsToNotify(ctx context.Context, start, end time.Time) ([]storage.Notification, error) {
+	// notify_before is stored in nanoseconds. The day of an all-day event
+	// starts at midnight in its time zone, up to storage.MaxZoneOffset from UTC.
 	sqlListEventsToNotify := `
-		SELECT
-			id,
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE EXISTS (
+			SELECT 1
+			FROM event_reminders r
+			WHERE r.event_id = e.id
+			  AND e.start_time - (r.notify_before / 1000) * INTERVAL '1 microsecond'
+			      - CASE WHEN e.all_day THEN $3 * INTERVAL '1 second' ELSE INTERVAL '0' END <= $2
+		)
+		  AND (series_end_time IS NULL OR series_end_time + $3 * INTERVAL '1 second' > $1)`
+	rows, err := s.db.Query(ctx, sqlListEventsToNotify, start, end, storage.MaxZoneOffset.Seconds())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []storage.Notification{}, nil
+		}
+		return nil, fmt.Errorf("list events to notify: %w", err)
+	}
+
+	series, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		return nil, fmt.Errorf("list events to notify: %w", err)
+	}
+	if err := loadReminders(ctx, s.db, series); err != nil {
+		return nil, fmt.Errorf("list events to notify: %w", err)
+	}
+	usernames := make([]string, len(series))
+	for i := range series {
+		usernames[i] = series[i].Username
+	}
+	users, err := notifiedUsers(ctx, s.db, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("list events to notify: %w", err)
+	}
+
+	notifications := make([]storage.Notification, 0, len(series))
+	for _, event := range series {
+		eventNotifications, err := event.Notifications(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("list events to notify: %w", err)
+		}
+		for _, notification := range eventNotifications {
+			notification.Email = users[event.Username].Email
+			notification.WebhookURL = users[event.Username].WebhookURL
+			notifications = append(notifications, notification)
+		}
+	}
+	slices.SortStableFunc(notifications, func(a, b storage.Notification) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return notifications, nil
+}
+
+// lockUserEvents serializes changes of the events of the user until the end
+// of the transaction, so that concurrent overlap checks cannot both pass.
+func lockUserEvents(ctx context.Context, tx pgx.Tx, username string) error {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", username); err != nil {
+		return fmt.Errorf("lock user events: %w", err)
+	}
+
+	return nil
+}
+
+// userSettings returns the settings of the user applied to the events,
+// the defaults if the user does not exist.
+func userSettings(ctx context.Context, tx pgx.Tx, username string) (*storage.User, error) {
+	var user storage.User
+	sqlGetSettings := "SELECT email, time_zone, conflict_policy FROM users WHERE name = $1"
+	err := tx.QueryRow(ctx, sqlGetSettings, username).Scan(&user.Email, &user.TimeZone, &user.ConflictPolicy)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get user settings: %w", err)
+	}
+
+	return &user, nil
+}
+
+// checkUID returns storage.ErrDuplicateUID if the user already has a series
+// with the uid.
+func checkUID(ctx context.Context, tx pgx.Tx, username, uid string) error {
+	if uid == "" {
+		return nil
+	}
+
+	sqlExistsUID := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM events
+			WHERE username = $1 AND uid = $2 AND parent_id IS NULL
+		)`
+	var exists bool
+	if err := tx.QueryRow(ctx, sqlExistsUID, username, uid).Scan(&exists); err != nil {
+		return fmt.Errorf("check uid: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %q", storage.ErrDuplicateUID, uid)
+	}
+
+	return nil
+}
+
+// checkBusy applies the conflict policy to the busy events of the user except
+// the one with excludeID overlapped by the event, unless the options allow
+// the conflict.
+func (s *Storage) checkBusy(ctx context.Context, tx pgx.Tx, policy storage.ConflictPolicy, event *storage.Event, excludeID uuid.UUID) error {
+	if policy == storage.ConflictAllow {
+		return policy.Resolve(event, nil)
+	}
+	seriesEnd, err := seriesEndTime(event)
+	if err != nil {
+		return err
+	}
+
+	sqlListCandidates := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE username = $1
+		  AND id != $2
+		  AND ($4::timestamptz IS NULL OR start_time < $4 + $5 * INTERVAL '1 second')
+		  AND (series_end_time IS NULL OR series_end_time + $5 * INTERVAL '1 second' > $3)
+		ORDER BY id`
+	rows, err := tx.Query(ctx, sqlListCandidates, event.Username, excludeID, event.StartTime, seriesEnd, allDayMargin.Seconds())
+	if err != nil {
+		return fmt.Errorf("list overlapping events: %w", err)
+	}
+	candidates, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		return fmt.Errorf("list overlapping events: %w", err)
+	}
+
+	var conflicts []uuid.UUID
+	for _, candidate := range candidates {
+		busy, err := s.opts.Conflicts(&candidate, event)
+		if err != nil {
+			return fmt.Errorf("check overlap: %w", err)
+		}
+		if busy {
+			conflicts = append(conflicts, candidate.ID)
+		}
+	}
+
+	return policy.Resolve(event, conflicts)
+}
+
+func getEvent(ctx context.Context, db querier, username string, id uuid.UUID, lock string) (*storage.Event, error) {
+	sqlGetEvent := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE username = $1 AND id = $2 ` + lock
+	rows, err := db.Query(ctx, sqlGetEvent, username, id)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", id, err)
+	}
+	event, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %q", storage.ErrEventNotFound, id)
+		}
+		return nil, fmt.Errorf("%q: %w", id, err)
+	}
+	events := []storage.Event{event}
+	if err := loadAttendees(ctx, db, events); err != nil {
+		return nil, err
+	}
+	if err := loadReminders(ctx, db, events); err != nil {
+		return nil, err
+	}
+
+	return &events[0], nil
+}
+
+// setOrganizer marks the events the user is invited to with their owner.
+func setOrganizer(events []storage.Event, username string) {
+	for i := range events {
+		if events[i].Username != username {
+			events[i].Organizer = events[i].Username
+		}
+	}
+}
+
+// getOccurrence returns the recurring event and the override of its occurrence
+// starting at recurrenceID, if any, locking both.
+func getOccurrence(ctx context.Context, tx pgx.Tx, username string, id uuid.UUID, recurrenceID time.Time) (*storage.Event, *storage.Event, error) {
+	series, err := getEvent(ctx, tx, username, id, "FOR UPDATE")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sqlGetOverride := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE parent_id = $1 AND recurrence_id = $2
+		FOR UPDATE`
+	rows, err := tx.Query(ctx, sqlGetOverride, id, recurrenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get override: %w", err)
+	}
+	override, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Event])
+	if err == nil {
+		overrides := []storage.Event{override}
+		if err := loadAttendees(ctx, tx, overrides); err != nil {
+			return nil, nil, err
+		}
+		if err := loadReminders(ctx, tx, overrides); err != nil {
+			return nil, nil, err
+		}
+		return series, &overrides[0], nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, fmt.Errorf("get override: %w", err)
+	}
+
+	ok, err := series.HasOccurrence(recurrenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get occurrence: %w", err)
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s %s", storage.ErrOccurrenceNotFound, id, recurrenceID)
+	}
+
+	return series, nil, nil
+}
+
+func insertEvent(ctx context.Context, tx pgx.Tx, event *storage.Event) error {
+	seriesEnd, err := seriesEndTime(event)
+	if err != nil {
+		return err
+	}
+
+	sqlInsertEvent := `
+		INSERT INTO events (
 			title,
 			description,
 			start_time,
 			end_time,
-			notify_before,
-			username,
-			(start_time - (notify_before * INTERVAL '1 second')) AS notify_time
-		FROM events
-		WHERE notify_time >= $1
-		  AND notify_time <= $2
-		ORDER BY notify_time ASC`
-	rows, err := s.db.Query(ctx, sqlListEventsToNotify, start, end)
+			all_day,
+			time_zone,
+			transparency,
+			rrule,
+			exdates,
+			series_end_time,
+			parent_id,
+			recurrence_id,
+			uid,
+			sequence,
+			calendar_id,
+			resource_ids,
+			username
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, '{}'::timestamptz[]), $10, $11, $12, $13, $14, $15,
+			COALESCE($16, '{}'::uuid[]), $17)
+		RETURNING id, revision`
+	if err := tx.QueryRow(ctx, sqlInsertEvent,
+		event.Title,
+		event.Description,
+		event.StartTime,
+		event.EndTime,
+		event.AllDay,
+		event.TimeZone,
+		event.Transparency,
+		event.RRule,
+		event.ExDates,
+		seriesEnd,
+		event.ParentID,
+		event.RecurrenceID,
+		event.UID,
+		event.Sequence,
+		event.CalendarID,
+		event.Resources,
+		event.Username,
+	).Scan(&event.ID, &event.Revision); err != nil {
+		return fmt.Errorf("insert: %w", err)
+	}
+	if err := saveReminders(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return saveAttendees(ctx, tx, event)
+}
+
+// updateEvent replaces the fields of oldEvent given by the user.
+// The event must be locked.
+func (s *Storage) updateEvent(ctx context.Context, tx pgx.Tx, user *storage.User, oldEvent, event *storage.Event) error {
+	event.ID = oldEvent.ID
+	if event.ExDates == nil {
+		event.ExDates = oldEvent.ExDates
+	}
+	event.ParentID = oldEvent.ParentID
+	event.RecurrenceID = oldEvent.RecurrenceID
+	event.UID = oldEvent.UID
+	if event.TimeZone == "" {
+		event.TimeZone = oldEvent.TimeZone
+	}
+	event.Username = oldEvent.Username
+	if oldEvent.ParentID != nil {
+		event.CalendarID = oldEvent.CalendarID
+	}
+	event.KeepAttendees(oldEvent)
+	event.KeepResources(oldEvent)
+	event.SetSequence(oldEvent)
+	if _, err := checkCalendar(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := s.checkBusy(ctx, tx, user.ConflictPolicy, event, event.ID); err != nil {
+		return err
+	}
+	if err := checkResources(ctx, tx, event, event.ID); err != nil {
+		return err
+	}
+	seriesEnd, err := seriesEndTime(event)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return []storage.Event{}, nil
+		return err
+	}
+
+	sqlUpdateEvent := `
+		UPDATE events
+		SET title = $1,
+		    description = $2,
+		    start_time = $3,
+		    end_time = $4,
+		    all_day = $5,
+		    time_zone = $6,
+		    transparency = $7,
+		    rrule = $8,
+		    exdates = COALESCE($9, '{}'::timestamptz[]),
+		    series_end_time = $10,
+		    sequence = $11,
+		    calendar_id = $12,
+		    resource_ids = COALESCE($13, '{}'::uuid[]),
+		    revision = revision + 1
+		WHERE username = $14 AND id = $15
+		RETURNING revision`
+	if err := tx.QueryRow(ctx, sqlUpdateEvent,
+		event.Title,
+		event.Description,
+		event.StartTime,
+		event.EndTime,
+		event.AllDay,
+		event.TimeZone,
+		event.Transparency,
+		event.RRule,
+		event.ExDates,
+		seriesEnd,
+		event.Sequence,
+		event.CalendarID,
+		event.Resources,
+		event.Username,
+		event.ID,
+	).Scan(&event.Revision); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if event.ParentID != nil {
+		if err := touchEvent(ctx, tx, *event.ParentID); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("list events to notify: %w", err)
+	}
+	// Overrides of occurrences follow their series to another calendar.
+	if _, err := tx.Exec(ctx, "UPDATE events SET calendar_id = $1 WHERE parent_id = $2", event.CalendarID, event.ID); err != nil {
+		return fmt.Errorf("update overrides: %w", err)
+	}
+	if err := saveReminders(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := saveAttendees(ctx, tx, event); err != nil {
+		return err
 	}
 
-	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	return invite(ctx, tx, oldEvent, event, user.Email)
+}
+
+// updateSeries stores the recurrence rule, exclusions and sequence of the series.
+func updateSeries(ctx context.Context, tx pgx.Tx, series *storage.Event) error {
+	seriesEnd, err := seriesEndTime(series)
 	if err != nil {
-		return nil, fmt.Errorf("list events to notify: %w", err)
+		return err
 	}
 
-	return events, nil
+	sqlUpdateSeries := `
+		UPDATE events
+		SET rrule = $1,
+		    exdates = COALESCE($2, '{}'::timestamptz[]),
+		    series_end_time = $3,
+		    sequence = $4,
+		    revision = revision + 1
+		WHERE id = $5
+		RETURNING revision`
+	err = tx.QueryRow(ctx, sqlUpdateSeries, series.RRule, series.ExDates, seriesEnd, series.Sequence, series.ID).Scan(&series.Revision)
+	if err != nil {
+		return fmt.Errorf("update series: %w", err)
+	}
+
+	return nil
+}
+
+// touchEvent increments the revision of the event.
+func touchEvent(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	if _, err := tx.Exec(ctx, "UPDATE events SET revision = revision + 1 WHERE id = $1", id); err != nil {
+		return fmt.Errorf("touch event: %w", err)
+	}
+
+	return nil
+}
+
+// splitSeries ends the series before the occurrence starting at recurrenceID,
+// a new revision of it, and deletes the overrides of the following occurrences.
+func splitSeries(ctx context.Context, tx pgx.Tx, series *storage.Event, recurrenceID time.Time) error {
+	if err := series.TruncateBefore(recurrenceID); err != nil {
+		return fmt.Errorf("split series: %w", err)
+	}
+	series.Sequence++
+	if err := updateSeries(ctx, tx, series); err != nil {
+		return err
+	}
+
+	sqlDeleteOverrides := "DELETE FROM events WHERE parent_id = $1 AND recurrence_id >= $2"
+	if _, err := tx.Exec(ctx, sqlDeleteOverrides, series.ID, recurrenceID); err != nil {
+		return fmt.Errorf("delete following overrides: %w", err)
+	}
+
+	return nil
+}
+
+// allDayMargin widens the candidates of the overlap checks by the largest
+// shift between the dates of all-day events in their time zones, see
+// storage.Event.Span.
+const allDayMargin = 2 * storage.MaxZoneOffset
+
+// seriesEndTime returns the value of the series_end_time column,
+// nil for an infinite series.
+func seriesEndTime(event *storage.Event) (*time.Time, error) {
+	seriesEnd, ok, err := event.SeriesEnd()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &seriesEnd, nil
 }
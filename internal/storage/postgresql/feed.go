@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateFeed(ctx context.Context, feed *storage.Feed) (uuid.UUID, error) {
+	sqlInsertFeed := `
+		INSERT INTO feeds (
+			name,
+			token_hash,
+			username
+		)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+	if err := s.db.QueryRow(ctx, sqlInsertFeed,
+		feed.Name,
+		feed.TokenHash,
+		feed.Username,
+	).Scan(&feed.ID, &feed.CreatedAt); err != nil {
+		return uuid.Nil, fmt.Errorf("insert feed: %w", err)
+	}
+
+	return feed.ID, nil
+}
+
+func (s *Storage) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*storage.Feed, error) {
+	sqlGetFeed := `
+		SELECT id, name, token_hash, created_at, username
+		FROM feeds
+		WHERE token_hash = $1`
+	rows, err := s.db.Query(ctx, sqlGetFeed, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("get feed: %w", err)
+	}
+	feed, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Feed])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get feed: %w", storage.ErrFeedNotFound)
+		}
+		return nil, fmt.Errorf("get feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+func (s *Storage) ListFeeds(ctx context.Context, username string) ([]storage.Feed, error) {
+	sqlListFeeds := `
+		SELECT id, name, token_hash, created_at, username
+		FROM feeds
+		WHERE username = $1
+		ORDER BY created_at`
+	rows, err := s.db.Query(ctx, sqlListFeeds, username)
+	if err != nil {
+		return nil, fmt.Errorf("list feeds: %w", err)
+	}
+	feeds, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Feed])
+	if err != nil {
+		return nil, fmt.Errorf("list feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+func (s *Storage) UpdateFeedToken(ctx context.Context, username string, id uuid.UUID, tokenHash string) error {
+	sqlUpdateFeedToken := "UPDATE feeds SET token_hash = $1 WHERE username = $2 AND id = $3"
+	res, err := s.db.Exec(ctx, sqlUpdateFeedToken, tokenHash, username, id)
+	if err != nil {
+		return fmt.Errorf("update feed token: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("update feed token: %w: %s", storage.ErrFeedNotFound, id)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteFeed(ctx context.Context, username string, id uuid.UUID) error {
+	sqlDeleteFeed := "DELETE FROM feeds WHERE username = $1 AND id = $2"
+	res, err := s.db.Exec(ctx, sqlDeleteFeed, username, id)
+	if err != nil {
+		return fmt.Errorf("delete feed: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete feed: %w: %s", storage.ErrFeedNotFound, id)
+	}
+
+	return nil
+}
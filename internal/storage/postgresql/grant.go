@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) SetGrant(ctx context.Context, grant *storage.Grant) error {
+	sqlSetGrant := `
+		INSERT INTO grants (owner, grantee, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (owner, grantee) DO UPDATE SET role = EXCLUDED.role
+		RETURNING created_at`
+	if err := s.db.QueryRow(ctx, sqlSetGrant,
+		grant.Owner,
+		grant.Grantee,
+		grant.Role,
+	).Scan(&grant.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // 23503 = foreign_key_violation
+			return fmt.Errorf("set grant: grantee: %w: %q", storage.ErrUserNotFound, grant.Grantee)
+		}
+		return fmt.Errorf("set grant: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetGrant(ctx context.Context, owner, grantee string) (*storage.Grant, error) {
+	sqlGetGrant := `
+		SELECT owner, grantee, role, created_at
+		FROM grants
+		WHERE owner = $1 AND grantee = $2`
+	rows, err := s.db.Query(ctx, sqlGetGrant, owner, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("get grant: %w", err)
+	}
+	grant, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Grant])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get grant: %w: %q to %q", storage.ErrGrantNotFound, owner, grantee)
+		}
+		return nil, fmt.Errorf("get grant: %w", err)
+	}
+
+	return &grant, nil
+}
+
+func (s *Storage) ListGrants(ctx context.Context, owner string) ([]storage.Grant, error) {
+	return s.listGrants(ctx, "owner", owner)
+}
+
+func (s *Storage) ListGrantsTo(ctx context.Context, grantee string) ([]storage.Grant, error) {
+	return s.listGrants(ctx, "grantee", grantee)
+}
+
+// listGrants returns the grants with the username in the column, owner or
+// grantee.
+func (s *Storage) listGrants(ctx context.Context, column, username string) ([]storage.Grant, error) {
+	sqlListGrants := `
+		SELECT owner, grantee, role, created_at
+		FROM grants
+		WHERE ` + column + ` = $1
+		ORDER BY owner, grantee`
+	rows, err := s.db.Query(ctx, sqlListGrants, username)
+	if err != nil {
+		return nil, fmt.Errorf("list grants: %w", err)
+	}
+	grants, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Grant])
+	if err != nil {
+		return nil, fmt.Errorf("list grants: %w", err)
+	}
+
+	return grants, nil
+}
+
+func (s *Storage) DeleteGrant(ctx context.Context, owner, grantee string) error {
+	res, err := s.db.Exec(ctx, "DELETE FROM grants WHERE owner = $1 AND grantee = $2", owner, grantee)
+	if err != nil {
+		return fmt.Errorf("delete grant: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete grant: %w: %q to %q", storage.ErrGrantNotFound, owner, grantee)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) ListInvitations(ctx context.Context, limit int) ([]storage.Invitation, error) {
+	sqlListInvitations := `
+		SELECT id, method, event, organizer, recipients, created_at
+		FROM invitations
+		ORDER BY created_at
+		LIMIT $1`
+	rows, err := s.db.Query(ctx, sqlListInvitations, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list invitations: %w", err)
+	}
+	invitations, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Invitation])
+	if err != nil {
+		return nil, fmt.Errorf("list invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (s *Storage) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	res, err := s.db.Exec(ctx, "DELETE FROM invitations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete invitation: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete invitation: %w: %q", storage.ErrInvitationNotFound, id)
+	}
+
+	return nil
+}
+
+// invite stores the invitations to the attendees about the change of old to
+// event.
+func invite(ctx context.Context, tx pgx.Tx, old, event *storage.Event, organizer string) error {
+	sqlInsertInvitation := `
+		INSERT INTO invitations (id, method, event, organizer, recipients, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	for _, invitation := range storage.Invitations(old, event, organizer) {
+		if _, err := tx.Exec(ctx, sqlInsertInvitation,
+			invitation.ID,
+			invitation.Method,
+			invitation.Event,
+			invitation.Organizer,
+			invitation.Recipients,
+			invitation.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("insert invitation: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// leaderLockClass keeps the leader locks apart from the locks of the events
+// of the users, taken with a single key.
+const leaderLockClass = 1
+
+// Leader elects one of the replicas sharing the database by a session
+// advisory lock. The lock is held on a connection taken out of the pool and
+// released by the server as soon as the connection closes, so another
+// replica takes over once the leader dies.
+type Leader struct {
+	db   *pgxpool.Pool
+	name string
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+// NewLeader returns the election of the replicas named name.
+func (s *Storage) NewLeader(name string) *Leader {
+	//nolint:exhaustruct
+	return &Leader{db: s.db, name: name}
+}
+
+// Lead takes the lock unless it already holds it and reports whether the
+// replica leads. The leadership is lost with the connection holding the lock.
+func (l *Leader) Lead(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err != nil {
+			_ = l.conn.Close(ctx)
+			l.conn = nil
+			return false, fmt.Errorf("lead: lost lock connection: %w", err)
+		}
+		return true, nil
+	}
+
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("lead: %w", err)
+	}
+	var locked bool
+	sqlTryLock := "SELECT pg_try_advisory_lock($1, hashtext($2))"
+	if err := conn.QueryRow(ctx, sqlTryLock, leaderLockClass, l.name).Scan(&locked); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("lead: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+	// The connection leaves the pool, which would otherwise close it
+	// after its lifetime.
+	l.conn = conn.Hijack()
+
+	return true, nil
+}
+
+// Resign releases the lock if the replica leads.
+func (l *Leader) Resign(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	// Closing the connection releases the lock.
+	err := l.conn.Close(ctx)
+	l.conn = nil
+	if err != nil {
+		return fmt.Errorf("resign: %w", err)
+	}
+
+	return nil
+}
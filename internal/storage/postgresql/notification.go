@@ -0,0 +1,115 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// RecordNotification stores the notification as pending unless it is already
+// stored, and sets its ID and status to the stored ones, so that each
+// reminder of an occurrence is dispatched under a single message ID.
+func (s *Storage) RecordNotification(ctx context.Context, notification *storage.Notification) error {
+	// The no-op update makes RETURNING give the stored row on conflict.
+	sqlInsertNotification := `
+		INSERT INTO notifications (id, event_id, start_time, notify_before, channel, notify_time, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (event_id, start_time, notify_before, channel) DO UPDATE
+		SET notify_time = notifications.notify_time
+		RETURNING id, status`
+	err := s.db.QueryRow(ctx, sqlInsertNotification,
+		uuid.New(),
+		notification.Event.ID,
+		notification.Event.StartTime,
+		notification.Reminder.Offset,
+		notification.Reminder.Channel,
+		notification.Time,
+		storage.NotificationPending,
+		notification.CreatedAt,
+	).Scan(&notification.ID, &notification.Status)
+	if err != nil {
+		return fmt.Errorf("record notification: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) MarkNotificationSent(ctx context.Context, id uuid.UUID) error {
+	sqlMarkSent := `
+		UPDATE notifications
+		SET status = $2, sent_at = NOW()
+		WHERE id = $1`
+	res, err := s.db.Exec(ctx, sqlMarkSent, id, storage.NotificationSent)
+	if err != nil {
+		return fmt.Errorf("mark notification sent: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("mark notification sent: %w: %q", storage.ErrNotificationNotFound, id)
+	}
+
+	return nil
+}
+
+// NotificationDelivered reports whether the message of the notification was
+// delivered, false if the notification is not stored.
+func (s *Storage) NotificationDelivered(ctx context.Context, id uuid.UUID) (bool, error) {
+	sqlDelivered := "SELECT EXISTS (SELECT 1 FROM notifications WHERE id = $1 AND delivered_at IS NOT NULL)"
+	var delivered bool
+	if err := s.db.QueryRow(ctx, sqlDelivered, id).Scan(&delivered); err != nil {
+		return false, fmt.Errorf("notification delivered: %w", err)
+	}
+
+	return delivered, nil
+}
+
+func (s *Storage) MarkNotificationDelivered(ctx context.Context, id uuid.UUID) error {
+	sqlMarkDelivered := `
+		UPDATE notifications
+		SET delivered_at = COALESCE(delivered_at, NOW())
+		WHERE id = $1`
+	res, err := s.db.Exec(ctx, sqlMarkDelivered, id)
+	if err != nil {
+		return fmt.Errorf("mark notification delivered: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("mark notification delivered: %w: %q", storage.ErrNotificationNotFound, id)
+	}
+
+	return nil
+}
+
+// NotifiedUntil returns the time from which the notifications may not have
+// been dispatched: the notify time of the earliest pending notification or
+// the time of the latest recorded one, nil if none are recorded.
+func (s *Storage) NotifiedUntil(ctx context.Context) (*time.Time, error) {
+	// LEAST ignores the NULL minimum if nothing is pending.
+	sqlNotifiedUntil := `
+		SELECT LEAST(MIN(notify_time) FILTER (WHERE status = $1), MAX(created_at))
+		FROM notifications`
+	var until *time.Time
+	if err := s.db.QueryRow(ctx, sqlNotifiedUntil, storage.NotificationPending).Scan(&until); err != nil {
+		return nil, fmt.Errorf("notified until: %w", err)
+	}
+
+	return until, nil
+}
+
+// DeleteSentNotifications deletes the sent notifications due before the
+// time but the latest recorded one, which NotifiedUntil needs, and returns
+// the number of deleted notifications.
+func (s *Storage) DeleteSentNotifications(ctx context.Context, before time.Time) (int, error) {
+	sqlDeleteSent := `
+		DELETE FROM notifications
+		WHERE status = $1
+		  AND notify_time < $2
+		  AND created_at < (SELECT MAX(created_at) FROM notifications)`
+	res, err := s.db.Exec(ctx, sqlDeleteSent, storage.NotificationSent, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete sent notifications: %w", err)
+	}
+
+	return int(res.RowsAffected()), nil
+}
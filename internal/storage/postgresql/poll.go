@@ -0,0 +1,300 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+const pollColumns = "id, title, description, final_option_id, event_id, created_at, username"
+
+func (s *Storage) CreatePoll(ctx context.Context, poll *storage.Poll) (uuid.UUID, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("insert poll: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	sqlInsertPoll := `
+		INSERT INTO polls (
+			title,
+			description,
+			username
+		)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+	if err := tx.QueryRow(ctx, sqlInsertPoll,
+		poll.Title,
+		poll.Description,
+		poll.Username,
+	).Scan(&poll.ID, &poll.CreatedAt); err != nil {
+		return uuid.Nil, fmt.Errorf("insert poll: %w", err)
+	}
+	poll.Votes = nil
+	poll.FinalOptionID = nil
+	poll.EventID = nil
+
+	slices.SortStableFunc(poll.Options, func(a, b storage.PollOption) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	sqlInsertOption := "INSERT INTO poll_options (poll_id, start_time, end_time) VALUES ($1, $2, $3) RETURNING id"
+	for i := range poll.Options {
+		option := &poll.Options[i]
+		option.PollID = poll.ID
+		if err := tx.QueryRow(ctx, sqlInsertOption, poll.ID, option.StartTime, option.EndTime).Scan(&option.ID); err != nil {
+			return uuid.Nil, fmt.Errorf("insert poll: option: %w", err)
+		}
+	}
+	sqlInsertInvitee := "INSERT INTO poll_invitees (poll_id, username, position) VALUES ($1, $2, $3)"
+	for i, invitee := range poll.Invitees {
+		if _, err := tx.Exec(ctx, sqlInsertInvitee, poll.ID, invitee, i); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" { // 23503 = foreign_key_violation
+				return uuid.Nil, fmt.Errorf("insert poll: invitee: %w: %q", storage.ErrUserNotFound, invitee)
+			}
+			return uuid.Nil, fmt.Errorf("insert poll: invitee: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("insert poll: commit: %w", err)
+	}
+
+	return poll.ID, nil
+}
+
+func (s *Storage) GetPoll(ctx context.Context, username string, id uuid.UUID) (*storage.Poll, error) {
+	sqlGetPoll := `
+		SELECT ` + pollColumns + `
+		FROM polls
+		WHERE id = $1
+		  AND (username = $2 OR EXISTS (SELECT 1 FROM poll_invitees WHERE poll_id = $1 AND username = $2))`
+	rows, err := s.db.Query(ctx, sqlGetPoll, id, username)
+	if err != nil {
+		return nil, fmt.Errorf("get poll: %w", err)
+	}
+	poll, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Poll])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get poll: %w: %s", storage.ErrPollNotFound, id)
+		}
+		return nil, fmt.Errorf("get poll: %w", err)
+	}
+	polls := []storage.Poll{poll}
+	if err := loadPolls(ctx, s.db, polls); err != nil {
+		return nil, fmt.Errorf("get poll: %w", err)
+	}
+
+	sqlListVotes := `
+		SELECT v.option_id, v.username, v.vote
+		FROM poll_votes v
+		JOIN poll_options o ON o.id = v.option_id
+		WHERE v.poll_id = $1
+		ORDER BY v.username, o.start_time, o.id`
+	rows, err = s.db.Query(ctx, sqlListVotes, id)
+	if err != nil {
+		return nil, fmt.Errorf("get poll: votes: %w", err)
+	}
+	if polls[0].Votes, err = pgx.CollectRows(rows, pgx.RowToStructByName[storage.PollVote]); err != nil {
+		return nil, fmt.Errorf("get poll: votes: %w", err)
+	}
+
+	return &polls[0], nil
+}
+
+func (s *Storage) ListPolls(ctx context.Context, username string) ([]storage.Poll, error) {
+	sqlListPolls := `
+		SELECT ` + pollColumns + `
+		FROM polls
+		WHERE username = $1 OR id IN (SELECT poll_id FROM poll_invitees WHERE username = $1)
+		ORDER BY created_at`
+	rows, err := s.db.Query(ctx, sqlListPolls, username)
+	if err != nil {
+		return nil, fmt.Errorf("list polls: %w", err)
+	}
+	polls, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Poll])
+	if err != nil {
+		return nil, fmt.Errorf("list polls: %w", err)
+	}
+	if err := loadPolls(ctx, s.db, polls); err != nil {
+		return nil, fmt.Errorf("list polls: %w", err)
+	}
+
+	return polls, nil
+}
+
+func (s *Storage) DeletePoll(ctx context.Context, username string, id uuid.UUID) error {
+	res, err := s.db.Exec(ctx, "DELETE FROM polls WHERE username = $1 AND id = $2", username, id)
+	if err != nil {
+		return fmt.Errorf("delete poll: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete poll: %w: %s", storage.ErrPollNotFound, id)
+	}
+
+	return nil
+}
+
+func (s *Storage) SetVotes(ctx context.Context, username string, id uuid.UUID, votes []storage.PollVote) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("set votes: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	sqlLockPoll := `
+		SELECT p.final_option_id
+		FROM polls p
+		JOIN poll_invitees i ON i.poll_id = p.id
+		WHERE p.id = $1 AND i.username = $2
+		FOR UPDATE OF p`
+	if err := lockOpenPoll(ctx, tx, id, sqlLockPoll, id, username); err != nil {
+		return fmt.Errorf("set votes: %w", err)
+	}
+	for _, vote := range votes {
+		if err := checkPollOption(ctx, tx, id, vote.OptionID); err != nil {
+			return fmt.Errorf("set votes: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM poll_votes WHERE poll_id = $1 AND username = $2", id, username); err != nil {
+		return fmt.Errorf("set votes: %w", err)
+	}
+	sqlInsertVote := "INSERT INTO poll_votes (poll_id, option_id, username, vote) VALUES ($1, $2, $3, $4)"
+	for _, vote := range votes {
+		if _, err := tx.Exec(ctx, sqlInsertVote, id, vote.OptionID, username, vote.Vote); err != nil {
+			return fmt.Errorf("set votes: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("set votes: commit: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) FinalizePoll(ctx context.Context, username string, id, optionID uuid.UUID, event *storage.Event) (uuid.UUID, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	sqlLockPoll := "SELECT final_option_id FROM polls WHERE id = $1 AND username = $2 FOR UPDATE"
+	if err := lockOpenPoll(ctx, tx, id, sqlLockPoll, id, username); err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: %w", err)
+	}
+	if err := checkPollOption(ctx, tx, id, optionID); err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: %w", err)
+	}
+	if err := s.addEvent(ctx, tx, event, nil); err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: insert event: %w", err)
+	}
+	sqlFinalizePoll := "UPDATE polls SET final_option_id = $1, event_id = $2 WHERE id = $3"
+	if _, err := tx.Exec(ctx, sqlFinalizePoll, optionID, event.ID, id); err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("finalize poll: commit: %w", err)
+	}
+
+	return event.ID, nil
+}
+
+// lockOpenPoll locks the poll selected by the query of its final option
+// until the end of the transaction, storage.ErrPollFinalized if it is closed.
+func lockOpenPoll(ctx context.Context, tx pgx.Tx, id uuid.UUID, query string, args ...any) error {
+	var finalOptionID *uuid.UUID
+	if err := tx.QueryRow(ctx, query, args...).Scan(&finalOptionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", storage.ErrPollNotFound, id)
+		}
+		return fmt.Errorf("lock poll: %w", err)
+	}
+	if finalOptionID != nil {
+		return fmt.Errorf("%w: %s", storage.ErrPollFinalized, id)
+	}
+
+	return nil
+}
+
+// checkPollOption returns storage.ErrPollOptionNotFound if the poll has no
+// option with the id.
+func checkPollOption(ctx context.Context, tx pgx.Tx, pollID, optionID uuid.UUID) error {
+	var exists bool
+	sqlExistsOption := "SELECT EXISTS (SELECT 1 FROM poll_options WHERE poll_id = $1 AND id = $2)"
+	if err := tx.QueryRow(ctx, sqlExistsOption, pollID, optionID).Scan(&exists); err != nil {
+		return fmt.Errorf("check poll option: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", storage.ErrPollOptionNotFound, optionID)
+	}
+
+	return nil
+}
+
+// loadPolls fills in the options and the invitees of the polls.
+func loadPolls(ctx context.Context, db querier, polls []storage.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(polls))
+	index := make(map[uuid.UUID]*storage.Poll, len(polls))
+	for i := range polls {
+		ids[i] = polls[i].ID
+		polls[i].Options = make([]storage.PollOption, 0)
+		polls[i].Invitees = make([]string, 0)
+		index[polls[i].ID] = &polls[i]
+	}
+
+	sqlListOptions := `
+		SELECT id, start_time, end_time, poll_id
+		FROM poll_options
+		WHERE poll_id = ANY($1)
+		ORDER BY start_time, id`
+	rows, err := db.Query(ctx, sqlListOptions, ids)
+	if err != nil {
+		return fmt.Errorf("load poll options: %w", err)
+	}
+	options, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.PollOption])
+	if err != nil {
+		return fmt.Errorf("load poll options: %w", err)
+	}
+	for _, option := range options {
+		poll := index[option.PollID]
+		poll.Options = append(poll.Options, option)
+	}
+
+	sqlListInvitees := `
+		SELECT poll_id, username
+		FROM poll_invitees
+		WHERE poll_id = ANY($1)
+		ORDER BY position`
+	rows, err = db.Query(ctx, sqlListInvitees, ids)
+	if err != nil {
+		return fmt.Errorf("load poll invitees: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pollID uuid.UUID
+		var invitee string
+		if err := rows.Scan(&pollID, &invitee); err != nil {
+			return fmt.Errorf("load poll invitees: %w", err)
+		}
+		poll := index[pollID]
+		poll.Invitees = append(poll.Invitees, invitee)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("load poll invitees: %w", err)
+	}
+
+	return nil
+}
@@ -6,7 +6,10 @@ import (
 	"net"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mrvin/calendar/internal/storage"
 	"github.com/mrvin/calendar/pkg/retry"
 )
 
@@ -27,16 +30,25 @@ type Conf struct {
 	Name     string `env:"POSTGRES_DB"       yaml:"name"`
 }
 
+// querier is implemented by both the connection pool and a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type Storage struct {
 	db *pgxpool.Pool
 
 	conf *Conf
+	opts storage.Options
 }
 
-func New(ctx context.Context, conf *Conf) (*Storage, error) {
+func New(ctx context.Context, conf *Conf, opts ...storage.Option) (*Storage, error) {
 	var st Storage
 
 	st.conf = conf
+	st.opts = storage.NewOptions(opts...)
 
 	if err := st.connect(ctx); err != nil {
 		return nil, err
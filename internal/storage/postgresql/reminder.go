@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+// loadReminders fills in the reminders of the events.
+func loadReminders(ctx context.Context, db querier, events []storage.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(events))
+	for i := range events {
+		ids[i] = events[i].ID
+	}
+
+	sqlListReminders := `
+		SELECT event_id, notify_before, channel
+		FROM event_reminders
+		WHERE event_id = ANY($1)
+		ORDER BY event_id, position`
+	rows, err := db.Query(ctx, sqlListReminders, ids)
+	if err != nil {
+		return fmt.Errorf("list reminders: %w", err)
+	}
+	defer rows.Close()
+
+	byEvent := make(map[uuid.UUID][]storage.Reminder)
+	for rows.Next() {
+		var eventID uuid.UUID
+		var reminder storage.Reminder
+		if err := rows.Scan(&eventID, &reminder.Offset, &reminder.Channel); err != nil {
+			return fmt.Errorf("list reminders: %w", err)
+		}
+		byEvent[eventID] = append(byEvent[eventID], reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list reminders: %w", err)
+	}
+	for i := range events {
+		events[i].Reminders = byEvent[events[i].ID]
+	}
+
+	return nil
+}
+
+// saveReminders replaces the stored reminders of the event.
+func saveReminders(ctx context.Context, tx pgx.Tx, event *storage.Event) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM event_reminders WHERE event_id = $1", event.ID); err != nil {
+		return fmt.Errorf("delete reminders: %w", err)
+	}
+
+	sqlInsertReminder := `
+		INSERT INTO event_reminders (event_id, notify_before, channel, position)
+		VALUES ($1, $2, $3, $4)`
+	for i, reminder := range event.Reminders {
+		if _, err := tx.Exec(ctx, sqlInsertReminder, event.ID, reminder.Offset, reminder.Channel, i); err != nil {
+			return fmt.Errorf("insert reminder: %w", err)
+		}
+	}
+
+	return nil
+}
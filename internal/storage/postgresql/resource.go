@@ -0,0 +1,209 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mrvin/calendar/internal/storage"
+)
+
+func (s *Storage) CreateResource(ctx context.Context, resource *storage.Resource) (uuid.UUID, error) {
+	sqlInsertResource := `
+		INSERT INTO resources (
+			name,
+			kind,
+			capacity,
+			attributes
+		)
+		VALUES ($1, $2, $3, COALESCE($4, '{}'::jsonb))
+		RETURNING id, created_at`
+	if err := s.db.QueryRow(ctx, sqlInsertResource,
+		resource.Name,
+		resource.Kind,
+		resource.Capacity,
+		resource.Attributes,
+	).Scan(&resource.ID, &resource.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("insert resource: %w: %q", storage.ErrResourceExists, resource.Name)
+		}
+		return uuid.Nil, fmt.Errorf("insert resource: %w", err)
+	}
+
+	return resource.ID, nil
+}
+
+func (s *Storage) GetResource(ctx context.Context, id uuid.UUID) (*storage.Resource, error) {
+	sqlGetResource := `
+		SELECT id, name, kind, capacity, attributes, created_at
+		FROM resources
+		WHERE id = $1`
+	rows, err := s.db.Query(ctx, sqlGetResource, id)
+	if err != nil {
+		return nil, fmt.Errorf("get resource: %w", err)
+	}
+	resource, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[storage.Resource])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get resource: %w: %q", storage.ErrResourceNotFound, id)
+		}
+		return nil, fmt.Errorf("get resource: %w", err)
+	}
+
+	return &resource, nil
+}
+
+func (s *Storage) ListResources(ctx context.Context, kind string, minCapacity int) ([]storage.Resource, error) {
+	sqlListResources := `
+		SELECT id, name, kind, capacity, attributes, created_at
+		FROM resources
+		WHERE ($1 = '' OR kind = $1) AND capacity >= $2
+		ORDER BY name`
+	rows, err := s.db.Query(ctx, sqlListResources, kind, minCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("list resources: %w", err)
+	}
+	resources, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Resource])
+	if err != nil {
+		return nil, fmt.Errorf("list resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+func (s *Storage) UpdateResource(ctx context.Context, id uuid.UUID, resource *storage.Resource) error {
+	sqlUpdateResource := `
+		UPDATE resources
+		SET name = $1,
+		    kind = $2,
+		    capacity = $3,
+		    attributes = COALESCE($4, '{}'::jsonb)
+		WHERE id = $5`
+	res, err := s.db.Exec(ctx, sqlUpdateResource,
+		resource.Name,
+		resource.Kind,
+		resource.Capacity,
+		resource.Attributes,
+		id,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update resource: %w: %q", storage.ErrResourceExists, resource.Name)
+		}
+		return fmt.Errorf("update resource: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("update resource: %w: %q", storage.ErrResourceNotFound, id)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteResource(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("delete resource: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	res, err := tx.Exec(ctx, "DELETE FROM resources WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete resource: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("delete resource: %w: %q", storage.ErrResourceNotFound, id)
+	}
+	sqlCancelReservations := `
+		UPDATE events
+		SET resource_ids = array_remove(resource_ids, $1)
+		WHERE resource_ids @> ARRAY[$1::uuid]`
+	if _, err := tx.Exec(ctx, sqlCancelReservations, id); err != nil {
+		return fmt.Errorf("delete resource: cancel reservations: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("delete resource: commit: %w", err)
+	}
+
+	return nil
+}
+
+// lockResources serializes the reservations of the resources until the end
+// of the transaction, as lockUserEvents does for the events of a user. The
+// rows are locked in the order of the ids, sorted by storage.SortResources,
+// so that transactions reserving several resources cannot deadlock.
+func lockResources(ctx context.Context, tx pgx.Tx, ids []uuid.UUID) error {
+	sqlLockResources := "SELECT id FROM resources WHERE id = ANY($1) ORDER BY id FOR UPDATE"
+	rows, err := tx.Query(ctx, sqlLockResources, ids)
+	if err != nil {
+		return fmt.Errorf("lock resources: %w", err)
+	}
+	locked, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return fmt.Errorf("lock resources: %w", err)
+	}
+	for _, id := range ids {
+		if !slices.Contains(locked, id) {
+			return fmt.Errorf("%w: %q", storage.ErrResourceNotFound, id)
+		}
+	}
+
+	return nil
+}
+
+// checkResources locks the resources of the event and returns a
+// storage.ResourceBusyError if other events, but the one with excludeID,
+// reserve one of them at the same time.
+func checkResources(ctx context.Context, tx pgx.Tx, event *storage.Event, excludeID uuid.UUID) error {
+	if len(event.Resources) == 0 {
+		return nil
+	}
+	if err := lockResources(ctx, tx, event.Resources); err != nil {
+		return err
+	}
+	seriesEnd, err := seriesEndTime(event)
+	if err != nil {
+		return err
+	}
+
+	sqlListCandidates := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE resource_ids && $1
+		  AND id != $2
+		  AND ($4::timestamptz IS NULL OR start_time < $4 + $5 * INTERVAL '1 second')
+		  AND (series_end_time IS NULL OR series_end_time + $5 * INTERVAL '1 second' > $3)
+		ORDER BY id`
+	rows, err := tx.Query(ctx, sqlListCandidates, event.Resources, excludeID, event.StartTime, seriesEnd, allDayMargin.Seconds())
+	if err != nil {
+		return fmt.Errorf("list reservations: %w", err)
+	}
+	candidates, err := pgx.CollectRows(rows, pgx.RowToStructByName[storage.Event])
+	if err != nil {
+		return fmt.Errorf("list reservations: %w", err)
+	}
+
+	for _, resourceID := range event.Resources {
+		var conflicts []uuid.UUID
+		for _, candidate := range candidates {
+			if !slices.Contains(candidate.Resources, resourceID) {
+				continue
+			}
+			busy, err := storage.ReservationConflicts(&candidate, event)
+			if err != nil {
+				return fmt.Errorf("check reservation: %w", err)
+			}
+			if busy {
+				conflicts = append(conflicts, candidate.ID)
+			}
+		}
+		if conflicts != nil {
+			return &storage.ResourceBusyError{ResourceID: resourceID, IDs: conflicts}
+		}
+	}
+
+	return nil
+}
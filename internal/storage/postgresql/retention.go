@@ -0,0 +1,36 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (s *Storage) DeleteExpiredEvents(ctx context.Context, now time.Time, retentionDays, limit int) (int, error) {
+	// Overrides are deleted with their series by cascade. Events being
+	// changed are left to the next batch rather than waited for.
+	sqlDeleteExpired := `
+		DELETE FROM events
+		WHERE id IN (
+			SELECT e.id
+			FROM events e
+			LEFT JOIN users u ON u.name = e.username
+			CROSS JOIN LATERAL (SELECT COALESCE(NULLIF(u.retention_days, 0), $2) AS days) r
+			WHERE e.parent_id IS NULL
+			  AND r.days > 0
+			  AND e.series_end_time < $1 - r.days * INTERVAL '1 day'
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM events o
+				WHERE o.parent_id = e.id AND o.end_time >= $1 - r.days * INTERVAL '1 day'
+			  )
+			LIMIT $3
+			FOR UPDATE OF e SKIP LOCKED
+		)`
+	res, err := s.db.Exec(ctx, sqlDeleteExpired, now, retentionDays, limit)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired events: %w", err)
+	}
+
+	return int(res.RowsAffected()), nil
+}
@@ -16,14 +16,26 @@ func (s *Storage) CreateUser(ctx context.Context, user *storage.User) error {
 			name,
 			hash_password,
 			email,
-			role
+			role,
+			time_zone,
+			conflict_policy,
+			share_free_busy
 		)
-		VALUES ($1, $2, $3, $4)`
-	if _, err := s.db.Exec(ctx, sqlInsertUser,
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("insert user: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, sqlInsertUser,
 		user.Name,
 		user.HashPassword,
 		user.Email,
 		user.Role,
+		user.TimeZone,
+		user.ConflictPolicy,
+		user.ShareFreeBusy,
 	); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 = unique_violation
@@ -31,13 +43,20 @@ func (s *Storage) CreateUser(ctx context.Context, user *storage.User) error {
 		}
 		return fmt.Errorf("insert user: %w", err)
 	}
+	if err := saveWorkSchedule(ctx, tx, user.Name, &user.WorkSchedule); err != nil {
+		return fmt.Errorf("insert user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("insert user: commit: %w", err)
+	}
 
 	return nil
 }
 
 func (s *Storage) GetUser(ctx context.Context, name string) (*storage.User, error) {
 	sqlGetUser := `
-		SELECT name, hash_password, email, role
+		SELECT name, hash_password, email, role, time_zone, conflict_policy, share_free_busy, retention_days, webhook_url
 		FROM users
 		WHERE name = $1`
 	var user storage.User
@@ -46,16 +65,68 @@ func (s *Storage) GetUser(ctx context.Context, name string) (*storage.User, erro
 		&user.HashPassword,
 		&user.Email,
 		&user.Role,
+		&user.TimeZone,
+		&user.ConflictPolicy,
+		&user.ShareFreeBusy,
+		&user.RetentionDays,
+		&user.WebhookURL,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("get user: %w: %q", storage.ErrUserNotFound, name)
 		}
 		return nil, fmt.Errorf("get user: %q: %w", name, err)
 	}
+	schedules, err := workSchedules(ctx, s.db, []string{name})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if ws, ok := schedules[name]; ok {
+		user.WorkSchedule = *ws
+	}
 
 	return &user, nil
 }
 
+func (s *Storage) UpdateUserSettings(ctx context.Context, user *storage.User) error {
+	sqlUpdateSettings := `
+		UPDATE users
+		SET time_zone = $1,
+		    conflict_policy = $2,
+		    share_free_busy = $3,
+		    retention_days = $4,
+		    webhook_url = $5
+		WHERE name = $6`
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("update user settings: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	res, err := tx.Exec(ctx, sqlUpdateSettings,
+		user.TimeZone,
+		user.ConflictPolicy,
+		user.ShareFreeBusy,
+		user.RetentionDays,
+		user.WebhookURL,
+		user.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("update user settings: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("update user settings: %w: %q", storage.ErrUserNotFound, user.Name)
+	}
+	if err := saveWorkSchedule(ctx, tx, user.Name, &user.WorkSchedule); err != nil {
+		return fmt.Errorf("update user settings: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("update user settings: commit: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Storage) DeleteUser(ctx context.Context, name string) error {
 	sqlDeleteUser := `DELETE FROM users WHERE name = $1`
 	res, err := s.db.Exec(ctx, sqlDeleteUser, name)
@@ -68,3 +139,121 @@ func (s *Storage) DeleteUser(ctx context.Context, name string) error {
 
 	return nil
 }
+
+// saveWorkSchedule replaces the work schedule of the user.
+func saveWorkSchedule(ctx context.Context, tx pgx.Tx, username string, ws *storage.WorkSchedule) error {
+	var dayStart, dayEnd *string
+	weekdays := []string{}
+	var timeZone string
+	if wh := ws.WorkingHours; wh != nil {
+		dayStart, dayEnd = &wh.Start, &wh.End
+		if wh.Weekdays != nil {
+			weekdays = wh.Weekdays
+		}
+		timeZone = wh.TimeZone
+	}
+	sqlUpdateSchedule := `
+		UPDATE users
+		SET work_day_start = $1,
+		    work_day_end = $2,
+		    work_weekdays = $3,
+		    work_time_zone = $4,
+		    decline_unavailable = $5
+		WHERE name = $6`
+	if _, err := tx.Exec(ctx, sqlUpdateSchedule, dayStart, dayEnd, weekdays, timeZone, ws.DeclineUnavailable, username); err != nil {
+		return fmt.Errorf("save work schedule: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM out_of_office WHERE username = $1", username); err != nil {
+		return fmt.Errorf("save work schedule: %w", err)
+	}
+	sqlInsertOutOfOffice := "INSERT INTO out_of_office (username, start_time, end_time) VALUES ($1, $2, $3)"
+	for _, ooo := range ws.OutOfOffice {
+		if _, err := tx.Exec(ctx, sqlInsertOutOfOffice, username, ooo.StartTime, ooo.EndTime); err != nil {
+			return fmt.Errorf("save work schedule: out of office: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// workSchedules returns the work schedules of the existing users by name.
+func workSchedules(ctx context.Context, db querier, usernames []string) (map[string]*storage.WorkSchedule, error) {
+	sqlListSchedules := `
+		SELECT name, work_day_start, work_day_end, work_weekdays, work_time_zone, decline_unavailable
+		FROM users
+		WHERE name = ANY($1)`
+	rows, err := db.Query(ctx, sqlListSchedules, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("list work schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make(map[string]*storage.WorkSchedule, len(usernames))
+	for rows.Next() {
+		var name string
+		var dayStart, dayEnd *string
+		var wh storage.WorkingHours
+		var ws storage.WorkSchedule
+		if err := rows.Scan(&name, &dayStart, &dayEnd, &wh.Weekdays, &wh.TimeZone, &ws.DeclineUnavailable); err != nil {
+			return nil, fmt.Errorf("list work schedules: %w", err)
+		}
+		if dayStart != nil && dayEnd != nil {
+			wh.Start, wh.End = *dayStart, *dayEnd
+			ws.WorkingHours = &wh
+		}
+		schedules[name] = &ws
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list work schedules: %w", err)
+	}
+
+	sqlListOutOfOffice := `
+		SELECT username, start_time, end_time
+		FROM out_of_office
+		WHERE username = ANY($1)
+		ORDER BY start_time`
+	rows, err = db.Query(ctx, sqlListOutOfOffice, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("list out of office: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var username string
+		var ooo storage.OutOfOffice
+		if err := rows.Scan(&username, &ooo.StartTime, &ooo.EndTime); err != nil {
+			return nil, fmt.Errorf("list out of office: %w", err)
+		}
+		if ws, ok := schedules[username]; ok {
+			ws.OutOfOffice = append(ws.OutOfOffice, ooo)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list out of office: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// notifiedUsers returns the emails and webhooks of the users by name.
+func notifiedUsers(ctx context.Context, db querier, usernames []string) (map[string]storage.User, error) {
+	rows, err := db.Query(ctx, "SELECT name, email, webhook_url FROM users WHERE name = ANY($1)", usernames)
+	if err != nil {
+		return nil, fmt.Errorf("list notified users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make(map[string]storage.User, len(usernames))
+	for rows.Next() {
+		var user storage.User
+		if err := rows.Scan(&user.Name, &user.Email, &user.WebhookURL); err != nil {
+			return nil, fmt.Errorf("list notified users: %w", err)
+		}
+		users[user.Name] = user
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list notified users: %w", err)
+	}
+
+	return users, nil
+}
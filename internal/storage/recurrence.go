@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mrvin/calendar/pkg/rrule"
+)
+
+// OverlapHorizon bounds the overlap check between two infinite series.
+const OverlapHorizon = 5 * 365 * 24 * time.Hour
+
+// MaxListRange bounds the period of the listed occurrences.
+const MaxListRange = 366 * 24 * time.Hour
+
+func (e *Event) IsRecurring() bool {
+	return e.RRule != ""
+}
+
+// SeriesEnd returns the end time of the last occurrence of the event.
+// It returns false if the event repeats forever.
+func (e *Event) SeriesEnd() (time.Time, bool, error) {
+	if !e.IsRecurring() {
+		return e.EndTime, true, nil
+	}
+	rule, err := rrule.Parse(e.RRule)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("event %s: %w", e.ID, err)
+	}
+	dtStart, err := e.dtStart()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	last, ok := rule.Last(dtStart)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	return last.Add(e.EndTime.Sub(e.StartTime)).In(e.StartTime.Location()), true, nil
+}
+
+// Occurrences returns the occurrences of the event which overlap [start, end)
+// in ascending order. A non-recurring event is its own single occurrence.
+// Occurrences keep the wall clock time of the event in its time zone.
+func (e *Event) Occurrences(start, end time.Time) ([]Event, error) {
+	if !e.IsRecurring() {
+		if e.StartTime.Before(end) && e.EndTime.After(start) {
+			return []Event{*e}, nil
+		}
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(e.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.ID, err)
+	}
+	dtStart, err := e.dtStart()
+	if err != nil {
+		return nil, err
+	}
+	duration := e.EndTime.Sub(e.StartTime)
+
+	var occurrences []Event
+	for occStart := range rule.From(dtStart, start.Add(-duration)) {
+		if !occStart.Before(end) {
+			break
+		}
+		occStart = occStart.In(e.StartTime.Location())
+		occEnd := occStart.Add(duration)
+		if occEnd.After(start) && !e.isExcluded(occStart) {
+			recurrenceID := occStart
+			occurrence := *e
+			occurrence.StartTime = occStart
+			occurrence.EndTime = occEnd
+			occurrence.RecurrenceID = &recurrenceID
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// Overlaps reports whether any occurrence of a overlaps any occurrence of b.
+// All-day occurrences take their dates in the time zone of their event, see
+// Span. Two infinite series are compared within OverlapHorizon only.
+func Overlaps(a, b *Event) (bool, error) {
+	if !a.IsRecurring() && !b.IsRecurring() {
+		spanA, err := a.span()
+		if err != nil {
+			return false, err
+		}
+		spanB, err := b.span()
+		if err != nil {
+			return false, err
+		}
+		return spanA.overlaps(spanB), nil
+	}
+
+	start := a.StartTime
+	if b.StartTime.After(start) {
+		start = b.StartTime
+	}
+	end := start.Add(OverlapHorizon)
+	for _, event := range []*Event{a, b} {
+		seriesEnd, ok, err := event.SeriesEnd()
+		if err != nil {
+			return false, err
+		}
+		if ok && seriesEnd.Before(end) {
+			end = seriesEnd
+		}
+	}
+	if a.AllDay || b.AllDay {
+		// The spans of the occurrences are up to MaxZoneOffset from their
+		// stored times either way.
+		start, end = start.Add(-2*MaxZoneOffset), end.Add(2*MaxZoneOffset)
+	}
+	if !start.Before(end) {
+		return false, nil
+	}
+
+	spansA, err := a.occurrenceSpans(start, end)
+	if err != nil {
+		return false, err
+	}
+	spansB, err := b.occurrenceSpans(start, end)
+	if err != nil {
+		return false, err
+	}
+	for i, j := 0, 0; i < len(spansA) && j < len(spansB); {
+		if spansA[i].overlaps(spansB[j]) {
+			return true, nil
+		}
+		if spansA[i].end.Before(spansB[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return false, nil
+}
+
+// span is the time taken by an event or occurrence.
+type span struct {
+	start, end time.Time
+}
+
+func (e *Event) span() (span, error) {
+	start, end, err := e.Span()
+
+	return span{start: start, end: end}, err
+}
+
+func (s span) overlaps(other span) bool {
+	return s.start.Before(other.end) && s.end.After(other.start)
+}
+
+// occurrenceSpans returns the spans of the occurrences of the event which
+// overlap [start, end) in ascending order.
+func (e *Event) occurrenceSpans(start, end time.Time) ([]span, error) {
+	occurrences, err := e.Occurrences(start, end)
+	if err != nil {
+		return nil, err
+	}
+	spans := make([]span, len(occurrences))
+	for i := range occurrences {
+		if spans[i], err = occurrences[i].span(); err != nil {
+			return nil, err
+		}
+	}
+
+	return spans, nil
+}
+
+// HasOccurrence reports whether the recurring event has a not excluded
+// occurrence starting at recurrenceID.
+func (e *Event) HasOccurrence(recurrenceID time.Time) (bool, error) {
+	if !e.IsRecurring() || e.isExcluded(recurrenceID) {
+		return false, nil
+	}
+	rule, err := rrule.Parse(e.RRule)
+	if err != nil {
+		return false, fmt.Errorf("event %s: %w", e.ID, err)
+	}
+	dtStart, err := e.dtStart()
+	if err != nil {
+		return false, err
+	}
+	for occStart := range rule.From(dtStart, recurrenceID) {
+		if occStart.Equal(recurrenceID) {
+			return true, nil
+		}
+		if occStart.After(recurrenceID) {
+			break
+		}
+	}
+
+	return false, nil
+}
+
+// Exclude adds recurrenceID to the excluded occurrences of the event.
+func (e *Event) Exclude(recurrenceID time.Time) {
+	if !e.isExcluded(recurrenceID) {
+		e.ExDates = append(e.ExDates, recurrenceID)
+	}
+}
+
+// TruncateBefore ends the series before the occurrence starting at
+// recurrenceID and drops the exclusions after it.
+func (e *Event) TruncateBefore(recurrenceID time.Time) error {
+	rule, err := rrule.Parse(e.RRule)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", e.ID, err)
+	}
+	rule.Count = 0
+	rule.SetUntil(recurrenceID.Add(-time.Second).Truncate(time.Second))
+	e.RRule = rule.String()
+	e.ExDates = slices.DeleteFunc(e.ExDates, func(exDate time.Time) bool {
+		return !exDate.Before(recurrenceID)
+	})
+
+	return nil
+}
+
+// RuleFrom returns the recurrence rule of the part of the series which starts
+// at recurrenceID, with COUNT reduced by the occurrences before it.
+func (e *Event) RuleFrom(recurrenceID time.Time) (string, error) {
+	rule, err := rrule.Parse(e.RRule)
+	if err != nil {
+		return "", fmt.Errorf("event %s: %w", e.ID, err)
+	}
+	if rule.Count != 0 {
+		dtStart, err := e.dtStart()
+		if err != nil {
+			return "", err
+		}
+		before := 0
+		for occStart := range rule.All(dtStart) {
+			if !occStart.Before(recurrenceID) {
+				break
+			}
+			before++
+		}
+		rule.Count -= before
+	}
+
+	return rule.String(), nil
+}
+
+func (e *Event) isExcluded(start time.Time) bool {
+	return slices.ContainsFunc(e.ExDates, start.Equal)
+}
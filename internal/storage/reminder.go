@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxReminders is the number of reminders an event may have.
+const MaxReminders = 10
+
+// MaxZoneOffset is the largest offset of a time zone from UTC.
+const MaxZoneOffset = 14 * time.Hour
+
+// Channel is the way a reminder reaches the user.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	// ChannelWebhook posts the reminder to User.WebhookURL.
+	ChannelWebhook Channel = "webhook"
+)
+
+// Reminder notifies the owner of an event through the channel Offset before
+// the start of each of its occurrences.
+type Reminder struct {
+	Offset  time.Duration `json:"offset"`
+	Channel Channel       `json:"channel"`
+}
+
+// NotificationStatus is the state of the dispatch of a notification.
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+)
+
+// Notification is a reminder of an event or occurrence due at Time.
+type Notification struct {
+	// ID is the ID of the message of the notification, the same each time
+	// the notification is dispatched.
+	ID       uuid.UUID
+	Event    Event
+	Reminder Reminder
+	Time     time.Time
+	Status   NotificationStatus
+	// Email is the address of the owner of the event.
+	Email string
+	// WebhookURL is the webhook of the owner of the event.
+	WebhookURL string
+	// CreatedAt is when the notification was first recorded.
+	CreatedAt time.Time
+	// DeliveredAt is when the sender delivered the message, zero until then.
+	DeliveredAt time.Time
+}
+
+// Recipient returns the address the notification is sent to through the
+// channel of its reminder.
+func (n *Notification) Recipient() string {
+	if n.Reminder.Channel == ChannelWebhook {
+		return n.WebhookURL
+	}
+
+	return n.Email
+}
+
+// ParseChannel parses the channel of a reminder: email or webhook.
+func ParseChannel(s string) (Channel, error) {
+	channel := Channel(s)
+	if channel != ChannelEmail && channel != ChannelWebhook {
+		return "", fmt.Errorf("invalid reminder channel %q", s)
+	}
+
+	return channel, nil
+}
+
+// CheckWebhookURL returns an error unless the webhook URL is empty or an
+// absolute http or https URL.
+func CheckWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: want an http or https URL", webhookURL)
+	}
+
+	return nil
+}
+
+// CheckReminders returns an error if there are more than MaxReminders
+// reminders, one of them is after the start or two are the same.
+func CheckReminders(reminders []Reminder) error {
+	if len(reminders) > MaxReminders {
+		return fmt.Errorf("at most %d reminders", MaxReminders)
+	}
+	seen := make(map[Reminder]bool, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.Offset < 0 {
+			return fmt.Errorf("reminder offset %s must not be negative", reminder.Offset)
+		}
+		if _, err := ParseChannel(string(reminder.Channel)); err != nil {
+			return err
+		}
+		if seen[reminder] {
+			return fmt.Errorf("duplicate reminder %s by %s", reminder.Offset, reminder.Channel)
+		}
+		seen[reminder] = true
+	}
+
+	return nil
+}
+
+// DefaultReminders returns the reminders of the events created in the
+// calendar without any, nil if there are none.
+func (c *Calendar) DefaultReminders() []Reminder {
+	if c.NotifyBefore == nil {
+		return nil
+	}
+
+	return []Reminder{{Offset: *c.NotifyBefore, Channel: ChannelEmail}}
+}
+
+// Notifications returns the notifications of the reminders of the event or
+// of its occurrences due within [start, end], without the email of the owner.
+func (e *Event) Notifications(start, end time.Time) ([]Notification, error) {
+	var notifications []Notification
+	for _, reminder := range e.Reminders {
+		// The day of an all-day event starts at midnight in its time zone.
+		from, to := start.Add(reminder.Offset), end.Add(reminder.Offset)
+		if e.AllDay {
+			from, to = from.Add(-MaxZoneOffset), to.Add(MaxZoneOffset)
+		}
+		occurrences, err := e.Occurrences(from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, occurrence := range occurrences {
+			notifyTime, err := occurrence.NotifyTime(reminder.Offset)
+			if err != nil {
+				return nil, err
+			}
+			if !notifyTime.Before(start) && !notifyTime.After(end) {
+				//nolint:exhaustruct
+				notifications = append(notifications, Notification{
+					Event:    occurrence,
+					Reminder: reminder,
+					Time:     notifyTime,
+				})
+			}
+		}
+	}
+
+	return notifications, nil
+}
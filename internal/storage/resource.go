@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource is a room or a piece of equipment booked by events. A resource is
+// never reserved by overlapping events, whatever their transparency.
+//
+//nolint:tagliatelle
+type Resource struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Kind is the type of the resource: room, projector, car...
+	Kind string `json:"kind"`
+	// Capacity is the number of people the resource holds, 0 if it does
+	// not apply.
+	Capacity   int               `json:"capacity,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// ResourceBusyError lists the events which already reserved the resource.
+type ResourceBusyError struct {
+	ResourceID uuid.UUID
+	IDs        []uuid.UUID
+}
+
+func (e *ResourceBusyError) Error() string {
+	return fmt.Sprintf("%s: resource %s is booked by %v", ErrResourceBusy, e.ResourceID, e.IDs)
+}
+
+func (e *ResourceBusyError) Unwrap() error {
+	return ErrResourceBusy
+}
+
+// KeepResources sets the resources of the event changed from old to those
+// of old if not given. The resources are sorted and deduplicated.
+func (e *Event) KeepResources(old *Event) {
+	if e.Resources == nil {
+		e.Resources = old.Resources
+	}
+	e.Resources = SortResources(e.Resources)
+}
+
+// SortResources returns the sorted ids without duplicates, the order the
+// resources are locked in.
+func SortResources(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return nil
+	}
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return slices.Compact(sorted)
+}
+
+// ReservationConflicts reports whether the events reserve a common resource
+// at the same time.
+func ReservationConflicts(a, b *Event) (bool, error) {
+	if !slices.ContainsFunc(a.Resources, func(id uuid.UUID) bool { return slices.Contains(b.Resources, id) }) {
+		return false, nil
+	}
+
+	return Overlaps(a, b)
+}
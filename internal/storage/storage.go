@@ -12,34 +12,214 @@ var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
 
-	ErrDateBusy      = errors.New("date already busy")
-	ErrEventNotFound = errors.New("event not found")
+	ErrDateBusy           = errors.New("date already busy")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrOccurrenceNotFound = errors.New("occurrence not found")
+	ErrDuplicateUID       = errors.New("event with this uid already exists")
+	ErrEventChanged       = errors.New("event changed since read")
+	ErrAttendeeNotFound   = errors.New("attendee not found")
+	ErrOutdatedReply      = errors.New("reply to an outdated revision of the event")
+
+	ErrInvitationNotFound = errors.New("invitation not found")
+
+	ErrFeedNotFound = errors.New("feed not found")
+
+	ErrCalendarExists   = errors.New("calendar with this name already exists")
+	ErrCalendarNotFound = errors.New("calendar not found")
+
+	ErrGrantNotFound = errors.New("grant not found")
+
+	ErrResourceExists   = errors.New("resource with this name already exists")
+	ErrResourceNotFound = errors.New("resource not found")
+	ErrResourceBusy     = errors.New("resource already booked")
+
+	ErrBookingLinkExists   = errors.New("booking link with this slug already exists")
+	ErrBookingLinkNotFound = errors.New("booking link not found")
+
+	ErrPollNotFound       = errors.New("poll not found")
+	ErrPollOptionNotFound = errors.New("poll option not found")
+	ErrPollFinalized      = errors.New("poll already finalized")
+
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// Scope selects which occurrences of a recurring event are changed.
+type Scope string
+
+const (
+	ScopeThis      Scope = "this"
+	ScopeFollowing Scope = "following"
+	ScopeAll       Scope = "all"
 )
 
 type UserStorage interface {
 	CreateUser(ctx context.Context, user *User) error
 	GetUser(ctx context.Context, name string) (*User, error)
+	// UpdateUserSettings replaces the settings of the user: the time zone,
+	// the conflict policy, the sharing of free/busy time, the work schedule
+	// and the retention of the events.
+	UpdateUserSettings(ctx context.Context, user *User) error
 	DeleteUser(ctx context.Context, name string) error
 }
 
 type EventStorage interface {
 	CreateEvent(ctx context.Context, event *Event) (uuid.UUID, error)
+	// GetEvent and ListEvents also return the events the user is invited to.
 	GetEvent(ctx context.Context, username string, id uuid.UUID) (*Event, error)
 	ListEvents(ctx context.Context, username string, start, end time.Time) ([]Event, error)
+	// GetEventOwner returns the username of the owner of the event.
+	GetEventOwner(ctx context.Context, id uuid.UUID) (string, error)
+	// ListCalendarEvents is ListEvents limited to the events of the user in
+	// the calendars.
+	ListCalendarEvents(ctx context.Context, username string, calendarIDs []uuid.UUID, start, end time.Time) ([]Event, error)
+	// ListSeries returns the events of the user as stored: recurring events
+	// are not expanded and overrides of occurrences are returned separately.
+	ListSeries(ctx context.Context, username string) ([]Event, error)
+	// UpdateEvent replaces the exclusions of a recurring event only if
+	// event.ExDates is not nil. If event.Revision is not 0, it fails with
+	// ErrEventChanged unless the stored event has this revision.
 	UpdateEvent(ctx context.Context, username string, id uuid.UUID, event *Event) error
-	DeleteEvent(ctx context.Context, username string, id uuid.UUID) error
+	// DeleteEvent deletes the event with the overrides of its occurrences.
+	// If revision is not 0, it fails with ErrEventChanged unless the stored
+	// event has this revision.
+	DeleteEvent(ctx context.Context, username string, id uuid.UUID, revision int64) error
+	UpdateEventOccurrence(ctx context.Context, username string, id uuid.UUID, recurrenceID time.Time, scope Scope, event *Event) error
+	DeleteEventOccurrence(ctx context.Context, username string, id uuid.UUID, recurrenceID time.Time, scope Scope) error
+	// UpdateAttendeeStatus stores the response of the attendee with the
+	// username to the invitation to the event.
+	UpdateAttendeeStatus(ctx context.Context, username string, id uuid.UUID, status PartStat) error
+	// ApplyReply stores the response of the attendee with the email to the
+	// events with the UID of the reply, ErrOutdatedReply if the events were
+	// rescheduled after the reply was sent.
+	ApplyReply(ctx context.Context, reply *Reply) error
+	// DeleteExpiredEvents deletes up to limit events, with the overrides of
+	// their occurrences, whose last occurrence ended more than the retention
+	// of their user before now: User.RetentionDays, retentionDays if 0. The
+	// events are kept forever if the retention is 0. It returns the number
+	// of deleted events, the attendees are not notified.
+	DeleteExpiredEvents(ctx context.Context, now time.Time, retentionDays, limit int) (int, error)
+}
+
+type FeedStorage interface {
+	CreateFeed(ctx context.Context, feed *Feed) (uuid.UUID, error)
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*Feed, error)
+	ListFeeds(ctx context.Context, username string) ([]Feed, error)
+	UpdateFeedToken(ctx context.Context, username string, id uuid.UUID, tokenHash string) error
+	DeleteFeed(ctx context.Context, username string, id uuid.UUID) error
+}
+
+// CalendarStorage keeps the named calendars of the users. Deleting a calendar
+// deletes its events.
+type CalendarStorage interface {
+	CreateCalendar(ctx context.Context, calendar *Calendar) (uuid.UUID, error)
+	GetCalendar(ctx context.Context, username string, id uuid.UUID) (*Calendar, error)
+	ListCalendars(ctx context.Context, username string) ([]Calendar, error)
+	UpdateCalendar(ctx context.Context, username string, id uuid.UUID, calendar *Calendar) error
+	DeleteCalendar(ctx context.Context, username string, id uuid.UUID) error
+}
+
+// GrantStorage keeps the access of the users to the events of each other.
+type GrantStorage interface {
+	// SetGrant creates or replaces the grant of the owner to the grantee,
+	// ErrUserNotFound if there is no such grantee.
+	SetGrant(ctx context.Context, grant *Grant) error
+	GetGrant(ctx context.Context, owner, grantee string) (*Grant, error)
+	// ListGrants returns the grants of the owner.
+	ListGrants(ctx context.Context, owner string) ([]Grant, error)
+	// ListGrantsTo returns the grants to the grantee.
+	ListGrantsTo(ctx context.Context, grantee string) ([]Grant, error)
+	DeleteGrant(ctx context.Context, owner, grantee string) error
+}
+
+// ResourceStorage keeps the rooms and equipment shared by all users. Deleting
+// a resource cancels its reservations.
+type ResourceStorage interface {
+	CreateResource(ctx context.Context, resource *Resource) (uuid.UUID, error)
+	GetResource(ctx context.Context, id uuid.UUID) (*Resource, error)
+	// ListResources returns the resources of the kind, any if empty, which
+	// hold at least minCapacity people.
+	ListResources(ctx context.Context, kind string, minCapacity int) ([]Resource, error)
+	UpdateResource(ctx context.Context, id uuid.UUID, resource *Resource) error
+	DeleteResource(ctx context.Context, id uuid.UUID) error
+}
+
+// BookingStorage keeps the booking links of the users and books their slots.
+type BookingStorage interface {
+	CreateBookingLink(ctx context.Context, link *BookingLink) (uuid.UUID, error)
+	GetBookingLink(ctx context.Context, username string, id uuid.UUID) (*BookingLink, error)
+	GetBookingLinkBySlug(ctx context.Context, slug string) (*BookingLink, error)
+	ListBookingLinks(ctx context.Context, username string) ([]BookingLink, error)
+	UpdateBookingLink(ctx context.Context, username string, id uuid.UUID, link *BookingLink) error
+	DeleteBookingLink(ctx context.Context, username string, id uuid.UUID) error
+	// Book creates the event as CreateEvent does, but fails with ErrDateBusy
+	// if the event with the buffer around it overlaps busy events of the
+	// user, whatever the conflict policy.
+	Book(ctx context.Context, event *Event, buffer time.Duration) (uuid.UUID, error)
+}
+
+// PollStorage keeps the polls of the users on the time of meetings. A poll is
+// visible to its organizer and its invitees.
+type PollStorage interface {
+	// CreatePoll returns ErrUserNotFound if there is no such invitee.
+	CreatePoll(ctx context.Context, poll *Poll) (uuid.UUID, error)
+	// GetPoll returns the poll with its votes.
+	GetPoll(ctx context.Context, username string, id uuid.UUID) (*Poll, error)
+	// ListPolls returns the polls the user organizes or is invited to,
+	// without their votes.
+	ListPolls(ctx context.Context, username string) ([]Poll, error)
+	DeletePoll(ctx context.Context, username string, id uuid.UUID) error
+	// SetVotes replaces the votes of the invitee on the poll,
+	// ErrPollFinalized if it no longer takes votes.
+	SetVotes(ctx context.Context, username string, id uuid.UUID, votes []PollVote) error
+	// FinalizePoll creates the event of the option of the poll of the
+	// organizer as CreateEvent does and closes the poll at once,
+	// ErrPollFinalized if it is already closed.
+	FinalizePoll(ctx context.Context, username string, id, optionID uuid.UUID, event *Event) (uuid.UUID, error)
+}
+
+// InvitationStorage is the outbox of the messages to the attendees, filled
+// in by the changes of the events.
+type InvitationStorage interface {
+	// ListInvitations returns up to limit invitations, oldest first.
+	ListInvitations(ctx context.Context, limit int) ([]Invitation, error)
+	// DeleteInvitation removes the sent invitation.
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
 }
 
 type Storage interface {
 	UserStorage
 	EventStorage
+	FeedStorage
+	CalendarStorage
+	GrantStorage
+	ResourceStorage
+	BookingStorage
+	PollStorage
+	InvitationStorage
 }
 
+// MaxRetentionDays is the longest retention of the events of a user.
+const MaxRetentionDays = 36500
+
 type User struct {
 	Name         string
 	HashPassword string
 	Email        string
 	Role         string
+	// TimeZone is the IANA name of the default time zone of the events of
+	// the user, UTC if empty.
+	TimeZone string
+	// ConflictPolicy applies to overlapping busy events, empty means reject.
+	ConflictPolicy ConflictPolicy
+	// ShareFreeBusy lets other users see when the user is busy.
+	ShareFreeBusy bool
+	WorkSchedule
+	// RetentionDays are the days the events of the user are kept after they
+	// end, at most MaxRetentionDays, the default retention if 0.
+	RetentionDays int
+	// WebhookURL is where the reminders by webhook are posted, none if
+	// empty.
+	WebhookURL string
 
 	//	UpdatedAt   time.Time
 	//	CreatedAt   time.Time
@@ -47,14 +227,83 @@ type User struct {
 
 //nolint:tagliatelle
 type Event struct {
-	ID           uuid.UUID      `json:"id"`
-	Title        string         `json:"title"`
-	Description  string         `json:"description"`
-	StartTime    time.Time      `json:"start_time"`
-	EndTime      time.Time      `json:"end_time"`
-	NotifyBefore *time.Duration `json:"notify_before"`
-	Username     string         `json:"-"`
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	// An all-day event lasts whole days whatever the time zone of the viewer:
+	// its StartTime is the midnight in UTC of the first day and its EndTime
+	// is the midnight in UTC of the day after the last one.
+	AllDay bool `json:"all_day,omitempty"`
+	// TimeZone is the IANA name of the time zone in which a recurring event
+	// keeps its wall clock time, UTC if empty.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Free events do not block the time of the user, empty means busy.
+	Transparency Transparency `json:"transparency,omitempty"`
+	// Reminders are in order. A new event of a calendar gets the default
+	// reminder of the calendar if nil.
+	Reminders []Reminder  `db:"-" json:"reminders,omitempty"`
+	RRule     string      `json:"rrule,omitempty"`
+	ExDates   []time.Time `json:"exdates,omitempty"`
+	// An occurrence of a recurring event carries its original start time in
+	// RecurrenceID; an override of a single occurrence also refers to its
+	// series by ParentID.
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	RecurrenceID *time.Time `json:"recurrence_id,omitempty"`
+	// CalendarID is the calendar of the user the event belongs to, none if
+	// nil. Overrides of occurrences belong to the calendar of their series.
+	CalendarID *uuid.UUID `json:"calendar_id,omitempty"`
+	// UID is the iCalendar UID of an imported event, unique among the
+	// series of the user.
+	UID string `json:"uid,omitempty"`
+	// Sequence is the revision of the event sent to its attendees, SEQUENCE
+	// of RFC 5545, incremented by the storage when the event is rescheduled.
+	Sequence int `json:"sequence,omitempty"`
+	// Revision is incremented by the storage on every change of the stored
+	// event, a series also on the changes of its exclusions and overrides.
+	Revision int64  `json:"-"`
+	Username string `json:"-"`
+	// Attendees are invited by the owner of the event. An update keeps them
+	// if nil. Their emails are filled in by the storage.
+	Attendees []Attendee `db:"-" json:"attendees,omitempty"`
+	// Resources are the ids of the resources reserved by the event, in
+	// order. An update keeps them if nil, overrides of occurrences start with
+	// those of their series.
+	Resources []uuid.UUID `db:"resource_ids" json:"resources,omitempty"`
+	// Organizer is the owner of an event returned to one of its attendees.
+	// Not stored.
+	Organizer string `db:"-" json:"organizer,omitempty"`
+	// Conflicts are the busy events overlapped by the event, set by the
+	// storage on save if the conflict policy of the user is warn. Not stored.
+	Conflicts []uuid.UUID `db:"-" json:"-"`
 
 	//	UpdatedAt   time.Time
 	//	CreatedAt   time.Time
 }
+
+// Feed is a read-only subscription to the events of the user. Only the hash
+// of its secret token is stored.
+//
+//nolint:tagliatelle
+type Feed struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	Username  string    `json:"-"`
+}
+
+// Calendar is a named set of the events of the user, such as Work or Personal.
+//
+//nolint:tagliatelle
+type Calendar struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Color string    `json:"color,omitempty"`
+	// NotifyBefore is the email reminder of the events created in the
+	// calendar without any, see DefaultReminders.
+	NotifyBefore *time.Duration `json:"notify_before,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	Username     string         `json:"-"`
+}
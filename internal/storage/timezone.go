@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var locations sync.Map // IANA name -> *time.Location
+
+// LoadLocation returns the time zone with the IANA name, UTC for an empty one.
+// Time zones are cached, they are used on every expansion of a series.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil //nolint:forcetypeassert
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %q: %w", name, err)
+	}
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// Location returns the time zone of the event. All-day events are in UTC,
+// their dates do not depend on the time zone.
+func (e *Event) Location() (*time.Location, error) {
+	if e.AllDay {
+		return time.UTC, nil
+	}
+
+	return LoadLocation(e.TimeZone)
+}
+
+// dtStart returns the start time of the event on the wall clock of its time
+// zone, from which the occurrences are generated.
+func (e *Event) dtStart() (time.Time, error) {
+	loc, err := e.Location()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("event %s: %w", e.ID, err)
+	}
+
+	return e.StartTime.In(loc), nil
+}
+
+// NotifyTime returns the time of the reminder offset before the start of the
+// event or occurrence. The day of an all-day event starts at midnight in the
+// time zone of the event, the default time zone of its user.
+func (e *Event) NotifyTime(offset time.Duration) (time.Time, error) {
+	start, _, err := e.Span()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return start.Add(-offset), nil
+}
+
+// Span returns the time taken by the event or occurrence. The dates of an
+// all-day event span from midnight to midnight in the time zone of the event,
+// up to MaxZoneOffset from their UTC midnights.
+func (e *Event) Span() (time.Time, time.Time, error) {
+	if !e.AllDay {
+		return e.StartTime, e.EndTime, nil
+	}
+	loc, err := LoadLocation(e.TimeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("event %s: %w", e.ID, err)
+	}
+
+	return midnight(e.StartTime, loc), midnight(e.EndTime, loc), nil
+}
+
+// midnight returns the midnight in loc of the date of the all-day bound t.
+func midnight(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
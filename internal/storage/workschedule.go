@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxOutOfOffice is the number of out-of-office periods a user may have.
+const MaxOutOfOffice = 100
+
+// Availability tells why an invited user cannot attend the event according
+// to their work schedule, empty if they can.
+type Availability string
+
+const (
+	AvailabilityOutsideWorkingHours Availability = "outside-working-hours"
+	AvailabilityOutOfOffice         Availability = "out-of-office"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WorkSchedule is when the user works. The new invitations of the user
+// outside of it are flagged or declined.
+type WorkSchedule struct {
+	// WorkingHours are optional, the user works at any time if nil.
+	WorkingHours *WorkingHours
+	// OutOfOffice are the periods in which the user does not work, by start
+	// time.
+	OutOfOffice []OutOfOffice
+	// DeclineUnavailable declines the invitations outside of the schedule
+	// instead of only flagging them.
+	DeclineUnavailable bool
+}
+
+// WorkingHours are the same hours of the given weekdays.
+//
+//nolint:tagliatelle
+type WorkingHours struct {
+	// Start and End are the times of day in the form 15:04, End may be 24:00.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Weekdays are the days as in RRULE BYDAY: MO, TU..., every day if empty.
+	Weekdays []string `json:"weekdays,omitempty"`
+	// TimeZone is the IANA name of the time zone of the hours, UTC if empty.
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+//nolint:tagliatelle
+type OutOfOffice struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// ParseClock parses the time of day in the form 15:04 as the time since the
+// midnight. The end of the day is 24:00.
+func ParseClock(s string) (time.Duration, error) {
+	if s == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseWeekdays parses the days of the week given as in RRULE BYDAY: MO, TU...
+func ParseWeekdays(days []string) ([]time.Weekday, error) {
+	result := make([]time.Weekday, 0, len(days))
+	for _, day := range days {
+		weekday, ok := weekdays[strings.ToUpper(day)]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", day)
+		}
+		result = append(result, weekday)
+	}
+
+	return result, nil
+}
+
+// Check returns an error if the working hours do not start before they end
+// or an out-of-office period does not end after it starts.
+func (w *WorkSchedule) Check() error {
+	if wh := w.WorkingHours; wh != nil {
+		start, err := ParseClock(wh.Start)
+		if err != nil {
+			return fmt.Errorf("working hours: %w", err)
+		}
+		end, err := ParseClock(wh.End)
+		if err != nil {
+			return fmt.Errorf("working hours: %w", err)
+		}
+		if start >= end {
+			return errors.New("working hours must start before they end")
+		}
+		if _, err := ParseWeekdays(wh.Weekdays); err != nil {
+			return fmt.Errorf("working hours: %w", err)
+		}
+		if _, err := LoadLocation(wh.TimeZone); err != nil {
+			return fmt.Errorf("working hours: %w", err)
+		}
+	}
+	if len(w.OutOfOffice) > MaxOutOfOffice {
+		return fmt.Errorf("at most %d out-of-office periods", MaxOutOfOffice)
+	}
+	for _, ooo := range w.OutOfOffice {
+		if !ooo.StartTime.Before(ooo.EndTime) {
+			return errors.New("out-of-office period must start before it ends")
+		}
+	}
+
+	return nil
+}
+
+// Availability returns whether the user can attend the event, its first
+// occurrence if it recurs. The working hours do not apply to all-day events.
+func (w *WorkSchedule) Availability(event *Event) Availability {
+	for _, ooo := range w.OutOfOffice {
+		if ooo.StartTime.Before(event.EndTime) && event.StartTime.Before(ooo.EndTime) {
+			return AvailabilityOutOfOffice
+		}
+	}
+	if w.WorkingHours != nil && !event.AllDay && !w.WorkingHours.contain(event.StartTime, event.EndTime) {
+		return AvailabilityOutsideWorkingHours
+	}
+
+	return ""
+}
+
+// contain reports whether [start, end) is within the working hours of a
+// single day. Invalid working hours contain any time.
+func (wh *WorkingHours) contain(start, end time.Time) bool {
+	dayStart, err1 := ParseClock(wh.Start)
+	dayEnd, err2 := ParseClock(wh.End)
+	days, err3 := ParseWeekdays(wh.Weekdays)
+	loc, err4 := LoadLocation(wh.TimeZone)
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return true
+	}
+
+	start = start.In(loc)
+	if len(days) != 0 && !slices.Contains(days, start.Weekday()) {
+		return false
+	}
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	from := clockOf(day, dayStart)
+	to := clockOf(day, dayEnd)
+
+	return !start.Before(from) && !end.After(to)
+}
+
+// clockOf returns the time of day of the midnight day, kept in wall clock
+// time across the daylight saving changes.
+func clockOf(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, int(clock/time.Minute), 0, 0, day.Location())
+}
+
+// CheckAvailability flags the invited users of the event who have not
+// responded yet and cannot attend it according to their work schedule,
+// returned by schedule or nil. Those who decline such invitations are
+// declined.
+func (e *Event) CheckAvailability(schedule func(username string) *WorkSchedule) {
+	for i := range e.Attendees {
+		attendee := &e.Attendees[i]
+		if attendee.Username == "" || attendee.Status != PartStatNeedsAction {
+			continue
+		}
+		attendee.Availability = ""
+		ws := schedule(attendee.Username)
+		if ws == nil {
+			continue
+		}
+		attendee.Availability = ws.Availability(e)
+		if attendee.Availability != "" && ws.DeclineUnavailable {
+			attendee.Status = PartStatDeclined
+		}
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE events
+	DROP COLUMN IF EXISTS series_end_time,
+	DROP COLUMN IF EXISTS rrule;
@@ -0,0 +1,5 @@
+ALTER TABLE events
+	ADD COLUMN rrule TEXT NOT NULL DEFAULT '',
+	ADD COLUMN series_end_time TIMESTAMPTZ;
+
+UPDATE events SET series_end_time = end_time;
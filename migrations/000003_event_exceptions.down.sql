@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS events_parent_id_recurrence_id_idx;
+
+ALTER TABLE events
+	DROP COLUMN IF EXISTS recurrence_id,
+	DROP COLUMN IF EXISTS parent_id,
+	DROP COLUMN IF EXISTS exdates;
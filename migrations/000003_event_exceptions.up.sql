@@ -0,0 +1,6 @@
+ALTER TABLE events
+	ADD COLUMN exdates TIMESTAMPTZ[] NOT NULL DEFAULT '{}',
+	ADD COLUMN parent_id UUID REFERENCES events(id) ON DELETE CASCADE,
+	ADD COLUMN recurrence_id TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_parent_id_recurrence_id_idx ON events (parent_id, recurrence_id);
@@ -0,0 +1,5 @@
+ALTER TABLE events
+	ADD COLUMN uid TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_username_uid_idx ON events (username, uid)
+	WHERE uid != '' AND parent_id IS NULL;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS feeds (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL DEFAULT '',
+	token_hash TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS feeds_username_idx ON feeds (username);
@@ -0,0 +1,5 @@
+ALTER TABLE events
+	DROP CONSTRAINT IF EXISTS events_all_day_check;
+
+ALTER TABLE events
+	DROP COLUMN IF EXISTS all_day;
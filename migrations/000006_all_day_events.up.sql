@@ -0,0 +1,8 @@
+ALTER TABLE events
+	ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE events
+	ADD CONSTRAINT events_all_day_check CHECK (NOT all_day OR (
+		date_trunc('day', start_time AT TIME ZONE 'UTC') = start_time AT TIME ZONE 'UTC' AND
+		date_trunc('day', end_time AT TIME ZONE 'UTC') = end_time AT TIME ZONE 'UTC'
+	));
@@ -0,0 +1,5 @@
+ALTER TABLE events
+	DROP COLUMN IF EXISTS time_zone;
+
+ALTER TABLE users
+	DROP COLUMN IF EXISTS time_zone;
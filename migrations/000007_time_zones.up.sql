@@ -0,0 +1,5 @@
+ALTER TABLE users
+	ADD COLUMN time_zone TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE events
+	ADD COLUMN time_zone TEXT NOT NULL DEFAULT '';
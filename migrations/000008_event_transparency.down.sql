@@ -0,0 +1,5 @@
+ALTER TABLE events
+	DROP COLUMN IF EXISTS transparency;
+
+ALTER TABLE users
+	DROP COLUMN IF EXISTS conflict_policy;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+	ADD COLUMN conflict_policy TEXT NOT NULL DEFAULT ''
+		CHECK (conflict_policy IN ('', 'reject', 'warn', 'allow'));
+
+ALTER TABLE events
+	ADD COLUMN transparency TEXT NOT NULL DEFAULT ''
+		CHECK (transparency IN ('', 'busy', 'free'));
@@ -0,0 +1,2 @@
+ALTER TABLE users
+	ADD COLUMN share_free_busy BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS event_attendees (
+	event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	email TEXT NOT NULL,
+	username TEXT REFERENCES users(name) ON DELETE CASCADE,
+	status TEXT NOT NULL DEFAULT 'needs-action'
+		CHECK (status IN ('needs-action', 'accepted', 'declined', 'tentative')),
+	position INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (event_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS event_attendees_username_idx ON event_attendees (username);
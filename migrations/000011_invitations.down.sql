@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS invitations;
+
+ALTER TABLE events DROP COLUMN IF EXISTS sequence;
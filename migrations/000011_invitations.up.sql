@@ -0,0 +1,12 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS invitations (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	method TEXT NOT NULL CHECK (method IN ('REQUEST', 'CANCEL')),
+	event JSONB NOT NULL,
+	organizer TEXT NOT NULL,
+	recipients TEXT[] NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS invitations_created_at_idx ON invitations (created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS calendars (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL,
+	color TEXT NOT NULL DEFAULT '',
+	notify_before BIGINT,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE,
+	UNIQUE (username, name)
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS calendar_id UUID REFERENCES calendars(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS events_calendar_id_idx ON events (calendar_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS grants;
+DROP TYPE IF EXISTS grant_role;
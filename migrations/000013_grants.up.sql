@@ -0,0 +1,11 @@
+CREATE TYPE grant_role AS ENUM ('owner', 'editor', 'viewer', 'freebusy');
+
+CREATE TABLE IF NOT EXISTS grants (
+	owner TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE,
+	grantee TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE,
+	role grant_role NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (owner, grantee)
+);
+
+CREATE INDEX IF NOT EXISTS grants_grantee_idx ON grants (grantee);
@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN IF EXISTS resource_ids;
+
+DROP TABLE IF EXISTS resources;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS resources (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name TEXT NOT NULL UNIQUE,
+	kind TEXT NOT NULL,
+	capacity INTEGER NOT NULL DEFAULT 0,
+	attributes JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS resource_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS events_resource_ids_idx ON events USING GIN (resource_ids);
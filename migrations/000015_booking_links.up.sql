@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS booking_links (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	slug TEXT NOT NULL UNIQUE,
+	title TEXT NOT NULL,
+	duration BIGINT NOT NULL,
+	buffer BIGINT NOT NULL DEFAULT 0,
+	day_start TEXT NOT NULL,
+	day_end TEXT NOT NULL,
+	weekdays TEXT[] NOT NULL DEFAULT '{}',
+	time_zone TEXT NOT NULL DEFAULT '',
+	lookahead BIGINT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS booking_links_username_idx ON booking_links (username);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_invitees;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+DROP TYPE IF EXISTS poll_vote;
@@ -0,0 +1,42 @@
+CREATE TYPE poll_vote AS ENUM ('yes', 'no', 'if-needed');
+
+CREATE TABLE IF NOT EXISTS polls (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	title TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	final_option_id UUID,
+	event_id UUID,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS polls_username_idx ON polls (username);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+	start_time TIMESTAMPTZ NOT NULL,
+	end_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS poll_options_poll_id_idx ON poll_options (poll_id);
+
+CREATE TABLE IF NOT EXISTS poll_invitees (
+	poll_id UUID NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	PRIMARY KEY (poll_id, username)
+);
+
+CREATE INDEX IF NOT EXISTS poll_invitees_username_idx ON poll_invitees (username);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+	poll_id UUID NOT NULL,
+	option_id UUID NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+	username TEXT NOT NULL,
+	vote poll_vote NOT NULL,
+	PRIMARY KEY (option_id, username),
+	FOREIGN KEY (poll_id, username) REFERENCES poll_invitees(poll_id, username) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS poll_votes_poll_id_idx ON poll_votes (poll_id);
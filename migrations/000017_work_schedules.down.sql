@@ -0,0 +1,11 @@
+ALTER TABLE event_attendees
+	DROP COLUMN IF EXISTS availability;
+
+DROP TABLE IF EXISTS out_of_office;
+
+ALTER TABLE users
+	DROP COLUMN IF EXISTS decline_unavailable,
+	DROP COLUMN IF EXISTS work_time_zone,
+	DROP COLUMN IF EXISTS work_weekdays,
+	DROP COLUMN IF EXISTS work_day_end,
+	DROP COLUMN IF EXISTS work_day_start;
@@ -0,0 +1,20 @@
+-- NULL work_day_start means no working hours.
+ALTER TABLE users
+	ADD COLUMN work_day_start TEXT,
+	ADD COLUMN work_day_end TEXT,
+	ADD COLUMN work_weekdays TEXT[] NOT NULL DEFAULT '{}',
+	ADD COLUMN work_time_zone TEXT NOT NULL DEFAULT '',
+	ADD COLUMN decline_unavailable BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS out_of_office (
+	username TEXT NOT NULL REFERENCES users(name) ON DELETE CASCADE,
+	start_time TIMESTAMPTZ NOT NULL,
+	end_time TIMESTAMPTZ NOT NULL,
+	CHECK (start_time < end_time)
+);
+
+CREATE INDEX IF NOT EXISTS out_of_office_username_idx ON out_of_office (username, start_time);
+
+ALTER TABLE event_attendees
+	ADD COLUMN availability TEXT NOT NULL DEFAULT ''
+		CHECK (availability IN ('', 'outside-working-hours', 'out-of-office'));
@@ -0,0 +1,9 @@
+ALTER TABLE events
+	ADD COLUMN notify_before BIGINT;
+
+UPDATE events e
+SET notify_before = r.notify_before
+FROM event_reminders r
+WHERE r.event_id = e.id AND r.position = 0;
+
+DROP TABLE IF EXISTS event_reminders;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS event_reminders (
+	event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	-- notify_before is the offset before the start in nanoseconds.
+	notify_before BIGINT NOT NULL CHECK (notify_before >= 0),
+	channel TEXT NOT NULL CHECK (channel IN ('email', 'webhook')),
+	position INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (event_id, notify_before, channel)
+);
+
+INSERT INTO event_reminders (event_id, notify_before, channel)
+SELECT id, notify_before, 'email'
+FROM events
+WHERE notify_before IS NOT NULL AND notify_before >= 0;
+
+ALTER TABLE events
+	DROP COLUMN IF EXISTS notify_before;
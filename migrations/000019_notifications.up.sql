@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS notifications (
+	id UUID PRIMARY KEY,
+	event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	-- start_time is the start of the notified occurrence.
+	start_time TIMESTAMPTZ NOT NULL,
+	notify_before BIGINT NOT NULL,
+	channel TEXT NOT NULL CHECK (channel IN ('email', 'webhook')),
+	notify_time TIMESTAMPTZ NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent')),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	sent_at TIMESTAMPTZ,
+	UNIQUE (event_id, start_time, notify_before, channel)
+);
+
+CREATE INDEX IF NOT EXISTS notifications_pending_idx ON notifications (notify_time) WHERE status = 'pending';
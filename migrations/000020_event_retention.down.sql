@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS events_series_end_time_idx;
+
+ALTER TABLE users
+	DROP COLUMN IF EXISTS retention_days;
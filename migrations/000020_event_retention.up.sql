@@ -0,0 +1,5 @@
+-- 0 means the default retention of the scheduler.
+ALTER TABLE users
+	ADD COLUMN retention_days INTEGER NOT NULL DEFAULT 0 CHECK (retention_days >= 0);
+
+CREATE INDEX IF NOT EXISTS events_series_end_time_idx ON events (series_end_time) WHERE parent_id IS NULL;
@@ -0,0 +1,3 @@
+-- Incremented on every change of the event, see storage.Event.Revision.
+ALTER TABLE events
+	ADD COLUMN revision BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,11 @@
+-- Reminders by webhook are posted to the URL of the owner, none if empty.
+ALTER TABLE users
+	ADD COLUMN webhook_url TEXT NOT NULL DEFAULT '';
+
+-- The databases migrated while the webhook channel was rejected.
+ALTER TABLE event_reminders
+	DROP CONSTRAINT IF EXISTS event_reminders_channel_check,
+	ADD CONSTRAINT event_reminders_channel_check CHECK (channel IN ('email', 'webhook'));
+ALTER TABLE notifications
+	DROP CONSTRAINT IF EXISTS notifications_channel_check,
+	ADD CONSTRAINT notifications_channel_check CHECK (channel IN ('email', 'webhook'));
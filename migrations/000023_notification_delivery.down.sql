@@ -0,0 +1,2 @@
+ALTER TABLE notifications
+	DROP COLUMN IF EXISTS delivered_at;
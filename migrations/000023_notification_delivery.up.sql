@@ -0,0 +1,4 @@
+-- delivered_at is when the sender delivered the message of the notification,
+-- NULL until then.
+ALTER TABLE notifications
+	ADD COLUMN delivered_at TIMESTAMPTZ;
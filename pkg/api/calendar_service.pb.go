@@ -25,12 +25,16 @@ const (
 )
 
 type ReqRegister struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Email    string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// IANA name of the default time zone of the events of the user.
+	TimeZone string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Policy for overlapping busy events: "reject" (default), "warn" or "allow".
+	ConflictPolicy string `protobuf:"bytes,5,opt,name=conflict_policy,json=conflictPolicy,proto3" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ReqRegister) Reset() {
@@ -84,6 +88,20 @@ func (x *ReqRegister) GetEmail() string {
 	return ""
 }
 
+func (x *ReqRegister) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ReqRegister) GetConflictPolicy() string {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return ""
+}
+
 type ReqLogin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -181,12 +199,21 @@ func (x *ResLogin) GetAccessToken() string {
 }
 
 type ResUser struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email          string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role           string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	TimeZone       string                 `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ConflictPolicy string                 `protobuf:"bytes,5,opt,name=conflict_policy,json=conflictPolicy,proto3" json:"conflict_policy,omitempty"`
+	ShareFreeBusy  bool                   `protobuf:"varint,6,opt,name=share_free_busy,json=shareFreeBusy,proto3" json:"share_free_busy,omitempty"`
+	// Not set if the user works at any time.
+	WorkingHours       *WorkingHours `protobuf:"bytes,7,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	OutOfOffice        []*Interval   `protobuf:"bytes,8,rep,name=out_of_office,json=outOfOffice,proto3" json:"out_of_office,omitempty"`
+	DeclineUnavailable bool          `protobuf:"varint,9,opt,name=decline_unavailable,json=declineUnavailable,proto3" json:"decline_unavailable,omitempty"`
+	RetentionDays      int32         `protobuf:"varint,10,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"`
+	WebhookUrl         string        `protobuf:"bytes,11,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ResUser) Reset() {
@@ -240,31 +267,96 @@ func (x *ResUser) GetRole() string {
 	return ""
 }
 
-type ReqCreateEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	NotifyBefore  *durationpb.Duration   `protobuf:"bytes,5,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+func (x *ResUser) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ResUser) GetConflictPolicy() string {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return ""
+}
+
+func (x *ResUser) GetShareFreeBusy() bool {
+	if x != nil {
+		return x.ShareFreeBusy
+	}
+	return false
+}
+
+func (x *ResUser) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *ResUser) GetOutOfOffice() []*Interval {
+	if x != nil {
+		return x.OutOfOffice
+	}
+	return nil
+}
+
+func (x *ResUser) GetDeclineUnavailable() bool {
+	if x != nil {
+		return x.DeclineUnavailable
+	}
+	return false
+}
+
+func (x *ResUser) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
+}
+
+func (x *ResUser) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+type ReqUserSettings struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone       string                 `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ConflictPolicy string                 `protobuf:"bytes,2,opt,name=conflict_policy,json=conflictPolicy,proto3" json:"conflict_policy,omitempty"`
+	// Lets other users see when the user is busy.
+	ShareFreeBusy bool `protobuf:"varint,3,opt,name=share_free_busy,json=shareFreeBusy,proto3" json:"share_free_busy,omitempty"`
+	// The new invitations outside of the working hours or in an
+	// out-of-office period are flagged, or declined if decline_unavailable.
+	WorkingHours       *WorkingHours `protobuf:"bytes,4,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	OutOfOffice        []*Interval   `protobuf:"bytes,5,rep,name=out_of_office,json=outOfOffice,proto3" json:"out_of_office,omitempty"`
+	DeclineUnavailable bool          `protobuf:"varint,6,opt,name=decline_unavailable,json=declineUnavailable,proto3" json:"decline_unavailable,omitempty"`
+	// Days the events are kept after they end, the default of the server
+	// if 0.
+	RetentionDays int32 `protobuf:"varint,7,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"`
+	// Where the reminders by webhook are posted, an http or https URL.
+	WebhookUrl    string `protobuf:"bytes,8,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReqCreateEvent) Reset() {
-	*x = ReqCreateEvent{}
+func (x *ReqUserSettings) Reset() {
+	*x = ReqUserSettings{}
 	mi := &file_calendar_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReqCreateEvent) String() string {
+func (x *ReqUserSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReqCreateEvent) ProtoMessage() {}
+func (*ReqUserSettings) ProtoMessage() {}
 
-func (x *ReqCreateEvent) ProtoReflect() protoreflect.Message {
+func (x *ReqUserSettings) ProtoReflect() protoreflect.Message {
 	mi := &file_calendar_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -276,112 +368,91 @@ func (x *ReqCreateEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReqCreateEvent.ProtoReflect.Descriptor instead.
-func (*ReqCreateEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReqUserSettings.ProtoReflect.Descriptor instead.
+func (*ReqUserSettings) Descriptor() ([]byte, []int) {
 	return file_calendar_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ReqCreateEvent) GetTitle() string {
+func (x *ReqUserSettings) GetTimeZone() string {
 	if x != nil {
-		return x.Title
+		return x.TimeZone
 	}
 	return ""
 }
 
-func (x *ReqCreateEvent) GetDescription() string {
+func (x *ReqUserSettings) GetConflictPolicy() string {
 	if x != nil {
-		return x.Description
+		return x.ConflictPolicy
 	}
 	return ""
 }
 
-func (x *ReqCreateEvent) GetStartTime() *timestamppb.Timestamp {
+func (x *ReqUserSettings) GetShareFreeBusy() bool {
 	if x != nil {
-		return x.StartTime
+		return x.ShareFreeBusy
 	}
-	return nil
+	return false
 }
 
-func (x *ReqCreateEvent) GetEndTime() *timestamppb.Timestamp {
+func (x *ReqUserSettings) GetWorkingHours() *WorkingHours {
 	if x != nil {
-		return x.EndTime
+		return x.WorkingHours
 	}
 	return nil
 }
 
-func (x *ReqCreateEvent) GetNotifyBefore() *durationpb.Duration {
+func (x *ReqUserSettings) GetOutOfOffice() []*Interval {
 	if x != nil {
-		return x.NotifyBefore
+		return x.OutOfOffice
 	}
 	return nil
 }
 
-type ResCreateEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ResCreateEvent) Reset() {
-	*x = ResCreateEvent{}
-	mi := &file_calendar_service_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ResCreateEvent) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ResCreateEvent) ProtoMessage() {}
-
-func (x *ResCreateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[5]
+func (x *ReqUserSettings) GetDeclineUnavailable() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DeclineUnavailable
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use ResCreateEvent.ProtoReflect.Descriptor instead.
-func (*ResCreateEvent) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{5}
+func (x *ReqUserSettings) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
 }
 
-func (x *ResCreateEvent) GetId() string {
+func (x *ReqUserSettings) GetWebhookUrl() string {
 	if x != nil {
-		return x.Id
+		return x.WebhookUrl
 	}
 	return ""
 }
 
-type ReqGetEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type ReqSetGrant struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Grantee string                 `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	// "owner", "editor", "viewer" or "freebusy".
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReqGetEvent) Reset() {
-	*x = ReqGetEvent{}
-	mi := &file_calendar_service_proto_msgTypes[6]
+func (x *ReqSetGrant) Reset() {
+	*x = ReqSetGrant{}
+	mi := &file_calendar_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReqGetEvent) String() string {
+func (x *ReqSetGrant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReqGetEvent) ProtoMessage() {}
+func (*ReqSetGrant) ProtoMessage() {}
 
-func (x *ReqGetEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[6]
+func (x *ReqSetGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,45 +463,50 @@ func (x *ReqGetEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReqGetEvent.ProtoReflect.Descriptor instead.
-func (*ReqGetEvent) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ReqSetGrant.ProtoReflect.Descriptor instead.
+func (*ReqSetGrant) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ReqGetEvent) GetId() string {
+func (x *ReqSetGrant) GetGrantee() string {
 	if x != nil {
-		return x.Id
+		return x.Grantee
 	}
 	return ""
 }
 
-type ResEvent struct {
+func (x *ReqSetGrant) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Grant struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	NotifyBefore  *durationpb.Duration   `protobuf:"bytes,6,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Grantee       string                 `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResEvent) Reset() {
-	*x = ResEvent{}
-	mi := &file_calendar_service_proto_msgTypes[7]
+func (x *Grant) Reset() {
+	*x = Grant{}
+	mi := &file_calendar_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResEvent) String() string {
+func (x *Grant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResEvent) ProtoMessage() {}
+func (*Grant) ProtoMessage() {}
 
-func (x *ResEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[7]
+func (x *Grant) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,76 +517,61 @@ func (x *ResEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResEvent.ProtoReflect.Descriptor instead.
-func (*ResEvent) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Grant.ProtoReflect.Descriptor instead.
+func (*Grant) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ResEvent) GetId() string {
+func (x *Grant) GetOwner() string {
 	if x != nil {
-		return x.Id
+		return x.Owner
 	}
 	return ""
 }
 
-func (x *ResEvent) GetTitle() string {
+func (x *Grant) GetGrantee() string {
 	if x != nil {
-		return x.Title
+		return x.Grantee
 	}
 	return ""
 }
 
-func (x *ResEvent) GetDescription() string {
+func (x *Grant) GetRole() string {
 	if x != nil {
-		return x.Description
+		return x.Role
 	}
 	return ""
 }
 
-func (x *ResEvent) GetStartTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.StartTime
-	}
-	return nil
-}
-
-func (x *ResEvent) GetEndTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.EndTime
-	}
-	return nil
-}
-
-func (x *ResEvent) GetNotifyBefore() *durationpb.Duration {
+func (x *Grant) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NotifyBefore
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type ReqListEvents struct {
+type ResListGrants struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Grants        []*Grant               `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReqListEvents) Reset() {
-	*x = ReqListEvents{}
-	mi := &file_calendar_service_proto_msgTypes[8]
+func (x *ResListGrants) Reset() {
+	*x = ResListGrants{}
+	mi := &file_calendar_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReqListEvents) String() string {
+func (x *ResListGrants) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReqListEvents) ProtoMessage() {}
+func (*ResListGrants) ProtoMessage() {}
 
-func (x *ReqListEvents) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[8]
+func (x *ResListGrants) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,47 +582,40 @@ func (x *ReqListEvents) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReqListEvents.ProtoReflect.Descriptor instead.
-func (*ReqListEvents) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *ReqListEvents) GetStartTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.StartTime
-	}
-	return nil
+// Deprecated: Use ResListGrants.ProtoReflect.Descriptor instead.
+func (*ResListGrants) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ReqListEvents) GetEndTime() *timestamppb.Timestamp {
+func (x *ResListGrants) GetGrants() []*Grant {
 	if x != nil {
-		return x.EndTime
+		return x.Grants
 	}
 	return nil
 }
 
-type ResListEvents struct {
+type ReqDeleteGrant struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*ResEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Grantee       string                 `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResListEvents) Reset() {
-	*x = ResListEvents{}
-	mi := &file_calendar_service_proto_msgTypes[9]
+func (x *ReqDeleteGrant) Reset() {
+	*x = ReqDeleteGrant{}
+	mi := &file_calendar_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResListEvents) String() string {
+func (x *ReqDeleteGrant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResListEvents) ProtoMessage() {}
+func (*ReqDeleteGrant) ProtoMessage() {}
 
-func (x *ResListEvents) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[9]
+func (x *ReqDeleteGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,45 +626,63 @@ func (x *ResListEvents) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResListEvents.ProtoReflect.Descriptor instead.
-func (*ResListEvents) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReqDeleteGrant.ProtoReflect.Descriptor instead.
+func (*ReqDeleteGrant) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ResListEvents) GetEvents() []*ResEvent {
+func (x *ReqDeleteGrant) GetGrantee() string {
 	if x != nil {
-		return x.Events
+		return x.Grantee
 	}
-	return nil
+	return ""
 }
 
-type ReqUpdateEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	NotifyBefore  *durationpb.Duration   `protobuf:"bytes,6,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+type ReqCreateEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Rrule       string                 `protobuf:"bytes,6,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	// An all-day event is given by dates in the form 2006-01-02 instead of
+	// times: end_date is its last day, start_date by default.
+	AllDay    bool   `protobuf:"varint,7,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	StartDate string `protobuf:"bytes,8,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,9,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// IANA name of the time zone of the event, the default one of the user
+	// if empty.
+	TimeZone string `protobuf:"bytes,10,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// "busy" (default) or "free", free events do not conflict.
+	Transparency string      `protobuf:"bytes,11,opt,name=transparency,proto3" json:"transparency,omitempty"`
+	Attendees    []*Attendee `protobuf:"bytes,12,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	// Calendar of the event, no calendar if empty.
+	CalendarId string `protobuf:"bytes,13,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	// User who shared their events, the user if empty.
+	Owner string `protobuf:"bytes,14,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Ids of the resources to reserve.
+	Resources []string `protobuf:"bytes,15,rep,name=resources,proto3" json:"resources,omitempty"`
+	// The default reminder of the calendar if not set.
+	Reminders     *Reminders `protobuf:"bytes,16,opt,name=reminders,proto3" json:"reminders,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReqUpdateEvent) Reset() {
-	*x = ReqUpdateEvent{}
-	mi := &file_calendar_service_proto_msgTypes[10]
+func (x *ReqCreateEvent) Reset() {
+	*x = ReqCreateEvent{}
+	mi := &file_calendar_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReqUpdateEvent) String() string {
+func (x *ReqCreateEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReqUpdateEvent) ProtoMessage() {}
+func (*ReqCreateEvent) ProtoMessage() {}
 
-func (x *ReqUpdateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[10]
+func (x *ReqCreateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,75 +693,3564 @@ func (x *ReqUpdateEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReqUpdateEvent.ProtoReflect.Descriptor instead.
-func (*ReqUpdateEvent) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *ReqUpdateEvent) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ReqCreateEvent.ProtoReflect.Descriptor instead.
+func (*ReqCreateEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ReqUpdateEvent) GetTitle() string {
+func (x *ReqCreateEvent) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *ReqUpdateEvent) GetDescription() string {
+func (x *ReqCreateEvent) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *ReqUpdateEvent) GetStartTime() *timestamppb.Timestamp {
+func (x *ReqCreateEvent) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
 		return x.StartTime
 	}
 	return nil
 }
 
-func (x *ReqUpdateEvent) GetEndTime() *timestamppb.Timestamp {
+func (x *ReqCreateEvent) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
 		return x.EndTime
 	}
 	return nil
 }
 
-func (x *ReqUpdateEvent) GetNotifyBefore() *durationpb.Duration {
+func (x *ReqCreateEvent) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *ReqCreateEvent) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetTransparency() string {
+	if x != nil {
+		return x.Transparency
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *ReqCreateEvent) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ReqCreateEvent) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ReqCreateEvent) GetReminders() *Reminders {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+// Attendee is a user given by username or anyone else given by email.
+type Attendee struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email    string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// "needs-action", "accepted", "declined" or "tentative", output only.
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// "outside-working-hours" or "out-of-office" if the user was invited
+	// outside of their work schedule, output only.
+	Availability  string `protobuf:"bytes,4,opt,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attendee) Reset() {
+	*x = Attendee{}
+	mi := &file_calendar_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attendee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendee) ProtoMessage() {}
+
+func (x *Attendee) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendee.ProtoReflect.Descriptor instead.
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Attendee) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Attendee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Attendee) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Attendee) GetAvailability() string {
+	if x != nil {
+		return x.Availability
+	}
+	return ""
+}
+
+// Reminder notifies the owner through the channel "email" or "webhook"
+// offset before the start of the event.
+type Reminder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        *durationpb.Duration   `protobuf:"bytes,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_calendar_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminder) ProtoMessage() {}
+
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Reminder) GetOffset() *durationpb.Duration {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+func (x *Reminder) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type Reminders struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminders     []*Reminder            `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reminders) Reset() {
+	*x = Reminders{}
+	mi := &file_calendar_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminders) ProtoMessage() {}
+
+func (x *Reminders) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reminders.ProtoReflect.Descriptor instead.
+func (*Reminders) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Reminders) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type Attendees struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attendees     []*Attendee            `protobuf:"bytes,1,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attendees) Reset() {
+	*x = Attendees{}
+	mi := &file_calendar_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attendees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendees) ProtoMessage() {}
+
+func (x *Attendees) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendees.ProtoReflect.Descriptor instead.
+func (*Attendees) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Attendees) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+type Resources struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	mi := &file_calendar_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Resources) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ResCreateEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Busy events overlapped by the event if the conflict policy is "warn".
+	Conflicts     []string `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCreateEvent) Reset() {
+	*x = ResCreateEvent{}
+	mi := &file_calendar_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCreateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCreateEvent) ProtoMessage() {}
+
+func (x *ResCreateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCreateEvent.ProtoReflect.Descriptor instead.
+func (*ResCreateEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResCreateEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResCreateEvent) GetConflicts() []string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type ReqGetEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetEvent) Reset() {
+	*x = ReqGetEvent{}
+	mi := &file_calendar_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetEvent) ProtoMessage() {}
+
+func (x *ReqGetEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetEvent.ProtoReflect.Descriptor instead.
+func (*ReqGetEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReqGetEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResEvent struct {
+	state        protoimpl.MessageState   `protogen:"open.v1"`
+	Id           string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string                   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description  string                   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartTime    *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Rrule        string                   `protobuf:"bytes,7,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Exdates      []*timestamppb.Timestamp `protobuf:"bytes,8,rep,name=exdates,proto3" json:"exdates,omitempty"`
+	ParentId     string                   `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	RecurrenceId *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=recurrence_id,json=recurrenceId,proto3" json:"recurrence_id,omitempty"`
+	Uid          string                   `protobuf:"bytes,11,opt,name=uid,proto3" json:"uid,omitempty"`
+	AllDay       bool                     `protobuf:"varint,12,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	StartDate    string                   `protobuf:"bytes,13,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate      string                   `protobuf:"bytes,14,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TimeZone     string                   `protobuf:"bytes,15,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Transparency string                   `protobuf:"bytes,16,opt,name=transparency,proto3" json:"transparency,omitempty"`
+	Attendees    []*Attendee              `protobuf:"bytes,17,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	// Owner of an event the user is invited to.
+	Organizer     string      `protobuf:"bytes,18,opt,name=organizer,proto3" json:"organizer,omitempty"`
+	CalendarId    string      `protobuf:"bytes,19,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	Resources     []string    `protobuf:"bytes,20,rep,name=resources,proto3" json:"resources,omitempty"`
+	Reminders     []*Reminder `protobuf:"bytes,21,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResEvent) Reset() {
+	*x = ResEvent{}
+	mi := &file_calendar_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResEvent) ProtoMessage() {}
+
+func (x *ResEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResEvent.ProtoReflect.Descriptor instead.
+func (*ResEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ResEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ResEvent) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ResEvent) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ResEvent) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *ResEvent) GetExdates() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.Exdates
+	}
+	return nil
+}
+
+func (x *ResEvent) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ResEvent) GetRecurrenceId() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecurrenceId
+	}
+	return nil
+}
+
+func (x *ResEvent) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *ResEvent) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *ResEvent) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ResEvent) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ResEvent) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ResEvent) GetTransparency() string {
+	if x != nil {
+		return x.Transparency
+	}
+	return ""
+}
+
+func (x *ResEvent) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *ResEvent) GetOrganizer() string {
+	if x != nil {
+		return x.Organizer
+	}
+	return ""
+}
+
+func (x *ResEvent) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *ResEvent) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ResEvent) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type ReqListEvents struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only the events of the calendars if set.
+	CalendarIds []string `protobuf:"bytes,3,rep,name=calendar_ids,json=calendarIds,proto3" json:"calendar_ids,omitempty"`
+	// User who shared their events, the user if empty.
+	Owner         string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqListEvents) Reset() {
+	*x = ReqListEvents{}
+	mi := &file_calendar_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqListEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqListEvents) ProtoMessage() {}
+
+func (x *ReqListEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqListEvents.ProtoReflect.Descriptor instead.
+func (*ReqListEvents) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReqListEvents) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqListEvents) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ReqListEvents) GetCalendarIds() []string {
+	if x != nil {
+		return x.CalendarIds
+	}
+	return nil
+}
+
+func (x *ReqListEvents) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type ResListEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*ResEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResListEvents) Reset() {
+	*x = ResListEvents{}
+	mi := &file_calendar_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResListEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResListEvents) ProtoMessage() {}
+
+func (x *ResListEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResListEvents.ProtoReflect.Descriptor instead.
+func (*ResListEvents) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResListEvents) GetEvents() []*ResEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ReqUpdateEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Rrule       string                 `protobuf:"bytes,7,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	// Occurrence of a recurring event and the scope of the change:
+	// "this", "following" or "all" (default).
+	RecurrenceId *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=recurrence_id,json=recurrenceId,proto3" json:"recurrence_id,omitempty"`
+	Scope        string                 `protobuf:"bytes,9,opt,name=scope,proto3" json:"scope,omitempty"`
+	AllDay       bool                   `protobuf:"varint,10,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	StartDate    string                 `protobuf:"bytes,11,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate      string                 `protobuf:"bytes,12,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TimeZone     string                 `protobuf:"bytes,13,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Transparency string                 `protobuf:"bytes,14,opt,name=transparency,proto3" json:"transparency,omitempty"`
+	// Replace the attendees if set, keep them otherwise.
+	Attendees *Attendees `protobuf:"bytes,15,opt,name=attendees,proto3" json:"attendees,omitempty"`
+	// Calendar of the event, no calendar if empty.
+	CalendarId string `protobuf:"bytes,16,opt,name=calendar_id,json=calendarId,proto3" json:"calendar_id,omitempty"`
+	// Replace the reserved resources if set, keep them otherwise.
+	Resources     *Resources  `protobuf:"bytes,17,opt,name=resources,proto3" json:"resources,omitempty"`
+	Reminders     []*Reminder `protobuf:"bytes,18,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqUpdateEvent) Reset() {
+	*x = ReqUpdateEvent{}
+	mi := &file_calendar_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqUpdateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqUpdateEvent) ProtoMessage() {}
+
+func (x *ReqUpdateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqUpdateEvent.ProtoReflect.Descriptor instead.
+func (*ReqUpdateEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReqUpdateEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqUpdateEvent) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ReqUpdateEvent) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetRecurrenceId() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecurrenceId
+	}
+	return nil
+}
+
+func (x *ReqUpdateEvent) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *ReqUpdateEvent) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetTransparency() string {
+	if x != nil {
+		return x.Transparency
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetAttendees() *Attendees {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *ReqUpdateEvent) GetCalendarId() string {
+	if x != nil {
+		return x.CalendarId
+	}
+	return ""
+}
+
+func (x *ReqUpdateEvent) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ReqUpdateEvent) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type ReqCreateCalendar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Color as "#rrggbb".
+	Color string `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	// Default reminder of the events of the calendar.
+	NotifyBefore  *durationpb.Duration `protobuf:"bytes,3,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqCreateCalendar) Reset() {
+	*x = ReqCreateCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqCreateCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqCreateCalendar) ProtoMessage() {}
+
+func (x *ReqCreateCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqCreateCalendar.ProtoReflect.Descriptor instead.
+func (*ReqCreateCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReqCreateCalendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReqCreateCalendar) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *ReqCreateCalendar) GetNotifyBefore() *durationpb.Duration {
+	if x != nil {
+		return x.NotifyBefore
+	}
+	return nil
+}
+
+type ResCreateCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCreateCalendar) Reset() {
+	*x = ResCreateCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCreateCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCreateCalendar) ProtoMessage() {}
+
+func (x *ResCreateCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCreateCalendar.ProtoReflect.Descriptor instead.
+func (*ResCreateCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResCreateCalendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqGetCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetCalendar) Reset() {
+	*x = ReqGetCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetCalendar) ProtoMessage() {}
+
+func (x *ReqGetCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetCalendar.ProtoReflect.Descriptor instead.
+func (*ReqGetCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReqGetCalendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	NotifyBefore  *durationpb.Duration   `protobuf:"bytes,4,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCalendar) Reset() {
+	*x = ResCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCalendar) ProtoMessage() {}
+
+func (x *ResCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCalendar.ProtoReflect.Descriptor instead.
+func (*ResCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResCalendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResCalendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResCalendar) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *ResCalendar) GetNotifyBefore() *durationpb.Duration {
+	if x != nil {
+		return x.NotifyBefore
+	}
+	return nil
+}
+
+func (x *ResCalendar) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ResListCalendars struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendars     []*ResCalendar         `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResListCalendars) Reset() {
+	*x = ResListCalendars{}
+	mi := &file_calendar_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResListCalendars) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResListCalendars) ProtoMessage() {}
+
+func (x *ResListCalendars) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResListCalendars.ProtoReflect.Descriptor instead.
+func (*ResListCalendars) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResListCalendars) GetCalendars() []*ResCalendar {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+type ReqUpdateCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	NotifyBefore  *durationpb.Duration   `protobuf:"bytes,4,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqUpdateCalendar) Reset() {
+	*x = ReqUpdateCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqUpdateCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqUpdateCalendar) ProtoMessage() {}
+
+func (x *ReqUpdateCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqUpdateCalendar.ProtoReflect.Descriptor instead.
+func (*ReqUpdateCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReqUpdateCalendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqUpdateCalendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReqUpdateCalendar) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *ReqUpdateCalendar) GetNotifyBefore() *durationpb.Duration {
 	if x != nil {
 		return x.NotifyBefore
 	}
 	return nil
 }
 
-type ReqDeleteEvent struct {
+type ReqDeleteCalendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqDeleteCalendar) Reset() {
+	*x = ReqDeleteCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqDeleteCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqDeleteCalendar) ProtoMessage() {}
+
+func (x *ReqDeleteCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqDeleteCalendar.ProtoReflect.Descriptor instead.
+func (*ReqDeleteCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReqDeleteCalendar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqCreateResource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "room", "projector", "car"...
+	Kind          string            `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Capacity      int32             `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Attributes    map[string]string `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqCreateResource) Reset() {
+	*x = ReqCreateResource{}
+	mi := &file_calendar_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqCreateResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqCreateResource) ProtoMessage() {}
+
+func (x *ReqCreateResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqCreateResource.ProtoReflect.Descriptor instead.
+func (*ReqCreateResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReqCreateResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReqCreateResource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ReqCreateResource) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ReqCreateResource) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ResCreateResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCreateResource) Reset() {
+	*x = ResCreateResource{}
+	mi := &file_calendar_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCreateResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCreateResource) ProtoMessage() {}
+
+func (x *ResCreateResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCreateResource.ProtoReflect.Descriptor instead.
+func (*ResCreateResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ResCreateResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqGetResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetResource) Reset() {
+	*x = ReqGetResource{}
+	mi := &file_calendar_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetResource) ProtoMessage() {}
+
+func (x *ReqGetResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetResource.ProtoReflect.Descriptor instead.
+func (*ReqGetResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReqGetResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResResource) Reset() {
+	*x = ResResource{}
+	mi := &file_calendar_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResResource) ProtoMessage() {}
+
+func (x *ResResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResResource.ProtoReflect.Descriptor instead.
+func (*ResResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResResource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResResource) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ResResource) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ResResource) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ReqListResources struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Any kind if empty.
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	MinCapacity   int32  `protobuf:"varint,2,opt,name=min_capacity,json=minCapacity,proto3" json:"min_capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqListResources) Reset() {
+	*x = ReqListResources{}
+	mi := &file_calendar_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqListResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqListResources) ProtoMessage() {}
+
+func (x *ReqListResources) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqListResources.ProtoReflect.Descriptor instead.
+func (*ReqListResources) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReqListResources) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ReqListResources) GetMinCapacity() int32 {
+	if x != nil {
+		return x.MinCapacity
+	}
+	return 0
+}
+
+type ResListResources struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resources     []*ResResource         `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResListResources) Reset() {
+	*x = ResListResources{}
+	mi := &file_calendar_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResListResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResListResources) ProtoMessage() {}
+
+func (x *ResListResources) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResListResources.ProtoReflect.Descriptor instead.
+func (*ResListResources) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ResListResources) GetResources() []*ResResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type ReqUpdateResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqUpdateResource) Reset() {
+	*x = ReqUpdateResource{}
+	mi := &file_calendar_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqUpdateResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqUpdateResource) ProtoMessage() {}
+
+func (x *ReqUpdateResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqUpdateResource.ProtoReflect.Descriptor instead.
+func (*ReqUpdateResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReqUpdateResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqUpdateResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReqUpdateResource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ReqUpdateResource) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ReqUpdateResource) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ReqDeleteResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqDeleteResource) Reset() {
+	*x = ReqDeleteResource{}
+	mi := &file_calendar_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqDeleteResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqDeleteResource) ProtoMessage() {}
+
+func (x *ReqDeleteResource) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqDeleteResource.ProtoReflect.Descriptor instead.
+func (*ReqDeleteResource) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReqDeleteResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqDeleteEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecurrenceId  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=recurrence_id,json=recurrenceId,proto3" json:"recurrence_id,omitempty"`
+	Scope         string                 `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqDeleteEvent) Reset() {
+	*x = ReqDeleteEvent{}
+	mi := &file_calendar_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqDeleteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqDeleteEvent) ProtoMessage() {}
+
+func (x *ReqDeleteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqDeleteEvent.ProtoReflect.Descriptor instead.
+func (*ReqDeleteEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReqDeleteEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqDeleteEvent) GetRecurrenceId() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecurrenceId
+	}
+	return nil
+}
+
+func (x *ReqDeleteEvent) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+type ReqRespondToEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "accepted", "declined" or "tentative".
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqRespondToEvent) Reset() {
+	*x = ReqRespondToEvent{}
+	mi := &file_calendar_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqRespondToEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqRespondToEvent) ProtoMessage() {}
+
+func (x *ReqRespondToEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqRespondToEvent.ProtoReflect.Descriptor instead.
+func (*ReqRespondToEvent) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReqRespondToEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqRespondToEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ReqExportCalendar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Default: a year before and after now.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqExportCalendar) Reset() {
+	*x = ReqExportCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqExportCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqExportCalendar) ProtoMessage() {}
+
+func (x *ReqExportCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqExportCalendar.ProtoReflect.Descriptor instead.
+func (*ReqExportCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReqExportCalendar) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqExportCalendar) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ResExportCalendar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// VCALENDAR object, RFC 5545.
+	Calendar      string `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResExportCalendar) Reset() {
+	*x = ResExportCalendar{}
+	mi := &file_calendar_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResExportCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResExportCalendar) ProtoMessage() {}
+
+func (x *ResExportCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResExportCalendar.ProtoReflect.Descriptor instead.
+func (*ResExportCalendar) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResExportCalendar) GetCalendar() string {
+	if x != nil {
+		return x.Calendar
+	}
+	return ""
+}
+
+type ReqCreateBookingLink struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the public page: lowercase letters, digits and dashes.
+	Slug  string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Length of the booked events.
+	Duration *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Free time kept before and after the booked events.
+	Buffer       *durationpb.Duration `protobuf:"bytes,4,opt,name=buffer,proto3" json:"buffer,omitempty"`
+	WorkingHours *WorkingHours        `protobuf:"bytes,5,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	// How far ahead the slots may start.
+	Lookahead     *durationpb.Duration `protobuf:"bytes,6,opt,name=lookahead,proto3" json:"lookahead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqCreateBookingLink) Reset() {
+	*x = ReqCreateBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqCreateBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqCreateBookingLink) ProtoMessage() {}
+
+func (x *ReqCreateBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqCreateBookingLink.ProtoReflect.Descriptor instead.
+func (*ReqCreateBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReqCreateBookingLink) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ReqCreateBookingLink) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ReqCreateBookingLink) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ReqCreateBookingLink) GetBuffer() *durationpb.Duration {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+func (x *ReqCreateBookingLink) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *ReqCreateBookingLink) GetLookahead() *durationpb.Duration {
+	if x != nil {
+		return x.Lookahead
+	}
+	return nil
+}
+
+type ResCreateBookingLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCreateBookingLink) Reset() {
+	*x = ResCreateBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCreateBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCreateBookingLink) ProtoMessage() {}
+
+func (x *ResCreateBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCreateBookingLink.ProtoReflect.Descriptor instead.
+func (*ResCreateBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ResCreateBookingLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqGetBookingLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetBookingLink) Reset() {
+	*x = ReqGetBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetBookingLink) ProtoMessage() {}
+
+func (x *ReqGetBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetBookingLink.ProtoReflect.Descriptor instead.
+func (*ReqGetBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReqGetBookingLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResBookingLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Buffer        *durationpb.Duration   `protobuf:"bytes,5,opt,name=buffer,proto3" json:"buffer,omitempty"`
+	WorkingHours  *WorkingHours          `protobuf:"bytes,6,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Lookahead     *durationpb.Duration   `protobuf:"bytes,7,opt,name=lookahead,proto3" json:"lookahead,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResBookingLink) Reset() {
+	*x = ResBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResBookingLink) ProtoMessage() {}
+
+func (x *ResBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResBookingLink.ProtoReflect.Descriptor instead.
+func (*ResBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ResBookingLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResBookingLink) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ResBookingLink) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ResBookingLink) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ResBookingLink) GetBuffer() *durationpb.Duration {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+func (x *ResBookingLink) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *ResBookingLink) GetLookahead() *durationpb.Duration {
+	if x != nil {
+		return x.Lookahead
+	}
+	return nil
+}
+
+func (x *ResBookingLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ResListBookingLinks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingLinks  []*ResBookingLink      `protobuf:"bytes,1,rep,name=booking_links,json=bookingLinks,proto3" json:"booking_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResListBookingLinks) Reset() {
+	*x = ResListBookingLinks{}
+	mi := &file_calendar_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResListBookingLinks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResListBookingLinks) ProtoMessage() {}
+
+func (x *ResListBookingLinks) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResListBookingLinks.ProtoReflect.Descriptor instead.
+func (*ResListBookingLinks) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ResListBookingLinks) GetBookingLinks() []*ResBookingLink {
+	if x != nil {
+		return x.BookingLinks
+	}
+	return nil
+}
+
+type ReqUpdateBookingLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Buffer        *durationpb.Duration   `protobuf:"bytes,5,opt,name=buffer,proto3" json:"buffer,omitempty"`
+	WorkingHours  *WorkingHours          `protobuf:"bytes,6,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Lookahead     *durationpb.Duration   `protobuf:"bytes,7,opt,name=lookahead,proto3" json:"lookahead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqUpdateBookingLink) Reset() {
+	*x = ReqUpdateBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqUpdateBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqUpdateBookingLink) ProtoMessage() {}
+
+func (x *ReqUpdateBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqUpdateBookingLink.ProtoReflect.Descriptor instead.
+func (*ReqUpdateBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReqUpdateBookingLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqUpdateBookingLink) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ReqUpdateBookingLink) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ReqUpdateBookingLink) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ReqUpdateBookingLink) GetBuffer() *durationpb.Duration {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+func (x *ReqUpdateBookingLink) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *ReqUpdateBookingLink) GetLookahead() *durationpb.Duration {
+	if x != nil {
+		return x.Lookahead
+	}
+	return nil
+}
+
+type ReqDeleteBookingLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqDeleteBookingLink) Reset() {
+	*x = ReqDeleteBookingLink{}
+	mi := &file_calendar_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqDeleteBookingLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqDeleteBookingLink) ProtoMessage() {}
+
+func (x *ReqDeleteBookingLink) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqDeleteBookingLink.ProtoReflect.Descriptor instead.
+func (*ReqDeleteBookingLink) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReqDeleteBookingLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqGetBookingPage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Default: the lookahead of the link from now.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetBookingPage) Reset() {
+	*x = ReqGetBookingPage{}
+	mi := &file_calendar_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetBookingPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetBookingPage) ProtoMessage() {}
+
+func (x *ReqGetBookingPage) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetBookingPage.ProtoReflect.Descriptor instead.
+func (*ReqGetBookingPage) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ReqGetBookingPage) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ReqGetBookingPage) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqGetBookingPage) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ResBookingPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Slots         []*Interval            `protobuf:"bytes,3,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResBookingPage) Reset() {
+	*x = ResBookingPage{}
+	mi := &file_calendar_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResBookingPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResBookingPage) ProtoMessage() {}
+
+func (x *ResBookingPage) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResBookingPage.ProtoReflect.Descriptor instead.
+func (*ResBookingPage) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ResBookingPage) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ResBookingPage) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ResBookingPage) GetSlots() []*Interval {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type ReqBook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqBook) Reset() {
+	*x = ReqBook{}
+	mi := &file_calendar_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqBook) ProtoMessage() {}
+
+func (x *ReqBook) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqBook.ProtoReflect.Descriptor instead.
+func (*ReqBook) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReqBook) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ReqBook) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqBook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReqBook) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ReqBook) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ReqCreatePoll struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Candidate times of the meeting.
+	Options []*Interval `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	// Usernames of the users who vote.
+	Invitees      []string `protobuf:"bytes,4,rep,name=invitees,proto3" json:"invitees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqCreatePoll) Reset() {
+	*x = ReqCreatePoll{}
+	mi := &file_calendar_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqCreatePoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqCreatePoll) ProtoMessage() {}
+
+func (x *ReqCreatePoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqCreatePoll.ProtoReflect.Descriptor instead.
+func (*ReqCreatePoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ReqCreatePoll) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ReqCreatePoll) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ReqCreatePoll) GetOptions() []*Interval {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ReqCreatePoll) GetInvitees() []string {
+	if x != nil {
+		return x.Invitees
+	}
+	return nil
+}
+
+type ResCreatePoll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResCreatePoll) Reset() {
+	*x = ResCreatePoll{}
+	mi := &file_calendar_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResCreatePoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResCreatePoll) ProtoMessage() {}
+
+func (x *ResCreatePoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResCreatePoll.ProtoReflect.Descriptor instead.
+func (*ResCreatePoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ResCreatePoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqGetPoll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqGetPoll) Reset() {
+	*x = ReqGetPoll{}
+	mi := &file_calendar_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqGetPoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqGetPoll) ProtoMessage() {}
+
+func (x *ReqGetPoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqGetPoll.ProtoReflect.Descriptor instead.
+func (*ReqGetPoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReqGetPoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_calendar_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PollOption) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PollOption) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *PollOption) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type PollVote struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OptionId string                 `protobuf:"bytes,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// "yes", "no" or "if-needed".
+	Vote          string `protobuf:"bytes,3,opt,name=vote,proto3" json:"vote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollVote) Reset() {
+	*x = PollVote{}
+	mi := &file_calendar_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollVote) ProtoMessage() {}
+
+func (x *PollVote) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollVote.ProtoReflect.Descriptor instead.
+func (*PollVote) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PollVote) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *PollVote) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PollVote) GetVote() string {
+	if x != nil {
+		return x.Vote
+	}
+	return ""
+}
+
+type ResPoll struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Options     []*PollOption          `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	Invitees    []string               `protobuf:"bytes,5,rep,name=invitees,proto3" json:"invitees,omitempty"`
+	// Only with a single poll.
+	Votes []*PollVote `protobuf:"bytes,6,rep,name=votes,proto3" json:"votes,omitempty"`
+	// Empty while the poll is open.
+	FinalOptionId string                 `protobuf:"bytes,7,opt,name=final_option_id,json=finalOptionId,proto3" json:"final_option_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,8,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Organizer     string                 `protobuf:"bytes,10,opt,name=organizer,proto3" json:"organizer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResPoll) Reset() {
+	*x = ResPoll{}
+	mi := &file_calendar_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResPoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResPoll) ProtoMessage() {}
+
+func (x *ResPoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResPoll.ProtoReflect.Descriptor instead.
+func (*ResPoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ResPoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResPoll) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ResPoll) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ResPoll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ResPoll) GetInvitees() []string {
+	if x != nil {
+		return x.Invitees
+	}
+	return nil
+}
+
+func (x *ResPoll) GetVotes() []*PollVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *ResPoll) GetFinalOptionId() string {
+	if x != nil {
+		return x.FinalOptionId
+	}
+	return ""
+}
+
+func (x *ResPoll) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ResPoll) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ResPoll) GetOrganizer() string {
+	if x != nil {
+		return x.Organizer
+	}
+	return ""
+}
+
+type ResListPolls struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Polls         []*ResPoll             `protobuf:"bytes,1,rep,name=polls,proto3" json:"polls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResListPolls) Reset() {
+	*x = ResListPolls{}
+	mi := &file_calendar_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResListPolls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResListPolls) ProtoMessage() {}
+
+func (x *ResListPolls) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResListPolls.ProtoReflect.Descriptor instead.
+func (*ResListPolls) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ResListPolls) GetPolls() []*ResPoll {
+	if x != nil {
+		return x.Polls
+	}
+	return nil
+}
+
+type ReqDeletePoll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqDeletePoll) Reset() {
+	*x = ReqDeletePoll{}
+	mi := &file_calendar_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqDeletePoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqDeletePoll) ProtoMessage() {}
+
+func (x *ReqDeletePoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqDeletePoll.ProtoReflect.Descriptor instead.
+func (*ReqDeletePoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReqDeletePoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReqSetVotes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Replace the previous votes of the user, username is ignored.
+	Votes         []*PollVote `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqSetVotes) Reset() {
+	*x = ReqSetVotes{}
+	mi := &file_calendar_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqSetVotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqSetVotes) ProtoMessage() {}
+
+func (x *ReqSetVotes) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqSetVotes.ProtoReflect.Descriptor instead.
+func (*ReqSetVotes) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ReqSetVotes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqSetVotes) GetVotes() []*PollVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+type ReqTallyPoll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqTallyPoll) Reset() {
+	*x = ReqTallyPoll{}
+	mi := &file_calendar_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqTallyPoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqTallyPoll) ProtoMessage() {}
+
+func (x *ReqTallyPoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqTallyPoll.ProtoReflect.Descriptor instead.
+func (*ReqTallyPoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ReqTallyPoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OptionTally struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Option   *PollOption            `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+	Yes      int32                  `protobuf:"varint,2,opt,name=yes,proto3" json:"yes,omitempty"`
+	IfNeeded int32                  `protobuf:"varint,3,opt,name=if_needed,json=ifNeeded,proto3" json:"if_needed,omitempty"`
+	No       int32                  `protobuf:"varint,4,opt,name=no,proto3" json:"no,omitempty"`
+	// Invitees who did not vote on the option.
+	Pending       []string `protobuf:"bytes,5,rep,name=pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionTally) Reset() {
+	*x = OptionTally{}
+	mi := &file_calendar_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionTally) ProtoMessage() {}
+
+func (x *OptionTally) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionTally.ProtoReflect.Descriptor instead.
+func (*OptionTally) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *OptionTally) GetOption() *PollOption {
+	if x != nil {
+		return x.Option
+	}
+	return nil
+}
+
+func (x *OptionTally) GetYes() int32 {
+	if x != nil {
+		return x.Yes
+	}
+	return 0
+}
+
+func (x *OptionTally) GetIfNeeded() int32 {
+	if x != nil {
+		return x.IfNeeded
+	}
+	return 0
+}
+
+func (x *OptionTally) GetNo() int32 {
+	if x != nil {
+		return x.No
+	}
+	return 0
+}
+
+func (x *OptionTally) GetPending() []string {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+type ResTallyPoll struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Options []*OptionTally         `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	// Option most invitees can attend, empty if nobody can.
+	WinnerId      string `protobuf:"bytes,2,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResTallyPoll) Reset() {
+	*x = ResTallyPoll{}
+	mi := &file_calendar_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResTallyPoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResTallyPoll) ProtoMessage() {}
+
+func (x *ResTallyPoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResTallyPoll.ProtoReflect.Descriptor instead.
+func (*ResTallyPoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ResTallyPoll) GetOptions() []*OptionTally {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ResTallyPoll) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+type ReqFinalizePoll struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Default: the winner of the votes.
+	OptionId      string `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqFinalizePoll) Reset() {
+	*x = ReqFinalizePoll{}
+	mi := &file_calendar_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqFinalizePoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqFinalizePoll) ProtoMessage() {}
+
+func (x *ReqFinalizePoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqFinalizePoll.ProtoReflect.Descriptor instead.
+func (*ReqFinalizePoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ReqFinalizePoll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqFinalizePoll) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+type ResFinalizePoll struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Busy events overlapped if the conflict policy of the user is "warn".
+	Conflicts     []string `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResFinalizePoll) Reset() {
+	*x = ResFinalizePoll{}
+	mi := &file_calendar_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResFinalizePoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResFinalizePoll) ProtoMessage() {}
+
+func (x *ResFinalizePoll) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResFinalizePoll.ProtoReflect.Descriptor instead.
+func (*ResFinalizePoll) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ResFinalizePoll) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ResFinalizePoll) GetConflicts() []string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type ReqFreeBusy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqFreeBusy) Reset() {
+	*x = ReqFreeBusy{}
+	mi := &file_calendar_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqFreeBusy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqFreeBusy) ProtoMessage() {}
+
+func (x *ReqFreeBusy) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqFreeBusy.ProtoReflect.Descriptor instead.
+func (*ReqFreeBusy) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ReqFreeBusy) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *ReqFreeBusy) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqFreeBusy) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type Interval struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReqDeleteEvent) Reset() {
-	*x = ReqDeleteEvent{}
-	mi := &file_calendar_service_proto_msgTypes[11]
+func (x *Interval) Reset() {
+	*x = Interval{}
+	mi := &file_calendar_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReqDeleteEvent) String() string {
+func (x *Interval) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReqDeleteEvent) ProtoMessage() {}
+func (*Interval) ProtoMessage() {}
 
-func (x *ReqDeleteEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_calendar_service_proto_msgTypes[11]
+func (x *Interval) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,83 +4261,753 @@ func (x *ReqDeleteEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReqDeleteEvent.ProtoReflect.Descriptor instead.
-func (*ReqDeleteEvent) Descriptor() ([]byte, []int) {
-	return file_calendar_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Interval.ProtoReflect.Descriptor instead.
+func (*Interval) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *ReqDeleteEvent) GetId() string {
+func (x *Interval) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Interval) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type UserFreeBusy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Busy     []*Interval            `protobuf:"bytes,2,rep,name=busy,proto3" json:"busy,omitempty"`
+	// Set if the free/busy time of the user is not available.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserFreeBusy) Reset() {
+	*x = UserFreeBusy{}
+	mi := &file_calendar_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserFreeBusy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFreeBusy) ProtoMessage() {}
+
+func (x *UserFreeBusy) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFreeBusy.ProtoReflect.Descriptor instead.
+func (*UserFreeBusy) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UserFreeBusy) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserFreeBusy) GetBusy() []*Interval {
+	if x != nil {
+		return x.Busy
+	}
+	return nil
+}
+
+func (x *UserFreeBusy) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ResFreeBusy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*UserFreeBusy        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Time when any of the users is busy.
+	Busy          []*Interval `protobuf:"bytes,2,rep,name=busy,proto3" json:"busy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResFreeBusy) Reset() {
+	*x = ResFreeBusy{}
+	mi := &file_calendar_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResFreeBusy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResFreeBusy) ProtoMessage() {}
+
+func (x *ResFreeBusy) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResFreeBusy.ProtoReflect.Descriptor instead.
+func (*ResFreeBusy) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ResFreeBusy) GetUsers() []*UserFreeBusy {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ResFreeBusy) GetBusy() []*Interval {
+	if x != nil {
+		return x.Busy
+	}
+	return nil
+}
+
+type WorkingHours struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Times of day: "09:00", "18:00".
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Days as in RRULE BYDAY: "MO", "TU"..., every day if empty.
+	Weekdays []string `protobuf:"bytes,3,rep,name=weekdays,proto3" json:"weekdays,omitempty"`
+	// Default: the time zone of the user.
+	TimeZone      string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkingHours) Reset() {
+	*x = WorkingHours{}
+	mi := &file_calendar_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkingHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkingHours) ProtoMessage() {}
+
+func (x *WorkingHours) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkingHours.ProtoReflect.Descriptor instead.
+func (*WorkingHours) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *WorkingHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *WorkingHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *WorkingHours) GetWeekdays() []string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return nil
+}
+
+func (x *WorkingHours) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
 	}
 	return ""
 }
 
+type ReqFindSlots struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Usernames    []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Duration     *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	StartTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	WorkingHours *WorkingHours          `protobuf:"bytes,5,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	// Default: 5.
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqFindSlots) Reset() {
+	*x = ReqFindSlots{}
+	mi := &file_calendar_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqFindSlots) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqFindSlots) ProtoMessage() {}
+
+func (x *ReqFindSlots) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqFindSlots.ProtoReflect.Descriptor instead.
+func (*ReqFindSlots) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ReqFindSlots) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *ReqFindSlots) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ReqFindSlots) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ReqFindSlots) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ReqFindSlots) GetWorkingHours() *WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *ReqFindSlots) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ResFindSlots struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slots         []*Interval            `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResFindSlots) Reset() {
+	*x = ResFindSlots{}
+	mi := &file_calendar_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResFindSlots) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResFindSlots) ProtoMessage() {}
+
+func (x *ResFindSlots) ProtoReflect() protoreflect.Message {
+	mi := &file_calendar_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResFindSlots.ProtoReflect.Descriptor instead.
+func (*ResFindSlots) Descriptor() ([]byte, []int) {
+	return file_calendar_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ResFindSlots) GetSlots() []*Interval {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
 var File_calendar_service_proto protoreflect.FileDescriptor
 
 const file_calendar_service_proto_rawDesc = "" +
 	"\n" +
-	"\x16calendar_service.proto\x12\bcalendar\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"[\n" +
+	"\x16calendar_service.proto\x12\bcalendar\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x01\n" +
 	"\vReqRegister\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\"B\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x12'\n" +
+	"\x0fconflict_policy\x18\x05 \x01(\tR\x0econflictPolicy\"B\n" +
 	"\bReqLogin\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"-\n" +
 	"\bResLogin\x12!\n" +
-	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"G\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\xa3\x03\n" +
 	"\aResUser\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
-	"\x04role\x18\x03 \x01(\tR\x04role\"\xfa\x01\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x12'\n" +
+	"\x0fconflict_policy\x18\x05 \x01(\tR\x0econflictPolicy\x12&\n" +
+	"\x0fshare_free_busy\x18\x06 \x01(\bR\rshareFreeBusy\x12;\n" +
+	"\rworking_hours\x18\a \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x126\n" +
+	"\rout_of_office\x18\b \x03(\v2\x12.calendar.IntervalR\voutOfOffice\x12/\n" +
+	"\x13decline_unavailable\x18\t \x01(\bR\x12declineUnavailable\x12%\n" +
+	"\x0eretention_days\x18\n" +
+	" \x01(\x05R\rretentionDays\x12\x1f\n" +
+	"\vwebhook_url\x18\v \x01(\tR\n" +
+	"webhookUrl\"\xed\x02\n" +
+	"\x0fReqUserSettings\x12\x1b\n" +
+	"\ttime_zone\x18\x01 \x01(\tR\btimeZone\x12'\n" +
+	"\x0fconflict_policy\x18\x02 \x01(\tR\x0econflictPolicy\x12&\n" +
+	"\x0fshare_free_busy\x18\x03 \x01(\bR\rshareFreeBusy\x12;\n" +
+	"\rworking_hours\x18\x04 \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x126\n" +
+	"\rout_of_office\x18\x05 \x03(\v2\x12.calendar.IntervalR\voutOfOffice\x12/\n" +
+	"\x13decline_unavailable\x18\x06 \x01(\bR\x12declineUnavailable\x12%\n" +
+	"\x0eretention_days\x18\a \x01(\x05R\rretentionDays\x12\x1f\n" +
+	"\vwebhook_url\x18\b \x01(\tR\n" +
+	"webhookUrl\";\n" +
+	"\vReqSetGrant\x12\x18\n" +
+	"\agrantee\x18\x01 \x01(\tR\agrantee\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"\x86\x01\n" +
+	"\x05Grant\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\agrantee\x18\x02 \x01(\tR\agrantee\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"8\n" +
+	"\rResListGrants\x12'\n" +
+	"\x06grants\x18\x01 \x03(\v2\x0f.calendar.GrantR\x06grants\"*\n" +
+	"\x0eReqDeleteGrant\x12\x18\n" +
+	"\agrantee\x18\x01 \x01(\tR\agrantee\"\xb3\x04\n" +
 	"\x0eReqCreateEvent\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
 	"\n" +
 	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12>\n" +
-	"\rnotify_before\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\" \n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05rrule\x18\x06 \x01(\tR\x05rrule\x12\x17\n" +
+	"\aall_day\x18\a \x01(\bR\x06allDay\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\b \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\t \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\n" +
+	" \x01(\tR\btimeZone\x12\"\n" +
+	"\ftransparency\x18\v \x01(\tR\ftransparency\x120\n" +
+	"\tattendees\x18\f \x03(\v2\x12.calendar.AttendeeR\tattendees\x12\x1f\n" +
+	"\vcalendar_id\x18\r \x01(\tR\n" +
+	"calendarId\x12\x14\n" +
+	"\x05owner\x18\x0e \x01(\tR\x05owner\x12\x1c\n" +
+	"\tresources\x18\x0f \x03(\tR\tresources\x121\n" +
+	"\treminders\x18\x10 \x01(\v2\x13.calendar.RemindersR\tremindersJ\x04\b\x05\x10\x06R\rnotify_before\"x\n" +
+	"\bAttendee\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\"\n" +
+	"\favailability\x18\x04 \x01(\tR\favailability\"W\n" +
+	"\bReminder\x121\n" +
+	"\x06offset\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06offset\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\"=\n" +
+	"\tReminders\x120\n" +
+	"\treminders\x18\x01 \x03(\v2\x12.calendar.ReminderR\treminders\"=\n" +
+	"\tAttendees\x120\n" +
+	"\tattendees\x18\x01 \x03(\v2\x12.calendar.AttendeeR\tattendees\"\x1d\n" +
+	"\tResources\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\">\n" +
 	"\x0eResCreateEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x1d\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tconflicts\x18\x02 \x03(\tR\tconflicts\"\x1d\n" +
 	"\vReqGetEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x84\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xea\x05\n" +
 	"\bResEvent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
 	"\n" +
 	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12>\n" +
-	"\rnotify_before\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\"\x81\x01\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05rrule\x18\a \x01(\tR\x05rrule\x124\n" +
+	"\aexdates\x18\b \x03(\v2\x1a.google.protobuf.TimestampR\aexdates\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x12?\n" +
+	"\rrecurrence_id\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\frecurrenceId\x12\x10\n" +
+	"\x03uid\x18\v \x01(\tR\x03uid\x12\x17\n" +
+	"\aall_day\x18\f \x01(\bR\x06allDay\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\r \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x0e \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\x0f \x01(\tR\btimeZone\x12\"\n" +
+	"\ftransparency\x18\x10 \x01(\tR\ftransparency\x120\n" +
+	"\tattendees\x18\x11 \x03(\v2\x12.calendar.AttendeeR\tattendees\x12\x1c\n" +
+	"\torganizer\x18\x12 \x01(\tR\torganizer\x12\x1f\n" +
+	"\vcalendar_id\x18\x13 \x01(\tR\n" +
+	"calendarId\x12\x1c\n" +
+	"\tresources\x18\x14 \x03(\tR\tresources\x120\n" +
+	"\treminders\x18\x15 \x03(\v2\x12.calendar.ReminderR\tremindersJ\x04\b\x06\x10\aR\rnotify_before\"\xba\x01\n" +
 	"\rReqListEvents\x129\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\";\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12!\n" +
+	"\fcalendar_ids\x18\x03 \x03(\tR\vcalendarIds\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\";\n" +
 	"\rResListEvents\x12*\n" +
-	"\x06events\x18\x01 \x03(\v2\x12.calendar.ResEventR\x06events\"\x8a\x02\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.calendar.ResEventR\x06events\"\x99\x05\n" +
 	"\x0eReqUpdateEvent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
 	"\n" +
 	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12>\n" +
-	"\rnotify_before\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\" \n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05rrule\x18\a \x01(\tR\x05rrule\x12?\n" +
+	"\rrecurrence_id\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\frecurrenceId\x12\x14\n" +
+	"\x05scope\x18\t \x01(\tR\x05scope\x12\x17\n" +
+	"\aall_day\x18\n" +
+	" \x01(\bR\x06allDay\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\v \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\f \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\r \x01(\tR\btimeZone\x12\"\n" +
+	"\ftransparency\x18\x0e \x01(\tR\ftransparency\x121\n" +
+	"\tattendees\x18\x0f \x01(\v2\x13.calendar.AttendeesR\tattendees\x12\x1f\n" +
+	"\vcalendar_id\x18\x10 \x01(\tR\n" +
+	"calendarId\x121\n" +
+	"\tresources\x18\x11 \x01(\v2\x13.calendar.ResourcesR\tresources\x120\n" +
+	"\treminders\x18\x12 \x03(\v2\x12.calendar.ReminderR\tremindersJ\x04\b\x06\x10\aR\rnotify_before\"}\n" +
+	"\x11ReqCreateCalendar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12>\n" +
+	"\rnotify_before\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\"#\n" +
+	"\x11ResCreateCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\" \n" +
+	"\x0eReqGetCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc2\x01\n" +
+	"\vResCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12>\n" +
+	"\rnotify_before\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"G\n" +
+	"\x10ResListCalendars\x123\n" +
+	"\tcalendars\x18\x01 \x03(\v2\x15.calendar.ResCalendarR\tcalendars\"\x8d\x01\n" +
+	"\x11ReqUpdateCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12>\n" +
+	"\rnotify_before\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fnotifyBefore\"#\n" +
+	"\x11ReqDeleteCalendar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe3\x01\n" +
+	"\x11ReqCreateResource\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\x12K\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2+.calendar.ReqCreateResource.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"#\n" +
+	"\x11ResCreateResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\" \n" +
+	"\x0eReqGetResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa2\x02\n" +
+	"\vResResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12E\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2%.calendar.ResResource.AttributesEntryR\n" +
+	"attributes\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
+	"\x10ReqListResources\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12!\n" +
+	"\fmin_capacity\x18\x02 \x01(\x05R\vminCapacity\"G\n" +
+	"\x10ResListResources\x123\n" +
+	"\tresources\x18\x01 \x03(\v2\x15.calendar.ResResourceR\tresources\"\xf3\x01\n" +
+	"\x11ReqUpdateResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12K\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2+.calendar.ReqUpdateResource.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"#\n" +
+	"\x11ReqDeleteResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
 	"\x0eReqDeleteEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xbf\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12?\n" +
+	"\rrecurrence_id\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\frecurrenceId\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\";\n" +
+	"\x11ReqRespondToEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x85\x01\n" +
+	"\x11ReqExportCalendar\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"/\n" +
+	"\x11ResExportCalendar\x12\x1a\n" +
+	"\bcalendar\x18\x01 \x01(\tR\bcalendar\"\xa0\x02\n" +
+	"\x14ReqCreateBookingLink\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x121\n" +
+	"\x06buffer\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x06buffer\x12;\n" +
+	"\rworking_hours\x18\x05 \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x127\n" +
+	"\tlookahead\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\tlookahead\"&\n" +
+	"\x14ResCreateBookingLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11ReqGetBookingLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe5\x02\n" +
+	"\x0eResBookingLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x121\n" +
+	"\x06buffer\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06buffer\x12;\n" +
+	"\rworking_hours\x18\x06 \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x127\n" +
+	"\tlookahead\x18\a \x01(\v2\x19.google.protobuf.DurationR\tlookahead\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"T\n" +
+	"\x13ResListBookingLinks\x12=\n" +
+	"\rbooking_links\x18\x01 \x03(\v2\x18.calendar.ResBookingLinkR\fbookingLinks\"\xb0\x02\n" +
+	"\x14ReqUpdateBookingLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x121\n" +
+	"\x06buffer\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06buffer\x12;\n" +
+	"\rworking_hours\x18\x06 \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x127\n" +
+	"\tlookahead\x18\a \x01(\v2\x19.google.protobuf.DurationR\tlookahead\"&\n" +
+	"\x14ReqDeleteBookingLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x99\x01\n" +
+	"\x11ReqGetBookingPage\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\x87\x01\n" +
+	"\x0eResBookingPage\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12(\n" +
+	"\x05slots\x18\x03 \x03(\v2\x12.calendar.IntervalR\x05slots\"\x96\x01\n" +
+	"\aReqBook\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"\x91\x01\n" +
+	"\rReqCreatePoll\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12,\n" +
+	"\aoptions\x18\x03 \x03(\v2\x12.calendar.IntervalR\aoptions\x12\x1a\n" +
+	"\binvitees\x18\x04 \x03(\tR\binvitees\"\x1f\n" +
+	"\rResCreatePoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
+	"\n" +
+	"ReqGetPoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8e\x01\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"W\n" +
+	"\bPollVote\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\tR\boptionId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04vote\x18\x03 \x01(\tR\x04vote\"\xe3\x02\n" +
+	"\aResPoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12.\n" +
+	"\aoptions\x18\x04 \x03(\v2\x14.calendar.PollOptionR\aoptions\x12\x1a\n" +
+	"\binvitees\x18\x05 \x03(\tR\binvitees\x12(\n" +
+	"\x05votes\x18\x06 \x03(\v2\x12.calendar.PollVoteR\x05votes\x12&\n" +
+	"\x0ffinal_option_id\x18\a \x01(\tR\rfinalOptionId\x12\x19\n" +
+	"\bevent_id\x18\b \x01(\tR\aeventId\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1c\n" +
+	"\torganizer\x18\n" +
+	" \x01(\tR\torganizer\"7\n" +
+	"\fResListPolls\x12'\n" +
+	"\x05polls\x18\x01 \x03(\v2\x11.calendar.ResPollR\x05polls\"\x1f\n" +
+	"\rReqDeletePoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\vReqSetVotes\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x05votes\x18\x02 \x03(\v2\x12.calendar.PollVoteR\x05votes\"\x1e\n" +
+	"\fReqTallyPoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x94\x01\n" +
+	"\vOptionTally\x12,\n" +
+	"\x06option\x18\x01 \x01(\v2\x14.calendar.PollOptionR\x06option\x12\x10\n" +
+	"\x03yes\x18\x02 \x01(\x05R\x03yes\x12\x1b\n" +
+	"\tif_needed\x18\x03 \x01(\x05R\bifNeeded\x12\x0e\n" +
+	"\x02no\x18\x04 \x01(\x05R\x02no\x12\x18\n" +
+	"\apending\x18\x05 \x03(\tR\apending\"\\\n" +
+	"\fResTallyPoll\x12/\n" +
+	"\aoptions\x18\x01 \x03(\v2\x15.calendar.OptionTallyR\aoptions\x12\x1b\n" +
+	"\twinner_id\x18\x02 \x01(\tR\bwinnerId\">\n" +
+	"\x0fReqFinalizePoll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\toption_id\x18\x02 \x01(\tR\boptionId\"J\n" +
+	"\x0fResFinalizePoll\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1c\n" +
+	"\tconflicts\x18\x02 \x03(\tR\tconflicts\"\x9d\x01\n" +
+	"\vReqFreeBusy\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"|\n" +
+	"\bInterval\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"h\n" +
+	"\fUserFreeBusy\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12&\n" +
+	"\x04busy\x18\x02 \x03(\v2\x12.calendar.IntervalR\x04busy\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"c\n" +
+	"\vResFreeBusy\x12,\n" +
+	"\x05users\x18\x01 \x03(\v2\x16.calendar.UserFreeBusyR\x05users\x12&\n" +
+	"\x04busy\x18\x02 \x03(\v2\x12.calendar.IntervalR\x04busy\"o\n" +
+	"\fWorkingHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1a\n" +
+	"\bweekdays\x18\x03 \x03(\tR\bweekdays\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\"\xa8\x02\n" +
+	"\fReqFindSlots\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12;\n" +
+	"\rworking_hours\x18\x05 \x01(\v2\x16.calendar.WorkingHoursR\fworkingHours\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"8\n" +
+	"\fResFindSlots\x12(\n" +
+	"\x05slots\x18\x01 \x03(\v2\x12.calendar.IntervalR\x05slots2\xbf\x16\n" +
 	"\x0fCalendarService\x12;\n" +
 	"\bRegister\x12\x15.calendar.ReqRegister\x1a\x16.google.protobuf.Empty\"\x00\x121\n" +
 	"\x05Login\x12\x12.calendar.ReqLogin\x1a\x12.calendar.ResLogin\"\x00\x126\n" +
 	"\aGetUser\x12\x16.google.protobuf.Empty\x1a\x11.calendar.ResUser\"\x00\x12>\n" +
 	"\n" +
-	"DeleteUser\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"DeleteUser\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12I\n" +
+	"\x12UpdateUserSettings\x12\x19.calendar.ReqUserSettings\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
+	"\bSetGrant\x12\x15.calendar.ReqSetGrant\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
+	"\n" +
+	"ListGrants\x12\x16.google.protobuf.Empty\x1a\x17.calendar.ResListGrants\"\x00\x12E\n" +
+	"\x10ListSharedGrants\x12\x16.google.protobuf.Empty\x1a\x17.calendar.ResListGrants\"\x00\x12A\n" +
+	"\vDeleteGrant\x12\x18.calendar.ReqDeleteGrant\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
 	"\vCreateEvent\x12\x18.calendar.ReqCreateEvent\x1a\x18.calendar.ResCreateEvent\"\x00\x127\n" +
 	"\bGetEvent\x12\x15.calendar.ReqGetEvent\x1a\x12.calendar.ResEvent\"\x00\x12@\n" +
 	"\n" +
 	"ListEvents\x12\x17.calendar.ReqListEvents\x1a\x17.calendar.ResListEvents\"\x00\x12A\n" +
 	"\vUpdateEvent\x12\x18.calendar.ReqUpdateEvent\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
-	"\vDeleteEvent\x12\x18.calendar.ReqDeleteEvent\x1a\x16.google.protobuf.Empty\"\x00B\aZ\x05.;apib\x06proto3"
+	"\vDeleteEvent\x12\x18.calendar.ReqDeleteEvent\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"\x0eExportCalendar\x12\x1b.calendar.ReqExportCalendar\x1a\x1b.calendar.ResExportCalendar\"\x00\x12G\n" +
+	"\x0eRespondToEvent\x12\x1b.calendar.ReqRespondToEvent\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"\x0eCreateCalendar\x12\x1b.calendar.ReqCreateCalendar\x1a\x1b.calendar.ResCreateCalendar\"\x00\x12@\n" +
+	"\vGetCalendar\x12\x18.calendar.ReqGetCalendar\x1a\x15.calendar.ResCalendar\"\x00\x12E\n" +
+	"\rListCalendars\x12\x16.google.protobuf.Empty\x1a\x1a.calendar.ResListCalendars\"\x00\x12G\n" +
+	"\x0eUpdateCalendar\x12\x1b.calendar.ReqUpdateCalendar\x1a\x16.google.protobuf.Empty\"\x00\x12G\n" +
+	"\x0eDeleteCalendar\x12\x1b.calendar.ReqDeleteCalendar\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"\x0eCreateResource\x12\x1b.calendar.ReqCreateResource\x1a\x1b.calendar.ResCreateResource\"\x00\x12@\n" +
+	"\vGetResource\x12\x18.calendar.ReqGetResource\x1a\x15.calendar.ResResource\"\x00\x12I\n" +
+	"\rListResources\x12\x1a.calendar.ReqListResources\x1a\x1a.calendar.ResListResources\"\x00\x12G\n" +
+	"\x0eUpdateResource\x12\x1b.calendar.ReqUpdateResource\x1a\x16.google.protobuf.Empty\"\x00\x12G\n" +
+	"\x0eDeleteResource\x12\x1b.calendar.ReqDeleteResource\x1a\x16.google.protobuf.Empty\"\x00\x12U\n" +
+	"\x11CreateBookingLink\x12\x1e.calendar.ReqCreateBookingLink\x1a\x1e.calendar.ResCreateBookingLink\"\x00\x12I\n" +
+	"\x0eGetBookingLink\x12\x1b.calendar.ReqGetBookingLink\x1a\x18.calendar.ResBookingLink\"\x00\x12K\n" +
+	"\x10ListBookingLinks\x12\x16.google.protobuf.Empty\x1a\x1d.calendar.ResListBookingLinks\"\x00\x12M\n" +
+	"\x11UpdateBookingLink\x12\x1e.calendar.ReqUpdateBookingLink\x1a\x16.google.protobuf.Empty\"\x00\x12M\n" +
+	"\x11DeleteBookingLink\x12\x1e.calendar.ReqDeleteBookingLink\x1a\x16.google.protobuf.Empty\"\x00\x12I\n" +
+	"\x0eGetBookingPage\x12\x1b.calendar.ReqGetBookingPage\x1a\x18.calendar.ResBookingPage\"\x00\x12/\n" +
+	"\x04Book\x12\x11.calendar.ReqBook\x1a\x12.calendar.Interval\"\x00\x12@\n" +
+	"\n" +
+	"CreatePoll\x12\x17.calendar.ReqCreatePoll\x1a\x17.calendar.ResCreatePoll\"\x00\x124\n" +
+	"\aGetPoll\x12\x14.calendar.ReqGetPoll\x1a\x11.calendar.ResPoll\"\x00\x12=\n" +
+	"\tListPolls\x12\x16.google.protobuf.Empty\x1a\x16.calendar.ResListPolls\"\x00\x12?\n" +
+	"\n" +
+	"DeletePoll\x12\x17.calendar.ReqDeletePoll\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
+	"\bSetVotes\x12\x15.calendar.ReqSetVotes\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
+	"\tTallyPoll\x12\x16.calendar.ReqTallyPoll\x1a\x16.calendar.ResTallyPoll\"\x00\x12F\n" +
+	"\fFinalizePoll\x12\x19.calendar.ReqFinalizePoll\x1a\x19.calendar.ResFinalizePoll\"\x00\x12:\n" +
+	"\bFreeBusy\x12\x15.calendar.ReqFreeBusy\x1a\x15.calendar.ResFreeBusy\"\x00\x12=\n" +
+	"\tFindSlots\x12\x16.calendar.ReqFindSlots\x1a\x16.calendar.ResFindSlots\"\x00B\aZ\x05.;apib\x06proto3"
 
 var (
 	file_calendar_service_proto_rawDescOnce sync.Once
@@ -790,60 +5021,258 @@ func file_calendar_service_proto_rawDescGZIP() []byte {
 	return file_calendar_service_proto_rawDescData
 }
 
-var file_calendar_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_calendar_service_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_calendar_service_proto_goTypes = []any{
 	(*ReqRegister)(nil),           // 0: calendar.ReqRegister
 	(*ReqLogin)(nil),              // 1: calendar.ReqLogin
 	(*ResLogin)(nil),              // 2: calendar.ResLogin
 	(*ResUser)(nil),               // 3: calendar.ResUser
-	(*ReqCreateEvent)(nil),        // 4: calendar.ReqCreateEvent
-	(*ResCreateEvent)(nil),        // 5: calendar.ResCreateEvent
-	(*ReqGetEvent)(nil),           // 6: calendar.ReqGetEvent
-	(*ResEvent)(nil),              // 7: calendar.ResEvent
-	(*ReqListEvents)(nil),         // 8: calendar.ReqListEvents
-	(*ResListEvents)(nil),         // 9: calendar.ResListEvents
-	(*ReqUpdateEvent)(nil),        // 10: calendar.ReqUpdateEvent
-	(*ReqDeleteEvent)(nil),        // 11: calendar.ReqDeleteEvent
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 13: google.protobuf.Duration
-	(*emptypb.Empty)(nil),         // 14: google.protobuf.Empty
+	(*ReqUserSettings)(nil),       // 4: calendar.ReqUserSettings
+	(*ReqSetGrant)(nil),           // 5: calendar.ReqSetGrant
+	(*Grant)(nil),                 // 6: calendar.Grant
+	(*ResListGrants)(nil),         // 7: calendar.ResListGrants
+	(*ReqDeleteGrant)(nil),        // 8: calendar.ReqDeleteGrant
+	(*ReqCreateEvent)(nil),        // 9: calendar.ReqCreateEvent
+	(*Attendee)(nil),              // 10: calendar.Attendee
+	(*Reminder)(nil),              // 11: calendar.Reminder
+	(*Reminders)(nil),             // 12: calendar.Reminders
+	(*Attendees)(nil),             // 13: calendar.Attendees
+	(*Resources)(nil),             // 14: calendar.Resources
+	(*ResCreateEvent)(nil),        // 15: calendar.ResCreateEvent
+	(*ReqGetEvent)(nil),           // 16: calendar.ReqGetEvent
+	(*ResEvent)(nil),              // 17: calendar.ResEvent
+	(*ReqListEvents)(nil),         // 18: calendar.ReqListEvents
+	(*ResListEvents)(nil),         // 19: calendar.ResListEvents
+	(*ReqUpdateEvent)(nil),        // 20: calendar.ReqUpdateEvent
+	(*ReqCreateCalendar)(nil),     // 21: calendar.ReqCreateCalendar
+	(*ResCreateCalendar)(nil),     // 22: calendar.ResCreateCalendar
+	(*ReqGetCalendar)(nil),        // 23: calendar.ReqGetCalendar
+	(*ResCalendar)(nil),           // 24: calendar.ResCalendar
+	(*ResListCalendars)(nil),      // 25: calendar.ResListCalendars
+	(*ReqUpdateCalendar)(nil),     // 26: calendar.ReqUpdateCalendar
+	(*ReqDeleteCalendar)(nil),     // 27: calendar.ReqDeleteCalendar
+	(*ReqCreateResource)(nil),     // 28: calendar.ReqCreateResource
+	(*ResCreateResource)(nil),     // 29: calendar.ResCreateResource
+	(*ReqGetResource)(nil),        // 30: calendar.ReqGetResource
+	(*ResResource)(nil),           // 31: calendar.ResResource
+	(*ReqListResources)(nil),      // 32: calendar.ReqListResources
+	(*ResListResources)(nil),      // 33: calendar.ResListResources
+	(*ReqUpdateResource)(nil),     // 34: calendar.ReqUpdateResource
+	(*ReqDeleteResource)(nil),     // 35: calendar.ReqDeleteResource
+	(*ReqDeleteEvent)(nil),        // 36: calendar.ReqDeleteEvent
+	(*ReqRespondToEvent)(nil),     // 37: calendar.ReqRespondToEvent
+	(*ReqExportCalendar)(nil),     // 38: calendar.ReqExportCalendar
+	(*ResExportCalendar)(nil),     // 39: calendar.ResExportCalendar
+	(*ReqCreateBookingLink)(nil),  // 40: calendar.ReqCreateBookingLink
+	(*ResCreateBookingLink)(nil),  // 41: calendar.ResCreateBookingLink
+	(*ReqGetBookingLink)(nil),     // 42: calendar.ReqGetBookingLink
+	(*ResBookingLink)(nil),        // 43: calendar.ResBookingLink
+	(*ResListBookingLinks)(nil),   // 44: calendar.ResListBookingLinks
+	(*ReqUpdateBookingLink)(nil),  // 45: calendar.ReqUpdateBookingLink
+	(*ReqDeleteBookingLink)(nil),  // 46: calendar.ReqDeleteBookingLink
+	(*ReqGetBookingPage)(nil),     // 47: calendar.ReqGetBookingPage
+	(*ResBookingPage)(nil),        // 48: calendar.ResBookingPage
+	(*ReqBook)(nil),               // 49: calendar.ReqBook
+	(*ReqCreatePoll)(nil),         // 50: calendar.ReqCreatePoll
+	(*ResCreatePoll)(nil),         // 51: calendar.ResCreatePoll
+	(*ReqGetPoll)(nil),            // 52: calendar.ReqGetPoll
+	(*PollOption)(nil),            // 53: calendar.PollOption
+	(*PollVote)(nil),              // 54: calendar.PollVote
+	(*ResPoll)(nil),               // 55: calendar.ResPoll
+	(*ResListPolls)(nil),          // 56: calendar.ResListPolls
+	(*ReqDeletePoll)(nil),         // 57: calendar.ReqDeletePoll
+	(*ReqSetVotes)(nil),           // 58: calendar.ReqSetVotes
+	(*ReqTallyPoll)(nil),          // 59: calendar.ReqTallyPoll
+	(*OptionTally)(nil),           // 60: calendar.OptionTally
+	(*ResTallyPoll)(nil),          // 61: calendar.ResTallyPoll
+	(*ReqFinalizePoll)(nil),       // 62: calendar.ReqFinalizePoll
+	(*ResFinalizePoll)(nil),       // 63: calendar.ResFinalizePoll
+	(*ReqFreeBusy)(nil),           // 64: calendar.ReqFreeBusy
+	(*Interval)(nil),              // 65: calendar.Interval
+	(*UserFreeBusy)(nil),          // 66: calendar.UserFreeBusy
+	(*ResFreeBusy)(nil),           // 67: calendar.ResFreeBusy
+	(*WorkingHours)(nil),          // 68: calendar.WorkingHours
+	(*ReqFindSlots)(nil),          // 69: calendar.ReqFindSlots
+	(*ResFindSlots)(nil),          // 70: calendar.ResFindSlots
+	nil,                           // 71: calendar.ReqCreateResource.AttributesEntry
+	nil,                           // 72: calendar.ResResource.AttributesEntry
+	nil,                           // 73: calendar.ReqUpdateResource.AttributesEntry
+	(*timestamppb.Timestamp)(nil), // 74: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 75: google.protobuf.Duration
+	(*emptypb.Empty)(nil),         // 76: google.protobuf.Empty
 }
 var file_calendar_service_proto_depIdxs = []int32{
-	12, // 0: calendar.ReqCreateEvent.start_time:type_name -> google.protobuf.Timestamp
-	12, // 1: calendar.ReqCreateEvent.end_time:type_name -> google.protobuf.Timestamp
-	13, // 2: calendar.ReqCreateEvent.notify_before:type_name -> google.protobuf.Duration
-	12, // 3: calendar.ResEvent.start_time:type_name -> google.protobuf.Timestamp
-	12, // 4: calendar.ResEvent.end_time:type_name -> google.protobuf.Timestamp
-	13, // 5: calendar.ResEvent.notify_before:type_name -> google.protobuf.Duration
-	12, // 6: calendar.ReqListEvents.start_time:type_name -> google.protobuf.Timestamp
-	12, // 7: calendar.ReqListEvents.end_time:type_name -> google.protobuf.Timestamp
-	7,  // 8: calendar.ResListEvents.events:type_name -> calendar.ResEvent
-	12, // 9: calendar.ReqUpdateEvent.start_time:type_name -> google.protobuf.Timestamp
-	12, // 10: calendar.ReqUpdateEvent.end_time:type_name -> google.protobuf.Timestamp
-	13, // 11: calendar.ReqUpdateEvent.notify_before:type_name -> google.protobuf.Duration
-	0,  // 12: calendar.CalendarService.Register:input_type -> calendar.ReqRegister
-	1,  // 13: calendar.CalendarService.Login:input_type -> calendar.ReqLogin
-	14, // 14: calendar.CalendarService.GetUser:input_type -> google.protobuf.Empty
-	14, // 15: calendar.CalendarService.DeleteUser:input_type -> google.protobuf.Empty
-	4,  // 16: calendar.CalendarService.CreateEvent:input_type -> calendar.ReqCreateEvent
-	6,  // 17: calendar.CalendarService.GetEvent:input_type -> calendar.ReqGetEvent
-	8,  // 18: calendar.CalendarService.ListEvents:input_type -> calendar.ReqListEvents
-	10, // 19: calendar.CalendarService.UpdateEvent:input_type -> calendar.ReqUpdateEvent
-	11, // 20: calendar.CalendarService.DeleteEvent:input_type -> calendar.ReqDeleteEvent
-	14, // 21: calendar.CalendarService.Register:output_type -> google.protobuf.Empty
-	2,  // 22: calendar.CalendarService.Login:output_type -> calendar.ResLogin
-	3,  // 23: calendar.CalendarService.GetUser:output_type -> calendar.ResUser
-	14, // 24: calendar.CalendarService.DeleteUser:output_type -> google.protobuf.Empty
-	5,  // 25: calendar.CalendarService.CreateEvent:output_type -> calendar.ResCreateEvent
-	7,  // 26: calendar.CalendarService.GetEvent:output_type -> calendar.ResEvent
-	9,  // 27: calendar.CalendarService.ListEvents:output_type -> calendar.ResListEvents
-	14, // 28: calendar.CalendarService.UpdateEvent:output_type -> google.protobuf.Empty
-	14, // 29: calendar.CalendarService.DeleteEvent:output_type -> google.protobuf.Empty
-	21, // [21:30] is the sub-list for method output_type
-	12, // [12:21] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	68,  // 0: calendar.ResUser.working_hours:type_name -> calendar.WorkingHours
+	65,  // 1: calendar.ResUser.out_of_office:type_name -> calendar.Interval
+	68,  // 2: calendar.ReqUserSettings.working_hours:type_name -> calendar.WorkingHours
+	65,  // 3: calendar.ReqUserSettings.out_of_office:type_name -> calendar.Interval
+	74,  // 4: calendar.Grant.created_at:type_name -> google.protobuf.Timestamp
+	6,   // 5: calendar.ResListGrants.grants:type_name -> calendar.Grant
+	74,  // 6: calendar.ReqCreateEvent.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 7: calendar.ReqCreateEvent.end_time:type_name -> google.protobuf.Timestamp
+	10,  // 8: calendar.ReqCreateEvent.attendees:type_name -> calendar.Attendee
+	12,  // 9: calendar.ReqCreateEvent.reminders:type_name -> calendar.Reminders
+	75,  // 10: calendar.Reminder.offset:type_name -> google.protobuf.Duration
+	11,  // 11: calendar.Reminders.reminders:type_name -> calendar.Reminder
+	10,  // 12: calendar.Attendees.attendees:type_name -> calendar.Attendee
+	74,  // 13: calendar.ResEvent.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 14: calendar.ResEvent.end_time:type_name -> google.protobuf.Timestamp
+	74,  // 15: calendar.ResEvent.exdates:type_name -> google.protobuf.Timestamp
+	74,  // 16: calendar.ResEvent.recurrence_id:type_name -> google.protobuf.Timestamp
+	10,  // 17: calendar.ResEvent.attendees:type_name -> calendar.Attendee
+	11,  // 18: calendar.ResEvent.reminders:type_name -> calendar.Reminder
+	74,  // 19: calendar.ReqListEvents.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 20: calendar.ReqListEvents.end_time:type_name -> google.protobuf.Timestamp
+	17,  // 21: calendar.ResListEvents.events:type_name -> calendar.ResEvent
+	74,  // 22: calendar.ReqUpdateEvent.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 23: calendar.ReqUpdateEvent.end_time:type_name -> google.protobuf.Timestamp
+	74,  // 24: calendar.ReqUpdateEvent.recurrence_id:type_name -> google.protobuf.Timestamp
+	13,  // 25: calendar.ReqUpdateEvent.attendees:type_name -> calendar.Attendees
+	14,  // 26: calendar.ReqUpdateEvent.resources:type_name -> calendar.Resources
+	11,  // 27: calendar.ReqUpdateEvent.reminders:type_name -> calendar.Reminder
+	75,  // 28: calendar.ReqCreateCalendar.notify_before:type_name -> google.protobuf.Duration
+	75,  // 29: calendar.ResCalendar.notify_before:type_name -> google.protobuf.Duration
+	74,  // 30: calendar.ResCalendar.created_at:type_name -> google.protobuf.Timestamp
+	24,  // 31: calendar.ResListCalendars.calendars:type_name -> calendar.ResCalendar
+	75,  // 32: calendar.ReqUpdateCalendar.notify_before:type_name -> google.protobuf.Duration
+	71,  // 33: calendar.ReqCreateResource.attributes:type_name -> calendar.ReqCreateResource.AttributesEntry
+	72,  // 34: calendar.ResResource.attributes:type_name -> calendar.ResResource.AttributesEntry
+	74,  // 35: calendar.ResResource.created_at:type_name -> google.protobuf.Timestamp
+	31,  // 36: calendar.ResListResources.resources:type_name -> calendar.ResResource
+	73,  // 37: calendar.ReqUpdateResource.attributes:type_name -> calendar.ReqUpdateResource.AttributesEntry
+	74,  // 38: calendar.ReqDeleteEvent.recurrence_id:type_name -> google.protobuf.Timestamp
+	74,  // 39: calendar.ReqExportCalendar.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 40: calendar.ReqExportCalendar.end_time:type_name -> google.protobuf.Timestamp
+	75,  // 41: calendar.ReqCreateBookingLink.duration:type_name -> google.protobuf.Duration
+	75,  // 42: calendar.ReqCreateBookingLink.buffer:type_name -> google.protobuf.Duration
+	68,  // 43: calendar.ReqCreateBookingLink.working_hours:type_name -> calendar.WorkingHours
+	75,  // 44: calendar.ReqCreateBookingLink.lookahead:type_name -> google.protobuf.Duration
+	75,  // 45: calendar.ResBookingLink.duration:type_name -> google.protobuf.Duration
+	75,  // 46: calendar.ResBookingLink.buffer:type_name -> google.protobuf.Duration
+	68,  // 47: calendar.ResBookingLink.working_hours:type_name -> calendar.WorkingHours
+	75,  // 48: calendar.ResBookingLink.lookahead:type_name -> google.protobuf.Duration
+	74,  // 49: calendar.ResBookingLink.created_at:type_name -> google.protobuf.Timestamp
+	43,  // 50: calendar.ResListBookingLinks.booking_links:type_name -> calendar.ResBookingLink
+	75,  // 51: calendar.ReqUpdateBookingLink.duration:type_name -> google.protobuf.Duration
+	75,  // 52: calendar.ReqUpdateBookingLink.buffer:type_name -> google.protobuf.Duration
+	68,  // 53: calendar.ReqUpdateBookingLink.working_hours:type_name -> calendar.WorkingHours
+	75,  // 54: calendar.ReqUpdateBookingLink.lookahead:type_name -> google.protobuf.Duration
+	74,  // 55: calendar.ReqGetBookingPage.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 56: calendar.ReqGetBookingPage.end_time:type_name -> google.protobuf.Timestamp
+	75,  // 57: calendar.ResBookingPage.duration:type_name -> google.protobuf.Duration
+	65,  // 58: calendar.ResBookingPage.slots:type_name -> calendar.Interval
+	74,  // 59: calendar.ReqBook.start_time:type_name -> google.protobuf.Timestamp
+	65,  // 60: calendar.ReqCreatePoll.options:type_name -> calendar.Interval
+	74,  // 61: calendar.PollOption.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 62: calendar.PollOption.end_time:type_name -> google.protobuf.Timestamp
+	53,  // 63: calendar.ResPoll.options:type_name -> calendar.PollOption
+	54,  // 64: calendar.ResPoll.votes:type_name -> calendar.PollVote
+	74,  // 65: calendar.ResPoll.created_at:type_name -> google.protobuf.Timestamp
+	55,  // 66: calendar.ResListPolls.polls:type_name -> calendar.ResPoll
+	54,  // 67: calendar.ReqSetVotes.votes:type_name -> calendar.PollVote
+	53,  // 68: calendar.OptionTally.option:type_name -> calendar.PollOption
+	60,  // 69: calendar.ResTallyPoll.options:type_name -> calendar.OptionTally
+	74,  // 70: calendar.ReqFreeBusy.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 71: calendar.ReqFreeBusy.end_time:type_name -> google.protobuf.Timestamp
+	74,  // 72: calendar.Interval.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 73: calendar.Interval.end_time:type_name -> google.protobuf.Timestamp
+	65,  // 74: calendar.UserFreeBusy.busy:type_name -> calendar.Interval
+	66,  // 75: calendar.ResFreeBusy.users:type_name -> calendar.UserFreeBusy
+	65,  // 76: calendar.ResFreeBusy.busy:type_name -> calendar.Interval
+	75,  // 77: calendar.ReqFindSlots.duration:type_name -> google.protobuf.Duration
+	74,  // 78: calendar.ReqFindSlots.start_time:type_name -> google.protobuf.Timestamp
+	74,  // 79: calendar.ReqFindSlots.end_time:type_name -> google.protobuf.Timestamp
+	68,  // 80: calendar.ReqFindSlots.working_hours:type_name -> calendar.WorkingHours
+	65,  // 81: calendar.ResFindSlots.slots:type_name -> calendar.Interval
+	0,   // 82: calendar.CalendarService.Register:input_type -> calendar.ReqRegister
+	1,   // 83: calendar.CalendarService.Login:input_type -> calendar.ReqLogin
+	76,  // 84: calendar.CalendarService.GetUser:input_type -> google.protobuf.Empty
+	76,  // 85: calendar.CalendarService.DeleteUser:input_type -> google.protobuf.Empty
+	4,   // 86: calendar.CalendarService.UpdateUserSettings:input_type -> calendar.ReqUserSettings
+	5,   // 87: calendar.CalendarService.SetGrant:input_type -> calendar.ReqSetGrant
+	76,  // 88: calendar.CalendarService.ListGrants:input_type -> google.protobuf.Empty
+	76,  // 89: calendar.CalendarService.ListSharedGrants:input_type -> google.protobuf.Empty
+	8,   // 90: calendar.CalendarService.DeleteGrant:input_type -> calendar.ReqDeleteGrant
+	9,   // 91: calendar.CalendarService.CreateEvent:input_type -> calendar.ReqCreateEvent
+	16,  // 92: calendar.CalendarService.GetEvent:input_type -> calendar.ReqGetEvent
+	18,  // 93: calendar.CalendarService.ListEvents:input_type -> calendar.ReqListEvents
+	20,  // 94: calendar.CalendarService.UpdateEvent:input_type -> calendar.ReqUpdateEvent
+	36,  // 95: calendar.CalendarService.DeleteEvent:input_type -> calendar.ReqDeleteEvent
+	38,  // 96: calendar.CalendarService.ExportCalendar:input_type -> calendar.ReqExportCalendar
+	37,  // 97: calendar.CalendarService.RespondToEvent:input_type -> calendar.ReqRespondToEvent
+	21,  // 98: calendar.CalendarService.CreateCalendar:input_type -> calendar.ReqCreateCalendar
+	23,  // 99: calendar.CalendarService.GetCalendar:input_type -> calendar.ReqGetCalendar
+	76,  // 100: calendar.CalendarService.ListCalendars:input_type -> google.protobuf.Empty
+	26,  // 101: calendar.CalendarService.UpdateCalendar:input_type -> calendar.ReqUpdateCalendar
+	27,  // 102: calendar.CalendarService.DeleteCalendar:input_type -> calendar.ReqDeleteCalendar
+	28,  // 103: calendar.CalendarService.CreateResource:input_type -> calendar.ReqCreateResource
+	30,  // 104: calendar.CalendarService.GetResource:input_type -> calendar.ReqGetResource
+	32,  // 105: calendar.CalendarService.ListResources:input_type -> calendar.ReqListResources
+	34,  // 106: calendar.CalendarService.UpdateResource:input_type -> calendar.ReqUpdateResource
+	35,  // 107: calendar.CalendarService.DeleteResource:input_type -> calendar.ReqDeleteResource
+	40,  // 108: calendar.CalendarService.CreateBookingLink:input_type -> calendar.ReqCreateBookingLink
+	42,  // 109: calendar.CalendarService.GetBookingLink:input_type -> calendar.ReqGetBookingLink
+	76,  // 110: calendar.CalendarService.ListBookingLinks:input_type -> google.protobuf.Empty
+	45,  // 111: calendar.CalendarService.UpdateBookingLink:input_type -> calendar.ReqUpdateBookingLink
+	46,  // 112: calendar.CalendarService.DeleteBookingLink:input_type -> calendar.ReqDeleteBookingLink
+	47,  // 113: calendar.CalendarService.GetBookingPage:input_type -> calendar.ReqGetBookingPage
+	49,  // 114: calendar.CalendarService.Book:input_type -> calendar.ReqBook
+	50,  // 115: calendar.CalendarService.CreatePoll:input_type -> calendar.ReqCreatePoll
+	52,  // 116: calendar.CalendarService.GetPoll:input_type -> calendar.ReqGetPoll
+	76,  // 117: calendar.CalendarService.ListPolls:input_type -> google.protobuf.Empty
+	57,  // 118: calendar.CalendarService.DeletePoll:input_type -> calendar.ReqDeletePoll
+	58,  // 119: calendar.CalendarService.SetVotes:input_type -> calendar.ReqSetVotes
+	59,  // 120: calendar.CalendarService.TallyPoll:input_type -> calendar.ReqTallyPoll
+	62,  // 121: calendar.CalendarService.FinalizePoll:input_type -> calendar.ReqFinalizePoll
+	64,  // 122: calendar.CalendarService.FreeBusy:input_type -> calendar.ReqFreeBusy
+	69,  // 123: calendar.CalendarService.FindSlots:input_type -> calendar.ReqFindSlots
+	76,  // 124: calendar.CalendarService.Register:output_type -> google.protobuf.Empty
+	2,   // 125: calendar.CalendarService.Login:output_type -> calendar.ResLogin
+	3,   // 126: calendar.CalendarService.GetUser:output_type -> calendar.ResUser
+	76,  // 127: calendar.CalendarService.DeleteUser:output_type -> google.protobuf.Empty
+	76,  // 128: calendar.CalendarService.UpdateUserSettings:output_type -> google.protobuf.Empty
+	76,  // 129: calendar.CalendarService.SetGrant:output_type -> google.protobuf.Empty
+	7,   // 130: calendar.CalendarService.ListGrants:output_type -> calendar.ResListGrants
+	7,   // 131: calendar.CalendarService.ListSharedGrants:output_type -> calendar.ResListGrants
+	76,  // 132: calendar.CalendarService.DeleteGrant:output_type -> google.protobuf.Empty
+	15,  // 133: calendar.CalendarService.CreateEvent:output_type -> calendar.ResCreateEvent
+	17,  // 134: calendar.CalendarService.GetEvent:output_type -> calendar.ResEvent
+	19,  // 135: calendar.CalendarService.ListEvents:output_type -> calendar.ResListEvents
+	76,  // 136: calendar.CalendarService.UpdateEvent:output_type -> google.protobuf.Empty
+	76,  // 137: calendar.CalendarService.DeleteEvent:output_type -> google.protobuf.Empty
+	39,  // 138: calendar.CalendarService.ExportCalendar:output_type -> calendar.ResExportCalendar
+	76,  // 139: calendar.CalendarService.RespondToEvent:output_type -> google.protobuf.Empty
+	22,  // 140: calendar.CalendarService.CreateCalendar:output_type -> calendar.ResCreateCalendar
+	24,  // 141: calendar.CalendarService.GetCalendar:output_type -> calendar.ResCalendar
+	25,  // 142: calendar.CalendarService.ListCalendars:output_type -> calendar.ResListCalendars
+	76,  // 143: calendar.CalendarService.UpdateCalendar:output_type -> google.protobuf.Empty
+	76,  // 144: calendar.CalendarService.DeleteCalendar:output_type -> google.protobuf.Empty
+	29,  // 145: calendar.CalendarService.CreateResource:output_type -> calendar.ResCreateResource
+	31,  // 146: calendar.CalendarService.GetResource:output_type -> calendar.ResResource
+	33,  // 147: calendar.CalendarService.ListResources:output_type -> calendar.ResListResources
+	76,  // 148: calendar.CalendarService.UpdateResource:output_type -> google.protobuf.Empty
+	76,  // 149: calendar.CalendarService.DeleteResource:output_type -> google.protobuf.Empty
+	41,  // 150: calendar.CalendarService.CreateBookingLink:output_type -> calendar.ResCreateBookingLink
+	43,  // 151: calendar.CalendarService.GetBookingLink:output_type -> calendar.ResBookingLink
+	44,  // 152: calendar.CalendarService.ListBookingLinks:output_type -> calendar.ResListBookingLinks
+	76,  // 153: calendar.CalendarService.UpdateBookingLink:output_type -> google.protobuf.Empty
+	76,  // 154: calendar.CalendarService.DeleteBookingLink:output_type -> google.protobuf.Empty
+	48,  // 155: calendar.CalendarService.GetBookingPage:output_type -> calendar.ResBookingPage
+	65,  // 156: calendar.CalendarService.Book:output_type -> calendar.Interval
+	51,  // 157: calendar.CalendarService.CreatePoll:output_type -> calendar.ResCreatePoll
+	55,  // 158: calendar.CalendarService.GetPoll:output_type -> calendar.ResPoll
+	56,  // 159: calendar.CalendarService.ListPolls:output_type -> calendar.ResListPolls
+	76,  // 160: calendar.CalendarService.DeletePoll:output_type -> google.protobuf.Empty
+	76,  // 161: calendar.CalendarService.SetVotes:output_type -> google.protobuf.Empty
+	61,  // 162: calendar.CalendarService.TallyPoll:output_type -> calendar.ResTallyPoll
+	63,  // 163: calendar.CalendarService.FinalizePoll:output_type -> calendar.ResFinalizePoll
+	67,  // 164: calendar.CalendarService.FreeBusy:output_type -> calendar.ResFreeBusy
+	70,  // 165: calendar.CalendarService.FindSlots:output_type -> calendar.ResFindSlots
+	124, // [124:166] is the sub-list for method output_type
+	82,  // [82:124] is the sub-list for method input_type
+	82,  // [82:82] is the sub-list for extension type_name
+	82,  // [82:82] is the sub-list for extension extendee
+	0,   // [0:82] is the sub-list for field type_name
 }
 
 func init() { file_calendar_service_proto_init() }
@@ -857,7 +5286,7 @@ func file_calendar_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calendar_service_proto_rawDesc), len(file_calendar_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
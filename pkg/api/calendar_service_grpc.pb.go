@@ -20,15 +20,48 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CalendarService_Register_FullMethodName    = "/calendar.CalendarService/Register"
-	CalendarService_Login_FullMethodName       = "/calendar.CalendarService/Login"
-	CalendarService_GetUser_FullMethodName     = "/calendar.CalendarService/GetUser"
-	CalendarService_DeleteUser_FullMethodName  = "/calendar.CalendarService/DeleteUser"
-	CalendarService_CreateEvent_FullMethodName = "/calendar.CalendarService/CreateEvent"
-	CalendarService_GetEvent_FullMethodName    = "/calendar.CalendarService/GetEvent"
-	CalendarService_ListEvents_FullMethodName  = "/calendar.CalendarService/ListEvents"
-	CalendarService_UpdateEvent_FullMethodName = "/calendar.CalendarService/UpdateEvent"
-	CalendarService_DeleteEvent_FullMethodName = "/calendar.CalendarService/DeleteEvent"
+	CalendarService_Register_FullMethodName           = "/calendar.CalendarService/Register"
+	CalendarService_Login_FullMethodName              = "/calendar.CalendarService/Login"
+	CalendarService_GetUser_FullMethodName            = "/calendar.CalendarService/GetUser"
+	CalendarService_DeleteUser_FullMethodName         = "/calendar.CalendarService/DeleteUser"
+	CalendarService_UpdateUserSettings_FullMethodName = "/calendar.CalendarService/UpdateUserSettings"
+	CalendarService_SetGrant_FullMethodName           = "/calendar.CalendarService/SetGrant"
+	CalendarService_ListGrants_FullMethodName         = "/calendar.CalendarService/ListGrants"
+	CalendarService_ListSharedGrants_FullMethodName   = "/calendar.CalendarService/ListSharedGrants"
+	CalendarService_DeleteGrant_FullMethodName        = "/calendar.CalendarService/DeleteGrant"
+	CalendarService_CreateEvent_FullMethodName        = "/calendar.CalendarService/CreateEvent"
+	CalendarService_GetEvent_FullMethodName           = "/calendar.CalendarService/GetEvent"
+	CalendarService_ListEvents_FullMethodName         = "/calendar.CalendarService/ListEvents"
+	CalendarService_UpdateEvent_FullMethodName        = "/calendar.CalendarService/UpdateEvent"
+	CalendarService_DeleteEvent_FullMethodName        = "/calendar.CalendarService/DeleteEvent"
+	CalendarService_ExportCalendar_FullMethodName     = "/calendar.CalendarService/ExportCalendar"
+	CalendarService_RespondToEvent_FullMethodName     = "/calendar.CalendarService/RespondToEvent"
+	CalendarService_CreateCalendar_FullMethodName     = "/calendar.CalendarService/CreateCalendar"
+	CalendarService_GetCalendar_FullMethodName        = "/calendar.CalendarService/GetCalendar"
+	CalendarService_ListCalendars_FullMethodName      = "/calendar.CalendarService/ListCalendars"
+	CalendarService_UpdateCalendar_FullMethodName     = "/calendar.CalendarService/UpdateCalendar"
+	CalendarService_DeleteCalendar_FullMethodName     = "/calendar.CalendarService/DeleteCalendar"
+	CalendarService_CreateResource_FullMethodName     = "/calendar.CalendarService/CreateResource"
+	CalendarService_GetResource_FullMethodName        = "/calendar.CalendarService/GetResource"
+	CalendarService_ListResources_FullMethodName      = "/calendar.CalendarService/ListResources"
+	CalendarService_UpdateResource_FullMethodName     = "/calendar.CalendarService/UpdateResource"
+	CalendarService_DeleteResource_FullMethodName     = "/calendar.CalendarService/DeleteResource"
+	CalendarService_CreateBookingLink_FullMethodName  = "/calendar.CalendarService/CreateBookingLink"
+	CalendarService_GetBookingLink_FullMethodName     = "/calendar.CalendarService/GetBookingLink"
+	CalendarService_ListBookingLinks_FullMethodName   = "/calendar.CalendarService/ListBookingLinks"
+	CalendarService_UpdateBookingLink_FullMethodName  = "/calendar.CalendarService/UpdateBookingLink"
+	CalendarService_DeleteBookingLink_FullMethodName  = "/calendar.CalendarService/DeleteBookingLink"
+	CalendarService_GetBookingPage_FullMethodName     = "/calendar.CalendarService/GetBookingPage"
+	CalendarService_Book_FullMethodName               = "/calendar.CalendarService/Book"
+	CalendarService_CreatePoll_FullMethodName         = "/calendar.CalendarService/CreatePoll"
+	CalendarService_GetPoll_FullMethodName            = "/calendar.CalendarService/GetPoll"
+	CalendarService_ListPolls_FullMethodName          = "/calendar.CalendarService/ListPolls"
+	CalendarService_DeletePoll_FullMethodName         = "/calendar.CalendarService/DeletePoll"
+	CalendarService_SetVotes_FullMethodName           = "/calendar.CalendarService/SetVotes"
+	CalendarService_TallyPoll_FullMethodName          = "/calendar.CalendarService/TallyPoll"
+	CalendarService_FinalizePoll_FullMethodName       = "/calendar.CalendarService/FinalizePoll"
+	CalendarService_FreeBusy_FullMethodName           = "/calendar.CalendarService/FreeBusy"
+	CalendarService_FindSlots_FullMethodName          = "/calendar.CalendarService/FindSlots"
 )
 
 // CalendarServiceClient is the client API for CalendarService service.
@@ -40,12 +73,52 @@ type CalendarServiceClient interface {
 	Login(ctx context.Context, in *ReqLogin, opts ...grpc.CallOption) (*ResLogin, error)
 	GetUser(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResUser, error)
 	DeleteUser(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateUserSettings(ctx context.Context, in *ReqUserSettings, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sharing
+	SetGrant(ctx context.Context, in *ReqSetGrant, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListGrants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListGrants, error)
+	ListSharedGrants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListGrants, error)
+	DeleteGrant(ctx context.Context, in *ReqDeleteGrant, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Events
 	CreateEvent(ctx context.Context, in *ReqCreateEvent, opts ...grpc.CallOption) (*ResCreateEvent, error)
 	GetEvent(ctx context.Context, in *ReqGetEvent, opts ...grpc.CallOption) (*ResEvent, error)
 	ListEvents(ctx context.Context, in *ReqListEvents, opts ...grpc.CallOption) (*ResListEvents, error)
 	UpdateEvent(ctx context.Context, in *ReqUpdateEvent, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteEvent(ctx context.Context, in *ReqDeleteEvent, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExportCalendar(ctx context.Context, in *ReqExportCalendar, opts ...grpc.CallOption) (*ResExportCalendar, error)
+	RespondToEvent(ctx context.Context, in *ReqRespondToEvent, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Calendars
+	CreateCalendar(ctx context.Context, in *ReqCreateCalendar, opts ...grpc.CallOption) (*ResCreateCalendar, error)
+	GetCalendar(ctx context.Context, in *ReqGetCalendar, opts ...grpc.CallOption) (*ResCalendar, error)
+	ListCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListCalendars, error)
+	UpdateCalendar(ctx context.Context, in *ReqUpdateCalendar, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteCalendar(ctx context.Context, in *ReqDeleteCalendar, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Resources
+	CreateResource(ctx context.Context, in *ReqCreateResource, opts ...grpc.CallOption) (*ResCreateResource, error)
+	GetResource(ctx context.Context, in *ReqGetResource, opts ...grpc.CallOption) (*ResResource, error)
+	ListResources(ctx context.Context, in *ReqListResources, opts ...grpc.CallOption) (*ResListResources, error)
+	UpdateResource(ctx context.Context, in *ReqUpdateResource, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteResource(ctx context.Context, in *ReqDeleteResource, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Booking links
+	CreateBookingLink(ctx context.Context, in *ReqCreateBookingLink, opts ...grpc.CallOption) (*ResCreateBookingLink, error)
+	GetBookingLink(ctx context.Context, in *ReqGetBookingLink, opts ...grpc.CallOption) (*ResBookingLink, error)
+	ListBookingLinks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListBookingLinks, error)
+	UpdateBookingLink(ctx context.Context, in *ReqUpdateBookingLink, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteBookingLink(ctx context.Context, in *ReqDeleteBookingLink, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Public, no account is needed.
+	GetBookingPage(ctx context.Context, in *ReqGetBookingPage, opts ...grpc.CallOption) (*ResBookingPage, error)
+	Book(ctx context.Context, in *ReqBook, opts ...grpc.CallOption) (*Interval, error)
+	// Polls
+	CreatePoll(ctx context.Context, in *ReqCreatePoll, opts ...grpc.CallOption) (*ResCreatePoll, error)
+	GetPoll(ctx context.Context, in *ReqGetPoll, opts ...grpc.CallOption) (*ResPoll, error)
+	ListPolls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListPolls, error)
+	DeletePoll(ctx context.Context, in *ReqDeletePoll, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetVotes(ctx context.Context, in *ReqSetVotes, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TallyPoll(ctx context.Context, in *ReqTallyPoll, opts ...grpc.CallOption) (*ResTallyPoll, error)
+	FinalizePoll(ctx context.Context, in *ReqFinalizePoll, opts ...grpc.CallOption) (*ResFinalizePoll, error)
+	// Free/busy
+	FreeBusy(ctx context.Context, in *ReqFreeBusy, opts ...grpc.CallOption) (*ResFreeBusy, error)
+	FindSlots(ctx context.Context, in *ReqFindSlots, opts ...grpc.CallOption) (*ResFindSlots, error)
 }
 
 type calendarServiceClient struct {
@@ -96,6 +169,56 @@ func (c *calendarServiceClient) DeleteUser(ctx context.Context, in *emptypb.Empt
 	return out, nil
 }
 
+func (c *calendarServiceClient) UpdateUserSettings(ctx context.Context, in *ReqUserSettings, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateUserSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) SetGrant(ctx context.Context, in *ReqSetGrant, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_SetGrant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListGrants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListGrants, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListGrants)
+	err := c.cc.Invoke(ctx, CalendarService_ListGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListSharedGrants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListGrants, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListGrants)
+	err := c.cc.Invoke(ctx, CalendarService_ListSharedGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteGrant(ctx context.Context, in *ReqDeleteGrant, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteGrant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *calendarServiceClient) CreateEvent(ctx context.Context, in *ReqCreateEvent, opts ...grpc.CallOption) (*ResCreateEvent, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ResCreateEvent)
@@ -113,268 +236,1281 @@ func (c *calendarServiceClient) GetEvent(ctx context.Context, in *ReqGetEvent, o
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ReqListEvents, opts ...grpc.CallOption) (*ResListEvents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListEvents)
+	err := c.cc.Invoke(ctx, CalendarService_ListEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateEvent(ctx context.Context, in *ReqUpdateEvent, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteEvent(ctx context.Context, in *ReqDeleteEvent, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ExportCalendar(ctx context.Context, in *ReqExportCalendar, opts ...grpc.CallOption) (*ResExportCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResExportCalendar)
+	err := c.cc.Invoke(ctx, CalendarService_ExportCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) RespondToEvent(ctx context.Context, in *ReqRespondToEvent, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_RespondToEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateCalendar(ctx context.Context, in *ReqCreateCalendar, opts ...grpc.CallOption) (*ResCreateCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResCreateCalendar)
+	err := c.cc.Invoke(ctx, CalendarService_CreateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetCalendar(ctx context.Context, in *ReqGetCalendar, opts ...grpc.CallOption) (*ResCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResCalendar)
+	err := c.cc.Invoke(ctx, CalendarService_GetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListCalendars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListCalendars, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListCalendars)
+	err := c.cc.Invoke(ctx, CalendarService_ListCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateCalendar(ctx context.Context, in *ReqUpdateCalendar, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteCalendar(ctx context.Context, in *ReqDeleteCalendar, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateResource(ctx context.Context, in *ReqCreateResource, opts ...grpc.CallOption) (*ResCreateResource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResCreateResource)
+	err := c.cc.Invoke(ctx, CalendarService_CreateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetResource(ctx context.Context, in *ReqGetResource, opts ...grpc.CallOption) (*ResResource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResResource)
+	err := c.cc.Invoke(ctx, CalendarService_GetResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListResources(ctx context.Context, in *ReqListResources, opts ...grpc.CallOption) (*ResListResources, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListResources)
+	err := c.cc.Invoke(ctx, CalendarService_ListResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateResource(ctx context.Context, in *ReqUpdateResource, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteResource(ctx context.Context, in *ReqDeleteResource, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateBookingLink(ctx context.Context, in *ReqCreateBookingLink, opts ...grpc.CallOption) (*ResCreateBookingLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResCreateBookingLink)
+	err := c.cc.Invoke(ctx, CalendarService_CreateBookingLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetBookingLink(ctx context.Context, in *ReqGetBookingLink, opts ...grpc.CallOption) (*ResBookingLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResBookingLink)
+	err := c.cc.Invoke(ctx, CalendarService_GetBookingLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListBookingLinks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListBookingLinks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListBookingLinks)
+	err := c.cc.Invoke(ctx, CalendarService_ListBookingLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateBookingLink(ctx context.Context, in *ReqUpdateBookingLink, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_UpdateBookingLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeleteBookingLink(ctx context.Context, in *ReqDeleteBookingLink, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeleteBookingLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetBookingPage(ctx context.Context, in *ReqGetBookingPage, opts ...grpc.CallOption) (*ResBookingPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResBookingPage)
+	err := c.cc.Invoke(ctx, CalendarService_GetBookingPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) Book(ctx context.Context, in *ReqBook, opts ...grpc.CallOption) (*Interval, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Interval)
+	err := c.cc.Invoke(ctx, CalendarService_Book_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreatePoll(ctx context.Context, in *ReqCreatePoll, opts ...grpc.CallOption) (*ResCreatePoll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResCreatePoll)
+	err := c.cc.Invoke(ctx, CalendarService_CreatePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetPoll(ctx context.Context, in *ReqGetPoll, opts ...grpc.CallOption) (*ResPoll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResPoll)
+	err := c.cc.Invoke(ctx, CalendarService_GetPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListPolls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResListPolls, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResListPolls)
+	err := c.cc.Invoke(ctx, CalendarService_ListPolls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) DeletePoll(ctx context.Context, in *ReqDeletePoll, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_DeletePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) SetVotes(ctx context.Context, in *ReqSetVotes, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CalendarService_SetVotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) TallyPoll(ctx context.Context, in *ReqTallyPoll, opts ...grpc.CallOption) (*ResTallyPoll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResTallyPoll)
+	err := c.cc.Invoke(ctx, CalendarService_TallyPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) FinalizePoll(ctx context.Context, in *ReqFinalizePoll, opts ...grpc.CallOption) (*ResFinalizePoll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResFinalizePoll)
+	err := c.cc.Invoke(ctx, CalendarService_FinalizePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) FreeBusy(ctx context.Context, in *ReqFreeBusy, opts ...grpc.CallOption) (*ResFreeBusy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResFreeBusy)
+	err := c.cc.Invoke(ctx, CalendarService_FreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) FindSlots(ctx context.Context, in *ReqFindSlots, opts ...grpc.CallOption) (*ResFindSlots, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResFindSlots)
+	err := c.cc.Invoke(ctx, CalendarService_FindSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceServer is the server API for CalendarService service.
+// All implementations should embed UnimplementedCalendarServiceServer
+// for forward compatibility.
+type CalendarServiceServer interface {
+	// Users
+	Register(context.Context, *ReqRegister) (*emptypb.Empty, error)
+	Login(context.Context, *ReqLogin) (*ResLogin, error)
+	GetUser(context.Context, *emptypb.Empty) (*ResUser, error)
+	DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	UpdateUserSettings(context.Context, *ReqUserSettings) (*emptypb.Empty, error)
+	// Sharing
+	SetGrant(context.Context, *ReqSetGrant) (*emptypb.Empty, error)
+	ListGrants(context.Context, *emptypb.Empty) (*ResListGrants, error)
+	ListSharedGrants(context.Context, *emptypb.Empty) (*ResListGrants, error)
+	DeleteGrant(context.Context, *ReqDeleteGrant) (*emptypb.Empty, error)
+	// Events
+	CreateEvent(context.Context, *ReqCreateEvent) (*ResCreateEvent, error)
+	GetEvent(context.Context, *ReqGetEvent) (*ResEvent, error)
+	ListEvents(context.Context, *ReqListEvents) (*ResListEvents, error)
+	UpdateEvent(context.Context, *ReqUpdateEvent) (*emptypb.Empty, error)
+	DeleteEvent(context.Context, *ReqDeleteEvent) (*emptypb.Empty, error)
+	ExportCalendar(context.Context, *ReqExportCalendar) (*ResExportCalendar, error)
+	RespondToEvent(context.Context, *ReqRespondToEvent) (*emptypb.Empty, error)
+	// Calendars
+	CreateCalendar(context.Context, *ReqCreateCalendar) (*ResCreateCalendar, error)
+	GetCalendar(context.Context, *ReqGetCalendar) (*ResCalendar, error)
+	ListCalendars(context.Context, *emptypb.Empty) (*ResListCalendars, error)
+	UpdateCalendar(context.Context, *ReqUpdateCalendar) (*emptypb.Empty, error)
+	DeleteCalendar(context.Context, *ReqDeleteCalendar) (*emptypb.Empty, error)
+	// Resources
+	CreateResource(context.Context, *ReqCreateResource) (*ResCreateResource, error)
+	GetResource(context.Context, *ReqGetResource) (*ResResource, error)
+	ListResources(context.Context, *ReqListResources) (*ResListResources, error)
+	UpdateResource(context.Context, *ReqUpdateResource) (*emptypb.Empty, error)
+	DeleteResource(context.Context, *ReqDeleteResource) (*emptypb.Empty, error)
+	// Booking links
+	CreateBookingLink(context.Context, *ReqCreateBookingLink) (*ResCreateBookingLink, error)
+	GetBookingLink(context.Context, *ReqGetBookingLink) (*ResBookingLink, error)
+	ListBookingLinks(context.Context, *emptypb.Empty) (*ResListBookingLinks, error)
+	UpdateBookingLink(context.Context, *ReqUpdateBookingLink) (*emptypb.Empty, error)
+	DeleteBookingLink(context.Context, *ReqDeleteBookingLink) (*emptypb.Empty, error)
+	// Public, no account is needed.
+	GetBookingPage(context.Context, *ReqGetBookingPage) (*ResBookingPage, error)
+	Book(context.Context, *ReqBook) (*Interval, error)
+	// Polls
+	CreatePoll(context.Context, *ReqCreatePoll) (*ResCreatePoll, error)
+	GetPoll(context.Context, *ReqGetPoll) (*ResPoll, error)
+	ListPolls(context.Context, *emptypb.Empty) (*ResListPolls, error)
+	DeletePoll(context.Context, *ReqDeletePoll) (*emptypb.Empty, error)
+	SetVotes(context.Context, *ReqSetVotes) (*emptypb.Empty, error)
+	TallyPoll(context.Context, *ReqTallyPoll) (*ResTallyPoll, error)
+	FinalizePoll(context.Context, *ReqFinalizePoll) (*ResFinalizePoll, error)
+	// Free/busy
+	FreeBusy(context.Context, *ReqFreeBusy) (*ResFreeBusy, error)
+	FindSlots(context.Context, *ReqFindSlots) (*ResFindSlots, error)
+}
+
+// UnimplementedCalendarServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalendarServiceServer struct{}
+
+func (UnimplementedCalendarServiceServer) Register(context.Context, *ReqRegister) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedCalendarServiceServer) Login(context.Context, *ReqLogin) (*ResLogin, error) {
+	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetUser(context.Context, *emptypb.Empty) (*ResUser, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateUserSettings(context.Context, *ReqUserSettings) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUserSettings not implemented")
+}
+func (UnimplementedCalendarServiceServer) SetGrant(context.Context, *ReqSetGrant) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetGrant not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListGrants(context.Context, *emptypb.Empty) (*ResListGrants, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListGrants not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListSharedGrants(context.Context, *emptypb.Empty) (*ResListGrants, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSharedGrants not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteGrant(context.Context, *ReqDeleteGrant) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteGrant not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateEvent(context.Context, *ReqCreateEvent) (*ResCreateEvent, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetEvent(context.Context, *ReqGetEvent) (*ResEvent, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListEvents(context.Context, *ReqListEvents) (*ResListEvents, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateEvent(context.Context, *ReqUpdateEvent) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteEvent(context.Context, *ReqDeleteEvent) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) ExportCalendar(context.Context, *ReqExportCalendar) (*ResExportCalendar, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) RespondToEvent(context.Context, *ReqRespondToEvent) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RespondToEvent not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateCalendar(context.Context, *ReqCreateCalendar) (*ResCreateCalendar, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetCalendar(context.Context, *ReqGetCalendar) (*ResCalendar, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListCalendars(context.Context, *emptypb.Empty) (*ResListCalendars, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateCalendar(context.Context, *ReqUpdateCalendar) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteCalendar(context.Context, *ReqDeleteCalendar) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateResource(context.Context, *ReqCreateResource) (*ResCreateResource, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetResource(context.Context, *ReqGetResource) (*ResResource, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListResources(context.Context, *ReqListResources) (*ResListResources, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListResources not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateResource(context.Context, *ReqUpdateResource) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteResource(context.Context, *ReqDeleteResource) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteResource not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreateBookingLink(context.Context, *ReqCreateBookingLink) (*ResCreateBookingLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateBookingLink not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetBookingLink(context.Context, *ReqGetBookingLink) (*ResBookingLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBookingLink not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListBookingLinks(context.Context, *emptypb.Empty) (*ResListBookingLinks, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBookingLinks not implemented")
+}
+func (UnimplementedCalendarServiceServer) UpdateBookingLink(context.Context, *ReqUpdateBookingLink) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateBookingLink not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeleteBookingLink(context.Context, *ReqDeleteBookingLink) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBookingLink not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetBookingPage(context.Context, *ReqGetBookingPage) (*ResBookingPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBookingPage not implemented")
+}
+func (UnimplementedCalendarServiceServer) Book(context.Context, *ReqBook) (*Interval, error) {
+	return nil, status.Error(codes.Unimplemented, "method Book not implemented")
+}
+func (UnimplementedCalendarServiceServer) CreatePoll(context.Context, *ReqCreatePoll) (*ResCreatePoll, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePoll not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetPoll(context.Context, *ReqGetPoll) (*ResPoll, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPoll not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListPolls(context.Context, *emptypb.Empty) (*ResListPolls, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPolls not implemented")
+}
+func (UnimplementedCalendarServiceServer) DeletePoll(context.Context, *ReqDeletePoll) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePoll not implemented")
+}
+func (UnimplementedCalendarServiceServer) SetVotes(context.Context, *ReqSetVotes) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetVotes not implemented")
+}
+func (UnimplementedCalendarServiceServer) TallyPoll(context.Context, *ReqTallyPoll) (*ResTallyPoll, error) {
+	return nil, status.Error(codes.Unimplemented, "method TallyPoll not implemented")
+}
+func (UnimplementedCalendarServiceServer) FinalizePoll(context.Context, *ReqFinalizePoll) (*ResFinalizePoll, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinalizePoll not implemented")
+}
+func (UnimplementedCalendarServiceServer) FreeBusy(context.Context, *ReqFreeBusy) (*ResFreeBusy, error) {
+	return nil, status.Error(codes.Unimplemented, "method FreeBusy not implemented")
+}
+func (UnimplementedCalendarServiceServer) FindSlots(context.Context, *ReqFindSlots) (*ResFindSlots, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindSlots not implemented")
+}
+func (UnimplementedCalendarServiceServer) testEmbeddedByValue() {}
+
+// UnsafeCalendarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServiceServer will
+// result in compilation errors.
+type UnsafeCalendarServiceServer interface {
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+func RegisterCalendarServiceServer(s grpc.ServiceRegistrar, srv CalendarServiceServer) {
+	// If the following call panics, it indicates UnimplementedCalendarServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CalendarService_ServiceDesc, srv)
+}
+
+func _CalendarService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRegister)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Register(ctx, req.(*ReqRegister))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqLogin)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Login(ctx, req.(*ReqLogin))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetUser(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteUser(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUserSettings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateUserSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateUserSettings(ctx, req.(*ReqUserSettings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_SetGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSetGrant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).SetGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_SetGrant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).SetGrant(ctx, req.(*ReqSetGrant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListGrants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListSharedGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListSharedGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListSharedGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListSharedGrants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteGrant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteGrant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteGrant(ctx, req.(*ReqDeleteGrant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCreateEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, req.(*ReqCreateEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetEvent(ctx, req.(*ReqGetEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqListEvents)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListEvents(ctx, req.(*ReqListEvents))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUpdateEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, req.(*ReqUpdateEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, req.(*ReqDeleteEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ExportCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqExportCalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ExportCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ExportCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ExportCalendar(ctx, req.(*ReqExportCalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_RespondToEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRespondToEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).RespondToEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_RespondToEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).RespondToEvent(ctx, req.(*ReqRespondToEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCreateCalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateCalendar(ctx, req.(*ReqCreateCalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetCalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetCalendar(ctx, req.(*ReqGetCalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListCalendars(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUpdateCalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateCalendar(ctx, req.(*ReqUpdateCalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteCalendar)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteCalendar(ctx, req.(*ReqDeleteCalendar))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCreateResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateResource(ctx, req.(*ReqCreateResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetResource(ctx, req.(*ReqGetResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqListResources)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListResources(ctx, req.(*ReqListResources))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUpdateResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateResource(ctx, req.(*ReqUpdateResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteResource(ctx, req.(*ReqDeleteResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateBookingLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCreateBookingLink)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateBookingLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateBookingLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateBookingLink(ctx, req.(*ReqCreateBookingLink))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ReqListEvents, opts ...grpc.CallOption) (*ResListEvents, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ResListEvents)
-	err := c.cc.Invoke(ctx, CalendarService_ListEvents_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _CalendarService_GetBookingLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetBookingLink)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetBookingLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetBookingLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetBookingLink(ctx, req.(*ReqGetBookingLink))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *calendarServiceClient) UpdateEvent(ctx context.Context, in *ReqUpdateEvent, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, CalendarService_UpdateEvent_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _CalendarService_ListBookingLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListBookingLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListBookingLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListBookingLinks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *calendarServiceClient) DeleteEvent(ctx context.Context, in *ReqDeleteEvent, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, CalendarService_DeleteEvent_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _CalendarService_UpdateBookingLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUpdateBookingLink)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// CalendarServiceServer is the server API for CalendarService service.
-// All implementations should embed UnimplementedCalendarServiceServer
-// for forward compatibility.
-type CalendarServiceServer interface {
-	// Users
-	Register(context.Context, *ReqRegister) (*emptypb.Empty, error)
-	Login(context.Context, *ReqLogin) (*ResLogin, error)
-	GetUser(context.Context, *emptypb.Empty) (*ResUser, error)
-	DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
-	// Events
-	CreateEvent(context.Context, *ReqCreateEvent) (*ResCreateEvent, error)
-	GetEvent(context.Context, *ReqGetEvent) (*ResEvent, error)
-	ListEvents(context.Context, *ReqListEvents) (*ResListEvents, error)
-	UpdateEvent(context.Context, *ReqUpdateEvent) (*emptypb.Empty, error)
-	DeleteEvent(context.Context, *ReqDeleteEvent) (*emptypb.Empty, error)
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateBookingLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_UpdateBookingLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateBookingLink(ctx, req.(*ReqUpdateBookingLink))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedCalendarServiceServer should be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedCalendarServiceServer struct{}
-
-func (UnimplementedCalendarServiceServer) Register(context.Context, *ReqRegister) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
-}
-func (UnimplementedCalendarServiceServer) Login(context.Context, *ReqLogin) (*ResLogin, error) {
-	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
-}
-func (UnimplementedCalendarServiceServer) GetUser(context.Context, *emptypb.Empty) (*ResUser, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
-}
-func (UnimplementedCalendarServiceServer) DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteUser not implemented")
-}
-func (UnimplementedCalendarServiceServer) CreateEvent(context.Context, *ReqCreateEvent) (*ResCreateEvent, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreateEvent not implemented")
-}
-func (UnimplementedCalendarServiceServer) GetEvent(context.Context, *ReqGetEvent) (*ResEvent, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetEvent not implemented")
-}
-func (UnimplementedCalendarServiceServer) ListEvents(context.Context, *ReqListEvents) (*ResListEvents, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListEvents not implemented")
-}
-func (UnimplementedCalendarServiceServer) UpdateEvent(context.Context, *ReqUpdateEvent) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateEvent not implemented")
-}
-func (UnimplementedCalendarServiceServer) DeleteEvent(context.Context, *ReqDeleteEvent) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteEvent not implemented")
+func _CalendarService_DeleteBookingLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteBookingLink)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteBookingLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_DeleteBookingLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteBookingLink(ctx, req.(*ReqDeleteBookingLink))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedCalendarServiceServer) testEmbeddedByValue() {}
 
-// UnsafeCalendarServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CalendarServiceServer will
-// result in compilation errors.
-type UnsafeCalendarServiceServer interface {
-	mustEmbedUnimplementedCalendarServiceServer()
+func _CalendarService_GetBookingPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetBookingPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetBookingPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetBookingPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetBookingPage(ctx, req.(*ReqGetBookingPage))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterCalendarServiceServer(s grpc.ServiceRegistrar, srv CalendarServiceServer) {
-	// If the following call panics, it indicates UnimplementedCalendarServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _CalendarService_Book_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqBook)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&CalendarService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).Book(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_Book_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).Book(ctx, req.(*ReqBook))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqRegister)
+func _CalendarService_CreatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCreatePoll)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).Register(ctx, in)
+		return srv.(CalendarServiceServer).CreatePoll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_Register_FullMethodName,
+		FullMethod: CalendarService_CreatePoll_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).Register(ctx, req.(*ReqRegister))
+		return srv.(CalendarServiceServer).CreatePoll(ctx, req.(*ReqCreatePoll))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqLogin)
+func _CalendarService_GetPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetPoll)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).Login(ctx, in)
+		return srv.(CalendarServiceServer).GetPoll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_Login_FullMethodName,
+		FullMethod: CalendarService_GetPoll_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).Login(ctx, req.(*ReqLogin))
+		return srv.(CalendarServiceServer).GetPoll(ctx, req.(*ReqGetPoll))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarService_ListPolls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).GetUser(ctx, in)
+		return srv.(CalendarServiceServer).ListPolls(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_GetUser_FullMethodName,
+		FullMethod: CalendarService_ListPolls_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).GetUser(ctx, req.(*emptypb.Empty))
+		return srv.(CalendarServiceServer).ListPolls(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _CalendarService_DeletePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeletePoll)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).DeleteUser(ctx, in)
+		return srv.(CalendarServiceServer).DeletePoll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_DeleteUser_FullMethodName,
+		FullMethod: CalendarService_DeletePoll_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).DeleteUser(ctx, req.(*emptypb.Empty))
+		return srv.(CalendarServiceServer).DeletePoll(ctx, req.(*ReqDeletePoll))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqCreateEvent)
+func _CalendarService_SetVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSetVotes)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).CreateEvent(ctx, in)
+		return srv.(CalendarServiceServer).SetVotes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_CreateEvent_FullMethodName,
+		FullMethod: CalendarService_SetVotes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).CreateEvent(ctx, req.(*ReqCreateEvent))
+		return srv.(CalendarServiceServer).SetVotes(ctx, req.(*ReqSetVotes))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqGetEvent)
+func _CalendarService_TallyPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqTallyPoll)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).GetEvent(ctx, in)
+		return srv.(CalendarServiceServer).TallyPoll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_GetEvent_FullMethodName,
+		FullMethod: CalendarService_TallyPoll_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).GetEvent(ctx, req.(*ReqGetEvent))
+		return srv.(CalendarServiceServer).TallyPoll(ctx, req.(*ReqTallyPoll))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqListEvents)
+func _CalendarService_FinalizePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqFinalizePoll)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).ListEvents(ctx, in)
+		return srv.(CalendarServiceServer).FinalizePoll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_ListEvents_FullMethodName,
+		FullMethod: CalendarService_FinalizePoll_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).ListEvents(ctx, req.(*ReqListEvents))
+		return srv.(CalendarServiceServer).FinalizePoll(ctx, req.(*ReqFinalizePoll))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqUpdateEvent)
+func _CalendarService_FreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqFreeBusy)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).UpdateEvent(ctx, in)
+		return srv.(CalendarServiceServer).FreeBusy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_UpdateEvent_FullMethodName,
+		FullMethod: CalendarService_FreeBusy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).UpdateEvent(ctx, req.(*ReqUpdateEvent))
+		return srv.(CalendarServiceServer).FreeBusy(ctx, req.(*ReqFreeBusy))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReqDeleteEvent)
+func _CalendarService_FindSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqFindSlots)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).DeleteEvent(ctx, in)
+		return srv.(CalendarServiceServer).FindSlots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CalendarService_DeleteEvent_FullMethodName,
+		FullMethod: CalendarService_FindSlots_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).DeleteEvent(ctx, req.(*ReqDeleteEvent))
+		return srv.(CalendarServiceServer).FindSlots(ctx, req.(*ReqFindSlots))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -402,6 +1538,26 @@ var CalendarService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _CalendarService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "UpdateUserSettings",
+			Handler:    _CalendarService_UpdateUserSettings_Handler,
+		},
+		{
+			MethodName: "SetGrant",
+			Handler:    _CalendarService_SetGrant_Handler,
+		},
+		{
+			MethodName: "ListGrants",
+			Handler:    _CalendarService_ListGrants_Handler,
+		},
+		{
+			MethodName: "ListSharedGrants",
+			Handler:    _CalendarService_ListSharedGrants_Handler,
+		},
+		{
+			MethodName: "DeleteGrant",
+			Handler:    _CalendarService_DeleteGrant_Handler,
+		},
 		{
 			MethodName: "CreateEvent",
 			Handler:    _CalendarService_CreateEvent_Handler,
@@ -422,6 +1578,118 @@ var CalendarService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEvent",
 			Handler:    _CalendarService_DeleteEvent_Handler,
 		},
+		{
+			MethodName: "ExportCalendar",
+			Handler:    _CalendarService_ExportCalendar_Handler,
+		},
+		{
+			MethodName: "RespondToEvent",
+			Handler:    _CalendarService_RespondToEvent_Handler,
+		},
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _CalendarService_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _CalendarService_GetCalendar_Handler,
+		},
+		{
+			MethodName: "ListCalendars",
+			Handler:    _CalendarService_ListCalendars_Handler,
+		},
+		{
+			MethodName: "UpdateCalendar",
+			Handler:    _CalendarService_UpdateCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _CalendarService_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _CalendarService_CreateResource_Handler,
+		},
+		{
+			MethodName: "GetResource",
+			Handler:    _CalendarService_GetResource_Handler,
+		},
+		{
+			MethodName: "ListResources",
+			Handler:    _CalendarService_ListResources_Handler,
+		},
+		{
+			MethodName: "UpdateResource",
+			Handler:    _CalendarService_UpdateResource_Handler,
+		},
+		{
+			MethodName: "DeleteResource",
+			Handler:    _CalendarService_DeleteResource_Handler,
+		},
+		{
+			MethodName: "CreateBookingLink",
+			Handler:    _CalendarService_CreateBookingLink_Handler,
+		},
+		{
+			MethodName: "GetBookingLink",
+			Handler:    _CalendarService_GetBookingLink_Handler,
+		},
+		{
+			MethodName: "ListBookingLinks",
+			Handler:    _CalendarService_ListBookingLinks_Handler,
+		},
+		{
+			MethodName: "UpdateBookingLink",
+			Handler:    _CalendarService_UpdateBookingLink_Handler,
+		},
+		{
+			MethodName: "DeleteBookingLink",
+			Handler:    _CalendarService_DeleteBookingLink_Handler,
+		},
+		{
+			MethodName: "GetBookingPage",
+			Handler:    _CalendarService_GetBookingPage_Handler,
+		},
+		{
+			MethodName: "Book",
+			Handler:    _CalendarService_Book_Handler,
+		},
+		{
+			MethodName: "CreatePoll",
+			Handler:    _CalendarService_CreatePoll_Handler,
+		},
+		{
+			MethodName: "GetPoll",
+			Handler:    _CalendarService_GetPoll_Handler,
+		},
+		{
+			MethodName: "ListPolls",
+			Handler:    _CalendarService_ListPolls_Handler,
+		},
+		{
+			MethodName: "DeletePoll",
+			Handler:    _CalendarService_DeletePoll_Handler,
+		},
+		{
+			MethodName: "SetVotes",
+			Handler:    _CalendarService_SetVotes_Handler,
+		},
+		{
+			MethodName: "TallyPoll",
+			Handler:    _CalendarService_TallyPoll_Handler,
+		},
+		{
+			MethodName: "FinalizePoll",
+			Handler:    _CalendarService_FinalizePoll_Handler,
+		},
+		{
+			MethodName: "FreeBusy",
+			Handler:    _CalendarService_FreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlots",
+			Handler:    _CalendarService_FindSlots_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "calendar_service.proto",
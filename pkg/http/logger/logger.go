@@ -3,6 +3,7 @@ package logger
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,8 +33,14 @@ func (lrw *LoggingResponseWriter) Write(slByte []byte) (int, error) {
 	return writeByte, err //nolint:wrapcheck
 }
 
+// redacted replaces the secret part of a logged path.
+const redacted = "REDACTED"
+
 type Logger struct {
 	Inner http.Handler
+	// SecretPrefixes are the prefixes of the paths which end in a secret,
+	// such as a token, logged as REDACTED instead.
+	SecretPrefixes []string
 }
 
 func (l *Logger) ServeHTTP(res http.ResponseWriter, req *http.Request) {
@@ -43,7 +50,7 @@ func (l *Logger) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	logReq := slog.With(
 		slog.String("requestID", requestID),
 		slog.String("method", req.Method),
-		slog.String("path", req.URL.Path),
+		slog.String("path", l.redactPath(req.URL.Path)),
 		slog.String("addr", req.RemoteAddr),
 	)
 	timeStart := time.Now()
@@ -58,3 +65,13 @@ func (l *Logger) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 
 	l.Inner.ServeHTTP(lrw, req.WithContext(ctx))
 }
+
+func (l *Logger) redactPath(path string) string {
+	for _, prefix := range l.SecretPrefixes {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return prefix + redacted
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger_RedactsSecretPaths(t *testing.T) {
+	buf := new(bytes.Buffer)
+	defer func(logger *slog.Logger) { slog.SetDefault(logger) }(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, nil)))
+
+	handler := &Logger{
+		Inner:          http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+		SecretPrefixes: []string{"/api/feeds/"},
+	}
+	for _, path := range []string{"/api/feeds/s3cr3t.ics", "/api/events"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	logs := buf.String()
+	if strings.Contains(logs, "s3cr3t") || !strings.Contains(logs, "path=/api/feeds/REDACTED") {
+		t.Errorf("feed token not redacted:\n%s", logs)
+	}
+	if !strings.Contains(logs, "path=/api/events") {
+		t.Errorf("path not logged:\n%s", logs)
+	}
+}
@@ -0,0 +1,336 @@
+// Package ical encodes and decodes iCalendar (RFC 5545) objects.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	CompCalendar = "VCALENDAR"
+	CompEvent    = "VEVENT"
+	CompAlarm    = "VALARM"
+	CompTimezone = "VTIMEZONE"
+	CompStandard = "STANDARD"
+	CompDaylight = "DAYLIGHT"
+)
+
+// maxLineLen is the maximum length of a content line in octets, without CRLF.
+const maxLineLen = 75
+
+var ErrInvalidFormat = errors.New("invalid iCalendar format")
+
+type Prop struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Param returns the value of the parameter, "" if absent.
+func (p *Prop) Param(name string) string {
+	return p.Params[strings.ToUpper(name)]
+}
+
+type Component struct {
+	Name     string
+	Props    []Prop
+	Children []*Component
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name} //nolint:exhaustruct
+}
+
+// Add appends a property. Params are given as name, value pairs.
+func (c *Component) Add(name, value string, params ...string) {
+	prop := Prop{Name: strings.ToUpper(name), Params: nil, Value: value}
+	for i := 0; i+1 < len(params); i += 2 {
+		if prop.Params == nil {
+			prop.Params = make(map[string]string)
+		}
+		prop.Params[strings.ToUpper(params[i])] = params[i+1]
+	}
+	c.Props = append(c.Props, prop)
+}
+
+// AddText appends a property with an escaped TEXT value.
+func (c *Component) AddText(name, value string) {
+	c.Add(name, EscapeText(value))
+}
+
+// Get returns the first property with the name, nil if absent.
+func (c *Component) Get(name string) *Prop {
+	name = strings.ToUpper(name)
+	for i := range c.Props {
+		if c.Props[i].Name == name {
+			return &c.Props[i]
+		}
+	}
+
+	return nil
+}
+
+// GetAll returns all properties with the name.
+func (c *Component) GetAll(name string) []Prop {
+	name = strings.ToUpper(name)
+	var props []Prop
+	for _, prop := range c.Props {
+		if prop.Name == name {
+			props = append(props, prop)
+		}
+	}
+
+	return props
+}
+
+// Text returns the unescaped TEXT value of the first property with the name.
+func (c *Component) Text(name string) string {
+	if prop := c.Get(name); prop != nil {
+		return UnescapeText(prop.Value)
+	}
+
+	return ""
+}
+
+// ChildrenByName returns the nested components with the name.
+func (c *Component) ChildrenByName(name string) []*Component {
+	var children []*Component
+	for _, child := range c.Children {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// Encode writes the component in iCalendar format with folded CRLF lines.
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	encodeComponent(bw, c)
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	return nil
+}
+
+// String returns the component in iCalendar format.
+func (c *Component) String() string {
+	var sb strings.Builder
+	_ = Encode(&sb, c)
+
+	return sb.String()
+}
+
+func encodeComponent(bw *bufio.Writer, c *Component) {
+	writeLine(bw, "BEGIN:"+c.Name)
+	for _, prop := range c.Props {
+		var line strings.Builder
+		line.WriteString(prop.Name)
+		names := make([]string, 0, len(prop.Params))
+		for name := range prop.Params {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			line.WriteString(";" + name + "=" + quoteParam(prop.Params[name]))
+		}
+		line.WriteString(":" + prop.Value)
+		writeLine(bw, line.String())
+	}
+	for _, child := range c.Children {
+		encodeComponent(bw, child)
+	}
+	writeLine(bw, "END:"+c.Name)
+}
+
+// writeLine folds the line at maxLineLen octets without splitting UTF-8 characters.
+func writeLine(bw *bufio.Writer, line string) {
+	limit := maxLineLen
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		bw.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineLen - 1
+	}
+	bw.WriteString(line + "\r\n")
+}
+
+func quoteParam(value string) string {
+	if strings.ContainsAny(value, ";:,") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
+
+// Decode reads a single top-level component, usually VCALENDAR.
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+	var root *Component
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidFormat, i+1, err)
+		}
+		switch prop.Name {
+		case "BEGIN":
+			if root != nil && len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: data after end of %s", ErrInvalidFormat, i+1, root.Name)
+			}
+			comp := NewComponent(strings.ToUpper(prop.Value))
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, comp)
+			} else {
+				root = comp
+			}
+			stack = append(stack, comp)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidFormat, i+1, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside component", ErrInvalidFormat, i+1)
+			}
+			comp := stack[len(stack)-1]
+			comp.Props = append(comp.Props, prop)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w: no component", ErrInvalidFormat)
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidFormat, stack[len(stack)-1].Name)
+	}
+
+	return root, nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	const maxTokenSize = 1 << 20
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxTokenSize)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseLine parses a content line: name *(";" param) ":" value.
+func parseLine(line string) (Prop, error) {
+	var prop Prop
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, errors.New("missing property name")
+	}
+	prop.Name = strings.ToUpper(line[:i])
+	for line[i] == ';' {
+		line = line[i+1:]
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			return prop, errors.New("malformed parameter")
+		}
+		name := strings.ToUpper(line[:eq])
+		line = line[eq+1:]
+
+		var value string
+		var values []string
+		for {
+			if strings.HasPrefix(line, `"`) {
+				end := strings.IndexByte(line[1:], '"')
+				if end < 0 {
+					return prop, errors.New("unterminated quoted parameter")
+				}
+				value = line[1 : end+1]
+				line = line[end+2:]
+			} else {
+				end := strings.IndexAny(line, ",;:")
+				if end < 0 {
+					return prop, errors.New("missing value")
+				}
+				value = line[:end]
+				line = line[end:]
+			}
+			values = append(values, value)
+			if !strings.HasPrefix(line, ",") {
+				break
+			}
+			line = line[1:]
+		}
+		if prop.Params == nil {
+			prop.Params = make(map[string]string)
+		}
+		prop.Params[name] = strings.Join(values, ",")
+
+		i = 0
+		if line == "" {
+			return prop, errors.New("missing value")
+		}
+	}
+	if line[i] != ':' {
+		return prop, errors.New("missing value")
+	}
+	prop.Value = line[i+1:]
+
+	return prop, nil
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// EscapeText escapes a TEXT value.
+func EscapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// UnescapeText unescapes a TEXT value.
+func UnescapeText(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 == len(text) {
+			sb.WriteByte(text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 'n', 'N':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(text[i])
+		}
+	}
+
+	return sb.String()
+}
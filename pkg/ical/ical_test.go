@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	cal := NewComponent(CompCalendar)
+	cal.Add("VERSION", "2.0")
+	event := NewComponent(CompEvent)
+	event.Add("UID", "42")
+	event.Add("DTSTART", "20250106T090000", "TZID", "Europe/Berlin")
+	event.AddText("SUMMARY", "Stand-up; daily, short")
+	event.AddText("DESCRIPTION", strings.Repeat("Длинное описание. ", 10)+"\nLast line")
+	event.Add("ATTENDEE", "mailto:bob@mail.com", "CN", "Bob, Jr.")
+	cal.Children = append(cal.Children, event)
+
+	encoded := cal.String()
+	for _, line := range strings.Split(strings.TrimSuffix(encoded, "\r\n"), "\r\n") {
+		if len(line) > maxLineLen {
+			t.Errorf("line longer than %d octets: %q", maxLineLen, line)
+		}
+	}
+
+	decoded, err := Decode(strings.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	events := decoded.ChildrenByName(CompEvent)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if have, want := events[0].Text("SUMMARY"), "Stand-up; daily, short"; have != want {
+		t.Errorf("SUMMARY: have %q, want %q", have, want)
+	}
+	if have, want := events[0].Text("DESCRIPTION"), event.Text("DESCRIPTION"); have != want {
+		t.Errorf("DESCRIPTION: have %q, want %q", have, want)
+	}
+	if have := events[0].Get("DTSTART").Param("tzid"); have != "Europe/Berlin" {
+		t.Errorf("TZID: have %q", have)
+	}
+	if have := events[0].Get("ATTENDEE").Param("CN"); have != "Bob, Jr." {
+		t.Errorf("CN: have %q", have)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VCALENDAR\r\nEND:VEVENT\r\n",
+		"VERSION:2.0\r\n",
+		"BEGIN:VCALENDAR\r\nVERSION\r\nEND:VCALENDAR\r\n",
+	}
+	for _, str := range tests {
+		if _, err := Decode(strings.NewReader(str)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Decode(%q): expected ErrInvalidFormat, got %v", str, err)
+		}
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	tests := []struct {
+		prop Prop
+		want time.Time
+	}{
+		{Prop{Name: "DTSTART", Value: "20250106T090000Z"}, time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{Prop{Name: "DTSTART", Value: "20250106T090000", Params: map[string]string{"TZID": "Europe/Berlin"}}, time.Date(2025, 1, 6, 9, 0, 0, 0, berlin)},
+		{Prop{Name: "DTSTART", Value: "20250106", Params: map[string]string{"VALUE": "DATE"}}, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		have, err := ParseDateTime(&test.prop, time.UTC)
+		if err != nil {
+			t.Errorf("ParseDateTime(%v): %v", test.prop, err)
+			continue
+		}
+		if !have.Equal(test.want) {
+			t.Errorf("ParseDateTime(%v): have %v, want %v", test.prop, have, test.want)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d   time.Duration
+		str string
+	}{
+		{0, "PT0S"},
+		{-15 * time.Minute, "-PT15M"},
+		{26*time.Hour + 30*time.Second, "P1DT2H30S"},
+		{48 * time.Hour, "P2D"},
+	}
+	for _, test := range tests {
+		if have := FormatDuration(test.d); have != test.str {
+			t.Errorf("FormatDuration(%v): have %q, want %q", test.d, have, test.str)
+		}
+		have, err := ParseDuration(test.str)
+		if err != nil || have != test.d {
+			t.Errorf("ParseDuration(%q): have %v, %v, want %v", test.str, have, err, test.d)
+		}
+	}
+
+	if have, err := ParseDuration("P2W"); err != nil || have != 14*24*time.Hour {
+		t.Errorf("ParseDuration(P2W): have %v, %v", have, err)
+	}
+	for _, str := range []string{"", "PT", "P1H", "-1D", "PTXM"} {
+		if _, err := ParseDuration(str); err == nil {
+			t.Errorf("ParseDuration(%q): expected error", str)
+		}
+	}
+}
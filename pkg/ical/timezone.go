@@ -0,0 +1,520 @@
+package ical
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxTimezoneYear is the last year of the transitions of the time zones
+// written to and read from VTIMEZONE components. The transitions repeated
+// up to it are written as a yearly rule without end.
+const maxTimezoneYear = 2100
+
+var errUnsupportedRule = errors.New("unsupported observance rule")
+
+// weekdayNames are the iCalendar names of the weekdays by time.Weekday.
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Timezones are the time zones defined by the VTIMEZONE components of a
+// calendar by TZID.
+type Timezones map[string]*time.Location
+
+// ParseTimezones returns the time zones defined by the VTIMEZONE components
+// of the calendar. A VTIMEZONE stands for the IANA time zone named by its
+// TZID, its X-LIC-LOCATION or the Windows time zone of its TZID; otherwise
+// the time zone is built from its STANDARD and DAYLIGHT observances.
+// VTIMEZONEs which are none of those are left out.
+func ParseTimezones(cal *Component) Timezones {
+	zones := make(Timezones)
+	for _, vtimezone := range cal.ChildrenByName(CompTimezone) {
+		prop := vtimezone.Get("TZID")
+		if prop == nil || prop.Value == "" {
+			continue
+		}
+		if loc, err := timezoneLocation(prop.Value, vtimezone); err == nil {
+			zones[prop.Value] = loc
+		}
+	}
+
+	return zones
+}
+
+// Location returns the time zone of the TZID: the one defined in the
+// calendar, if any, or the IANA one.
+func (z Timezones) Location(tzid string) (*time.Location, error) {
+	if loc, ok := z[tzid]; ok {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return nil, fmt.Errorf("unknown TZID %q: %w", tzid, err)
+	}
+
+	return loc, nil
+}
+
+// VTimezone returns the VTIMEZONE component of the time zone with the
+// observances in effect from the time from on. The transitions repeated
+// every year on the same weekday of the month are one observance with
+// a yearly RRULE.
+func VTimezone(loc *time.Location, from time.Time) *Component {
+	vtimezone := NewComponent(CompTimezone)
+	vtimezone.Add("TZID", loc.String())
+
+	var observances []*observance
+	start, end := from.In(loc).ZoneBounds()
+	if start.IsZero() {
+		// The offset at from has always been in effect.
+		name, offset := from.In(loc).Zone()
+		observances = append(observances, &observance{
+			transitions: []transition{{at: from.In(loc), from: offset, to: offset, name: name, isDST: from.In(loc).IsDST()}},
+			ordinal:     0,
+			last:        false,
+		})
+		start = end
+	}
+	for t := start; !t.IsZero() && t.Year() <= maxTimezoneYear; t = nextZone(loc, t) {
+		tr := newTransition(loc, t)
+		if name, _ := t.Add(-time.Second).In(loc).Zone(); tr.from == tr.to && tr.name == name {
+			// The bounds of the zones past the transitions of the tzdata
+			// also fall on the ends of the years.
+			continue
+		}
+		added := false
+		for i := len(observances) - 1; i >= 0; i-- {
+			if observances[i].transitions[0].isDST == tr.isDST {
+				added = observances[i].add(tr)
+				break
+			}
+		}
+		if !added {
+			ordinal, last := weekdayOfMonth(tr.at)
+			observances = append(observances, &observance{transitions: []transition{tr}, ordinal: ordinal, last: last})
+		}
+	}
+	for _, o := range observances {
+		vtimezone.Children = append(vtimezone.Children, o.component())
+	}
+
+	return vtimezone
+}
+
+// nextZone returns the start of the zone after the one in effect at t, zero
+// if none.
+func nextZone(loc *time.Location, t time.Time) time.Time {
+	_, end := t.In(loc).ZoneBounds()
+	if !end.IsZero() && !end.After(t) {
+		// ZoneBounds may not move past the end of a year computed from the
+		// rule of the tzdata.
+		_, end = t.Add(24 * time.Hour).In(loc).ZoneBounds()
+	}
+
+	return end
+}
+
+// transition is a change of the offset of a time zone.
+type transition struct {
+	// at is the time of the transition on the wall clock before it.
+	at       time.Time
+	from, to int
+	name     string
+	isDST    bool
+}
+
+// newTransition returns the transition of the time zone at t.
+func newTransition(loc *time.Location, t time.Time) transition {
+	_, from := t.Add(-time.Second).In(loc).Zone()
+	name, to := t.In(loc).Zone()
+
+	return transition{at: t.In(time.FixedZone("", from)), from: from, to: to, name: name, isDST: t.In(loc).IsDST()}
+}
+
+// observance is a STANDARD or DAYLIGHT component: the transitions in
+// consecutive years on the same weekday of the same month at the same time.
+type observance struct {
+	transitions []transition
+	// ordinal is the number of the weekday in the month of all the
+	// transitions, 0 if it differs; last is set if it is the last one.
+	ordinal int
+	last    bool
+}
+
+// add appends the transition if it repeats the observance a year after its
+// last transition and reports whether it does.
+func (o *observance) add(tr transition) bool {
+	prev := o.transitions[len(o.transitions)-1]
+	if tr.at.Year() != prev.at.Year()+1 || tr.at.Month() != prev.at.Month() || tr.at.Weekday() != prev.at.Weekday() ||
+		timeOfDay(tr.at) != timeOfDay(prev.at) || tr.from != prev.from || tr.to != prev.to || tr.name != prev.name {
+		return false
+	}
+	ordinal, last := weekdayOfMonth(tr.at)
+	if ordinal != o.ordinal {
+		ordinal = 0
+	}
+	last = last && o.last
+	if ordinal == 0 && !last {
+		return false
+	}
+	o.ordinal, o.last = ordinal, last
+	o.transitions = append(o.transitions, tr)
+
+	return true
+}
+
+func (o *observance) component() *Component {
+	first, final := o.transitions[0], o.transitions[len(o.transitions)-1]
+	comp := NewComponent(CompStandard)
+	if first.isDST {
+		comp.Name = CompDaylight
+	}
+	comp.Add("DTSTART", FormatLocalDateTime(first.at))
+	comp.Add("TZOFFSETFROM", formatUTCOffset(first.from))
+	comp.Add("TZOFFSETTO", formatUTCOffset(first.to))
+	if first.name != "" {
+		comp.AddText("TZNAME", first.name)
+	}
+	if len(o.transitions) > 1 {
+		byDay := strconv.Itoa(o.ordinal)
+		if o.last {
+			byDay = "-1"
+		}
+		rule := fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%s%s", first.at.Month(), byDay, weekdayNames[first.at.Weekday()])
+		if final.at.Year() < maxTimezoneYear {
+			rule += ";UNTIL=" + FormatDateTime(final.at)
+		}
+		comp.Add("RRULE", rule)
+	}
+
+	return comp
+}
+
+// weekdayOfMonth returns the number of the weekday of t in its month and
+// whether it is the last one.
+func weekdayOfMonth(t time.Time) (int, bool) {
+	const daysInWeek = 7
+
+	return (t.Day()-1)/daysInWeek + 1, t.AddDate(0, 0, daysInWeek).Month() != t.Month()
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+}
+
+// timezoneLocation returns the time zone the VTIMEZONE stands for.
+func timezoneLocation(tzid string, vtimezone *Component) (*time.Location, error) {
+	names := []string{tzid}
+	if prop := vtimezone.Get("X-LIC-LOCATION"); prop != nil {
+		names = append(names, prop.Value)
+	}
+	if name, ok := windowsZones[tzid]; ok {
+		names = append(names, name)
+	}
+	// Some producers prefix the IANA name, such as
+	// /mozilla.org/20050126_1/Europe/Berlin.
+	for i := range len(tzid) - 1 {
+		if tzid[i] == '/' {
+			names = append(names, tzid[i+1:])
+		}
+	}
+	for _, name := range names {
+		if name == "" || name == "Local" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc, nil
+		}
+	}
+
+	return observancesLocation(tzid, vtimezone)
+}
+
+// onset is the start of an observance.
+type onset struct {
+	at       int64
+	from, to int
+	name     string
+	isDST    bool
+}
+
+// observancesLocation builds the time zone from the STANDARD and DAYLIGHT
+// observances of the VTIMEZONE up to maxTimezoneYear. The last offset holds
+// after it.
+func observancesLocation(tzid string, vtimezone *Component) (*time.Location, error) {
+	var onsets []onset
+	for _, comp := range vtimezone.Children {
+		if comp.Name != CompStandard && comp.Name != CompDaylight {
+			continue
+		}
+		more, err := parseObservance(comp)
+		if err != nil {
+			return nil, fmt.Errorf("VTIMEZONE %q: %s: %w", tzid, comp.Name, err)
+		}
+		onsets = append(onsets, more...)
+	}
+	if len(onsets) == 0 {
+		return nil, fmt.Errorf("%w: VTIMEZONE %q without observances", ErrInvalidFormat, tzid)
+	}
+	slices.SortFunc(onsets, func(a, b onset) int { return cmp.Compare(a.at, b.at) })
+
+	loc, err := time.LoadLocationFromTZData(tzid, tzData(onsets))
+	if err != nil {
+		return nil, fmt.Errorf("VTIMEZONE %q: %w", tzid, err)
+	}
+
+	return loc, nil
+}
+
+// parseObservance returns the onsets of the STANDARD or DAYLIGHT component.
+func parseObservance(comp *Component) ([]onset, error) {
+	from, err := parseUTCOffset(comp.Get("TZOFFSETFROM"))
+	if err != nil {
+		return nil, fmt.Errorf("TZOFFSETFROM: %w", err)
+	}
+	to, err := parseUTCOffset(comp.Get("TZOFFSETTO"))
+	if err != nil {
+		return nil, fmt.Errorf("TZOFFSETTO: %w", err)
+	}
+	// The onsets are given on the wall clock before them.
+	loc := time.FixedZone("", from)
+	start, err := ParseDateTime(comp.Get("DTSTART"), loc)
+	if err != nil {
+		return nil, fmt.Errorf("DTSTART: %w", err)
+	}
+	times := []time.Time{start}
+	for _, prop := range comp.GetAll("RDATE") {
+		for value := range strings.SplitSeq(prop.Value, ",") {
+			prop.Value = value
+			t, err := ParseDateTime(&prop, loc)
+			if err != nil {
+				return nil, fmt.Errorf("RDATE: %w", err)
+			}
+			times = append(times, t)
+		}
+	}
+	if prop := comp.Get("RRULE"); prop != nil {
+		more, err := yearlyOnsets(prop.Value, start)
+		if err != nil {
+			return nil, fmt.Errorf("RRULE: %w", err)
+		}
+		times = append(times, more...)
+	}
+
+	name := comp.Text("TZNAME")
+	if name == "" {
+		name = formatUTCOffset(to)
+	}
+	onsets := make([]onset, 0, len(times))
+	for _, t := range times {
+		onsets = append(onsets, onset{at: t.Unix(), from: from, to: to, name: name, isDST: comp.Name == CompDaylight})
+	}
+
+	return onsets, nil
+}
+
+// yearlyOnsets returns the onsets after start of the yearly rule of an
+// observance, such as FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU, up to
+// maxTimezoneYear.
+//
+//nolint:cyclop
+func yearlyOnsets(rule string, start time.Time) ([]time.Time, error) {
+	month := start.Month()
+	var weekday *time.Weekday
+	var ordinal int
+	var monthDays []int
+	var until time.Time
+	count, interval := 0, 1
+	for part := range strings.SplitSeq(rule, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			if !strings.EqualFold(value, "YEARLY") {
+				return nil, fmt.Errorf("%w: FREQ=%s", errUnsupportedRule, value)
+			}
+		case "BYMONTH":
+			var n int
+			if n, err = strconv.Atoi(value); err == nil && (n < 1 || n > 12) {
+				err = ErrInvalidFormat
+			}
+			month = time.Month(n)
+		case "BYDAY":
+			day := strings.ToUpper(value)
+			i := slices.Index(weekdayNames[:], day[max(len(day)-2, 0):])
+			if i < 0 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", errUnsupportedRule, value)
+			}
+			wd := time.Weekday(i)
+			weekday = &wd
+			if number := day[:len(day)-2]; number != "" {
+				ordinal, err = strconv.Atoi(number)
+			}
+		case "BYMONTHDAY":
+			for day := range strings.SplitSeq(value, ",") {
+				var n int
+				if n, err = strconv.Atoi(day); err != nil {
+					break
+				}
+				monthDays = append(monthDays, n)
+			}
+		case "UNTIL":
+			until, err = ParseDateTime(&Prop{Name: name, Params: nil, Value: value}, start.Location())
+		case "COUNT":
+			count, err = strconv.Atoi(value)
+		case "INTERVAL":
+			if interval, err = strconv.Atoi(value); err == nil && interval < 1 {
+				err = ErrInvalidFormat
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnsupportedRule, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s=%s: %w", name, value, err)
+		}
+	}
+
+	if weekday == nil && monthDays == nil {
+		monthDays = []int{start.Day()}
+	}
+	hour, minute, second := start.Clock()
+	onsets := make([]time.Time, 0)
+	// start is the first onset, counted but not returned.
+	n := 1
+	for year := start.Year(); year <= maxTimezoneYear; year += interval {
+		var days []time.Time
+		for day := time.Date(year, month, 1, hour, minute, second, 0, start.Location()); day.Month() == month; day = day.AddDate(0, 0, 1) {
+			if (weekday == nil || day.Weekday() == *weekday) && (monthDays == nil || slices.Contains(monthDays, day.Day())) {
+				days = append(days, day)
+			}
+		}
+		switch {
+		case ordinal > 0 && ordinal <= len(days):
+			days = days[ordinal-1 : ordinal]
+		case ordinal < 0 && -ordinal <= len(days):
+			days = days[len(days)+ordinal : len(days)+ordinal+1]
+		case ordinal != 0:
+			days = nil
+		}
+		for _, day := range days {
+			if !day.After(start) {
+				continue
+			}
+			if (!until.IsZero() && day.After(until)) || (count > 0 && n == count) {
+				return onsets, nil
+			}
+			onsets = append(onsets, day)
+			n++
+		}
+	}
+
+	return onsets, nil
+}
+
+// tzData returns the time zone of the onsets in the TZif format, RFC 8536,
+// of version 2 with the 64-bit data only.
+func tzData(onsets []onset) []byte {
+	type zone struct {
+		offset int
+		isDST  bool
+		name   string
+	}
+	// The zone before the first onset goes first.
+	first := zone{offset: onsets[0].from, isDST: false, name: formatUTCOffset(onsets[0].from)}
+	if i := slices.IndexFunc(onsets, func(o onset) bool { return o.to == first.offset && !o.isDST }); i >= 0 {
+		first.name = onsets[i].name
+	}
+	zones := []zone{first}
+	indexes := make([]byte, 0, len(onsets))
+	for _, o := range onsets {
+		z := zone{offset: o.to, isDST: o.isDST, name: o.name}
+		i := slices.Index(zones, z)
+		if i < 0 {
+			i = len(zones)
+			zones = append(zones, z)
+		}
+		indexes = append(indexes, byte(i))
+	}
+
+	header := func(data []byte, timeCount, zoneCount, charCount int) []byte {
+		data = append(data, "TZif2"...)
+		data = append(data, make([]byte, 15)...)
+		// Counts of UT/local and standard/wall indicators and of leap seconds.
+		data = append(data, make([]byte, 3*4)...)
+		data = binary.BigEndian.AppendUint32(data, uint32(timeCount))
+		data = binary.BigEndian.AppendUint32(data, uint32(zoneCount))
+
+		return binary.BigEndian.AppendUint32(data, uint32(charCount))
+	}
+	var names []byte
+	zoneInfo := make([]byte, 0, len(zones)*6)
+	for _, z := range zones {
+		zoneInfo = binary.BigEndian.AppendUint32(zoneInfo, uint32(int32(z.offset)))
+		isDST := byte(0)
+		if z.isDST {
+			isDST = 1
+		}
+		zoneInfo = append(zoneInfo, isDST, byte(len(names)))
+		names = append(names, z.name+"\x00"...)
+	}
+
+	// The 32-bit data of version 1 is empty.
+	data := header(nil, 0, 0, 0)
+	data = header(data, len(onsets), len(zones), len(names))
+	for _, o := range onsets {
+		data = binary.BigEndian.AppendUint64(data, uint64(o.at))
+	}
+	data = append(data, indexes...)
+	data = append(data, zoneInfo...)
+	data = append(data, names...)
+
+	return append(data, "\n\n"...)
+}
+
+// formatUTCOffset formats a UTC-OFFSET value in seconds, such as "+0100".
+func formatUTCOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	value := fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		value += fmt.Sprintf("%02d", offset%60)
+	}
+
+	return value
+}
+
+// parseUTCOffset parses a UTC-OFFSET value, such as "-0500" or "+013000",
+// in seconds.
+func parseUTCOffset(prop *Prop) (int, error) {
+	if prop == nil {
+		return 0, errors.New("missing offset")
+	}
+	value := prop.Value
+	if (len(value) != len("+hhmm") && len(value) != len("+hhmmss")) || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("parse offset %q: %w", value, ErrInvalidFormat)
+	}
+	offset := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+		n, err := strconv.Atoi(value[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, fmt.Errorf("parse offset %q: %w", value, err)
+		}
+		offset += n * unit
+	}
+	if value[0] == '-' {
+		offset = -offset
+	}
+
+	return offset, nil
+}
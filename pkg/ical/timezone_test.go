@@ -0,0 +1,170 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // time zones of the tests on hosts without tzdata
+)
+
+func TestVTimezone(t *testing.T) {
+	tests := []struct {
+		zone string
+		from time.Time
+		// observances is the number of the STANDARD and DAYLIGHT components.
+		observances int
+	}{
+		{"Europe/Berlin", time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC), 2},
+		{"America/New_York", time.Date(1990, time.June, 1, 0, 0, 0, 0, time.UTC), 4},
+		{"Australia/Sydney", time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC), 2},
+		{"Asia/Tokyo", time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC), 1},
+		{"UTC", time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC), 1},
+	}
+	for _, test := range tests {
+		t.Run(test.zone, func(t *testing.T) {
+			loc, err := time.LoadLocation(test.zone)
+			if err != nil {
+				t.Fatalf("load location: %v", err)
+			}
+			cal := NewComponent(CompCalendar)
+			cal.Children = append(cal.Children, VTimezone(loc, test.from))
+			decoded, err := Decode(strings.NewReader(cal.String()))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			vtimezone := decoded.ChildrenByName(CompTimezone)[0]
+			if have := vtimezone.Get("TZID").Value; have != test.zone {
+				t.Errorf("TZID: have %q", have)
+			}
+			if have := len(vtimezone.Children); have != test.observances {
+				t.Errorf("have %d observances, want %d:\n%s", have, test.observances, cal)
+			}
+
+			// The observances alone give the offsets of the time zone.
+			zone, err := observancesLocation("Custom", vtimezone)
+			if err != nil {
+				t.Fatalf("observancesLocation: %v", err)
+			}
+			for at := test.from; at.Year() < maxTimezoneYear; at = at.Add(89 * 24 * time.Hour) {
+				_, want := at.In(loc).Zone()
+				if _, have := at.In(zone).Zone(); have != want {
+					t.Fatalf("offset at %v: have %d, want %d", at, have, want)
+				}
+				// Around the transitions.
+				start, _ := at.In(loc).ZoneBounds()
+				for _, near := range []time.Time{start.Add(-time.Second), start} {
+					if start.IsZero() || start.Before(test.from) {
+						break
+					}
+					_, want := near.In(loc).Zone()
+					if _, have := near.In(zone).Zone(); have != want {
+						t.Fatalf("offset at %v: have %d, want %d", near, have, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+const outlookCalendar = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:W. Europe Standard Time\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:16010101T030000\r\n" +
+	"TZOFFSETFROM:+0200\r\n" +
+	"TZOFFSETTO:+0100\r\n" +
+	"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=10\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:16010101T020000\r\n" +
+	"TZOFFSETFROM:+0100\r\n" +
+	"TZOFFSETTO:+0200\r\n" +
+	"RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=3\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:/mozilla.org/20050126_1/America/New_York\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:19701101T020000\r\n" +
+	"TZOFFSETFROM:-0400\r\n" +
+	"TZOFFSETTO:-0500\r\n" +
+	"END:STANDARD\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Custom Time Zone\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:19701025T030000\r\n" +
+	"TZOFFSETFROM:+0330\r\n" +
+	"TZOFFSETTO:+0230\r\n" +
+	"TZNAME:CST\r\n" +
+	"RRULE:FREQ=YEARLY;BYMONTH=10;BYMONTHDAY=8,9,10,11,12,13,14;BYDAY=SU\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:19700329T020000\r\n" +
+	"TZOFFSETFROM:+0230\r\n" +
+	"TZOFFSETTO:+0330\r\n" +
+	"TZNAME:CDT\r\n" +
+	"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=1SU\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Unsupported\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:19700101T000000\r\n" +
+	"TZOFFSETFROM:+0100\r\n" +
+	"TZOFFSETTO:+0100\r\n" +
+	"RRULE:FREQ=MONTHLY\r\n" +
+	"END:STANDARD\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseTimezones(t *testing.T) {
+	cal, err := Decode(strings.NewReader(outlookCalendar))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	zones := ParseTimezones(cal)
+
+	tests := []struct {
+		tzid  string
+		value string
+		want  time.Time
+		// name is the name of the time zone, the IANA one if known.
+		name string
+	}{
+		{"W. Europe Standard Time", "20250106T090000", time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC), "Europe/Berlin"},
+		{"W. Europe Standard Time", "20250707T090000", time.Date(2025, time.July, 7, 7, 0, 0, 0, time.UTC), "Europe/Berlin"},
+		{"/mozilla.org/20050126_1/America/New_York", "20250707T090000", time.Date(2025, time.July, 7, 13, 0, 0, 0, time.UTC), "America/New_York"},
+		// Daylight from the first Sunday of March to the second Sunday of October.
+		{"Custom Time Zone", "20250301T090000", time.Date(2025, time.March, 1, 6, 30, 0, 0, time.UTC), "Custom Time Zone"},
+		{"Custom Time Zone", "20250302T090000", time.Date(2025, time.March, 2, 5, 30, 0, 0, time.UTC), "Custom Time Zone"},
+		{"Custom Time Zone", "20251011T090000", time.Date(2025, time.October, 11, 5, 30, 0, 0, time.UTC), "Custom Time Zone"},
+		{"Custom Time Zone", "20251012T090000", time.Date(2025, time.October, 12, 6, 30, 0, 0, time.UTC), "Custom Time Zone"},
+		{"Europe/Berlin", "20250106T090000", time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC), "Europe/Berlin"},
+	}
+	for _, test := range tests {
+		prop := Prop{Name: "DTSTART", Params: map[string]string{"TZID": test.tzid}, Value: test.value}
+		have, err := zones.ParseDateTime(&prop, time.UTC)
+		if err != nil {
+			t.Errorf("ParseDateTime(%s %s): %v", test.tzid, test.value, err)
+			continue
+		}
+		if !have.Equal(test.want) || have.Location().String() != test.name {
+			t.Errorf("ParseDateTime(%s %s): have %v in %s, want %v in %s",
+				test.tzid, test.value, have.UTC(), have.Location(), test.want, test.name)
+		}
+	}
+
+	prop := Prop{Name: "DTSTART", Params: map[string]string{"TZID": "Unsupported"}, Value: "20250106T090000"}
+	if _, err := zones.ParseDateTime(&prop, time.UTC); err == nil {
+		t.Error("ParseDateTime: expected an error for a time zone with an unsupported rule")
+	}
+}
+
+func TestWindowsZones(t *testing.T) {
+	for windows, iana := range windowsZones {
+		if _, err := time.LoadLocation(iana); err != nil {
+			t.Errorf("%s: %v", windows, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	layoutDate        = "20060102"
+	layoutDateTime    = "20060102T150405"
+	layoutDateTimeUTC = "20060102T150405Z"
+)
+
+// FormatDateTime formats a DATE-TIME value in UTC.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(layoutDateTimeUTC)
+}
+
+// FormatLocalDateTime formats a DATE-TIME value on the wall clock of the
+// location of t, to be qualified by the TZID parameter.
+func FormatLocalDateTime(t time.Time) string {
+	return t.Format(layoutDateTime)
+}
+
+// FormatDate formats a DATE value.
+func FormatDate(t time.Time) string {
+	return t.Format(layoutDate)
+}
+
+// ParseDateTime parses the DATE or DATE-TIME value of the property, honoring
+// its TZID parameter as an IANA name. Floating times are interpreted in loc.
+func ParseDateTime(prop *Prop, loc *time.Location) (time.Time, error) {
+	return Timezones(nil).ParseDateTime(prop, loc)
+}
+
+// ParseDateTime parses the DATE or DATE-TIME value of the property, honoring
+// its TZID parameter. Floating times are interpreted in loc.
+func (z Timezones) ParseDateTime(prop *Prop, loc *time.Location) (time.Time, error) {
+	if prop == nil {
+		return time.Time{}, errors.New("missing date-time")
+	}
+	value := prop.Value
+	if tzid := prop.Param("TZID"); tzid != "" {
+		tz, err := z.Location(tzid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = tz
+	}
+
+	switch {
+	case prop.Param("VALUE") == "DATE" || len(value) == len(layoutDate):
+		t, err := time.ParseInLocation(layoutDate, value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse date %q: %w", value, err)
+		}
+		return t, nil
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse(layoutDateTimeUTC, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse date-time %q: %w", value, err)
+		}
+		return t, nil
+	default:
+		t, err := time.ParseInLocation(layoutDateTime, value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse date-time %q: %w", value, err)
+		}
+		return t, nil
+	}
+}
+
+// IsDate reports whether the property holds a DATE value.
+func IsDate(prop *Prop) bool {
+	return prop != nil && (prop.Param("VALUE") == "DATE" || len(prop.Value) == len(layoutDate))
+}
+
+// FormatDuration formats a DURATION value, such as "-PT15M".
+func FormatDuration(d time.Duration) string {
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteByte('P')
+
+	const day = 24 * time.Hour
+	days := d / day
+	d -= days * day
+	if days > 0 {
+		sb.WriteString(strconv.FormatInt(int64(days), 10) + "D")
+	}
+	if d == 0 {
+		if days == 0 {
+			sb.WriteString("T0S")
+		}
+		return sb.String()
+	}
+	sb.WriteByte('T')
+	if hours := d / time.Hour; hours > 0 {
+		sb.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		sb.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+		d -= minutes * time.Minute
+	}
+	if seconds := d / time.Second; seconds > 0 {
+		sb.WriteString(strconv.FormatInt(int64(seconds), 10) + "S")
+	}
+
+	return sb.String()
+}
+
+// ParseDuration parses a DURATION value, such as "-P1DT2H" or "P2W".
+func ParseDuration(value string) (time.Duration, error) {
+	str := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(str, "-"):
+		sign = -1
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+	if !strings.HasPrefix(str, "P") || len(str) < len("P0D") {
+		return 0, fmt.Errorf("parse duration %q: %w", value, ErrInvalidFormat)
+	}
+	str = str[1:]
+
+	var d time.Duration
+	inTime := false
+	for str != "" {
+		if str[0] == 'T' {
+			inTime = true
+			str = str[1:]
+			continue
+		}
+		i := strings.IndexFunc(str, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, fmt.Errorf("parse duration %q: %w", value, ErrInvalidFormat)
+		}
+		n, err := strconv.Atoi(str[:i])
+		if err != nil {
+			return 0, fmt.Errorf("parse duration %q: %w", value, err)
+		}
+		unit := map[bool]map[byte]time.Duration{
+			false: {'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour},
+			true:  {'H': time.Hour, 'M': time.Minute, 'S': time.Second},
+		}[inTime][str[i]]
+		if unit == 0 {
+			return 0, fmt.Errorf("parse duration %q: %w", value, ErrInvalidFormat)
+		}
+		d += time.Duration(n) * unit
+		str = str[i+1:]
+	}
+
+	return sign * d, nil
+}
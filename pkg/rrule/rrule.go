@@ -0,0 +1,464 @@
+// Package rrule implements a subset of the RFC 5545 recurrence rule:
+// FREQ, INTERVAL, BYDAY, COUNT and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxEmptyPeriods limits the number of consecutive periods without
+// occurrences, so that a rule which never matches does not loop forever.
+const maxEmptyPeriods = 1000
+
+const (
+	daysInWeek   = 7
+	monthsInYear = 12
+	secondsInDay = 24 * 60 * 60
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// WeekdayNum is a BYDAY element. A non-zero N selects the N-th weekday of the
+// month (MONTHLY) or year (YEARLY), counting from the end when negative.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Weekday]
+}
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int
+	Until    time.Time
+
+	// untilLayout is the layout UNTIL was written in. A floating date-time
+	// or a date is stored as its wall clock in UTC and is resolved in the
+	// location of dtstart.
+	untilLayout string
+}
+
+const (
+	layoutUTC      = "20060102T150405Z"
+	layoutFloating = "20060102T150405"
+	layoutDate     = "20060102"
+)
+
+// Parse parses a recurrence rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE".
+// An optional "RRULE:" prefix is accepted.
+func Parse(str string) (*Rule, error) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "RRULE:")
+	if str == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidRule)
+	}
+
+	rule := Rule{Interval: 1} //nolint:exhaustruct
+	for part := range strings.SplitSeq(str, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			err = rule.parseFreq(value)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err == nil && rule.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "BYDAY":
+			err = rule.parseByDay(value)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+			if err == nil && rule.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			rule.Until, rule.untilLayout, err = parseUntil(value)
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = errors.New("only MO is supported")
+			}
+		default:
+			err = errors.New("unsupported part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%s: %w", ErrInvalidRule, name, value, err)
+		}
+	}
+
+	if rule.Freq == 0 {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count != 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+	if rule.Freq == Daily || rule.Freq == Weekly {
+		for _, day := range rule.ByDay {
+			if day.N != 0 {
+				return nil, fmt.Errorf("%w: BYDAY=%s is not allowed with FREQ=%s", ErrInvalidRule, day, rule.Freq)
+			}
+		}
+	}
+
+	return &rule, nil
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for freq, name := range frequencyNames {
+		if strings.EqualFold(value, name) {
+			r.Freq = freq
+			return nil
+		}
+	}
+
+	return errors.New("unsupported frequency")
+}
+
+func (r *Rule) parseByDay(value string) error {
+	for item := range strings.SplitSeq(strings.ToUpper(value), ",") {
+		const lenName = 2
+		if len(item) < lenName {
+			return fmt.Errorf("malformed weekday %q", item)
+		}
+		name := item[len(item)-lenName:]
+		var day WeekdayNum
+		found := false
+		for weekday, weekdayName := range weekdayNames {
+			if weekdayName == name {
+				day.Weekday = weekday
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown weekday %q", name)
+		}
+		if numStr := item[:len(item)-lenName]; numStr != "" {
+			n, err := strconv.Atoi(numStr)
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return fmt.Errorf("malformed weekday %q", item)
+			}
+			day.N = n
+		}
+		r.ByDay = append(r.ByDay, day)
+	}
+
+	return nil
+}
+
+func parseUntil(value string) (time.Time, string, error) {
+	for _, layout := range []string{layoutUTC, layoutFloating, layoutDate} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == layoutUTC {
+				layout = ""
+			}
+			return until, layout, nil
+		}
+	}
+
+	return time.Time{}, "", errors.New("expected date or UTC date-time")
+}
+
+// until returns the UNTIL bound, resolving a floating date-time or a date
+// in loc. A date UNTIL includes the whole day.
+func (r *Rule) until(loc *time.Location) time.Time {
+	if r.Until.IsZero() || r.untilLayout == "" {
+		return r.Until
+	}
+	year, month, day := r.Until.Date()
+	if r.untilLayout == layoutDate {
+		return time.Date(year, month, day+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	}
+	hour, minute, sec := r.Until.Clock()
+
+	return time.Date(year, month, day, hour, minute, sec, r.Until.Nanosecond(), loc)
+}
+
+// SetUntil sets UNTIL to the UTC date-time until.
+func (r *Rule) SetUntil(until time.Time) {
+	r.Until = until.UTC()
+	r.untilLayout = ""
+}
+
+// String returns the rule in RFC 5545 form without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.untilLayout != "" {
+			parts = append(parts, "UNTIL="+r.Until.Format(r.untilLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(layoutUTC))
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// IsInfinite reports whether the rule has neither COUNT nor UNTIL.
+func (r *Rule) IsInfinite() bool {
+	return r.Count == 0 && r.Until.IsZero()
+}
+
+// All returns the start times of all occurrences in ascending order,
+// beginning with dtstart itself. Occurrences keep the wall clock time of
+// dtstart in its location. The sequence is infinite if the rule is.
+func (r *Rule) All(dtstart time.Time) iter.Seq[time.Time] {
+	return r.fromPeriod(dtstart, 0)
+}
+
+// From returns the start times of the occurrences at or after from in
+// ascending order, as All does. A rule without COUNT begins near from
+// instead of walking every period since dtstart.
+func (r *Rule) From(dtstart, from time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := range r.fromPeriod(dtstart, r.firstPeriod(dtstart, from)) {
+			if t.Before(from) {
+				continue
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// fromPeriod returns the occurrences beginning with the period which is
+// first periods after the period of dtstart. Only the sequence of a rule
+// without COUNT may begin after the period of dtstart.
+func (r *Rule) fromPeriod(dtstart time.Time, first int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		until := r.until(dtstart.Location())
+		if !until.IsZero() && dtstart.After(until) {
+			return
+		}
+		if first == 0 && !yield(dtstart) {
+			return
+		}
+		count := 1
+		empty := 0
+		for period := first; ; period++ {
+			candidates := r.expand(dtstart, period*r.Interval)
+			found := false
+			for _, t := range candidates {
+				if !t.After(dtstart) {
+					continue
+				}
+				found = true
+				if !until.IsZero() && t.After(until) {
+					return
+				}
+				if r.Count != 0 && count >= r.Count {
+					return
+				}
+				count++
+				if !yield(t) {
+					return
+				}
+			}
+			if found {
+				empty = 0
+			} else if empty++; empty > maxEmptyPeriods {
+				return
+			}
+		}
+	}
+}
+
+// firstPeriod returns a period no later than the one of from. It is zero
+// for a rule with COUNT, whose occurrences are counted from dtstart.
+func (r *Rule) firstPeriod(dtstart, from time.Time) int {
+	if r.Count != 0 || !from.After(dtstart) {
+		return 0
+	}
+	from = from.In(dtstart.Location())
+	var periods int
+	switch r.Freq {
+	case Daily:
+		periods = daysBetween(dtstart, from)
+	case Weekly:
+		periods = daysBetween(dtstart, from) / daysInWeek
+	case Monthly:
+		periods = (from.Year()-dtstart.Year())*monthsInYear + int(from.Month()-dtstart.Month())
+	case Yearly:
+		periods = from.Year() - dtstart.Year()
+	}
+
+	// The period before, the week of from may begin before it.
+	return max(0, periods/r.Interval-1)
+}
+
+// daysBetween returns the number of calendar days from the date of a to the
+// date of b.
+func daysBetween(a, b time.Time) int {
+	yearA, monthA, dayA := a.Date()
+	yearB, monthB, dayB := b.Date()
+	secondsA := time.Date(yearA, monthA, dayA, 0, 0, 0, 0, time.UTC).Unix()
+	secondsB := time.Date(yearB, monthB, dayB, 0, 0, 0, 0, time.UTC).Unix()
+
+	return int((secondsB - secondsA) / secondsInDay)
+}
+
+// Last returns the start time of the last occurrence.
+// It returns false if the rule is infinite.
+func (r *Rule) Last(dtstart time.Time) (time.Time, bool) {
+	if r.IsInfinite() {
+		return time.Time{}, false
+	}
+	last := dtstart
+	for t := range r.All(dtstart) {
+		last = t
+	}
+
+	return last, true
+}
+
+// expand returns the sorted candidate occurrences in the period which is
+// offset periods after the period of dtstart.
+func (r *Rule) expand(dtstart time.Time, offset int) []time.Time {
+	year, month, day := dtstart.Date()
+	hour, minute, sec := dtstart.Clock()
+	nsec := dtstart.Nanosecond()
+	loc := dtstart.Location()
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, nsec, loc)
+	}
+
+	var candidates []time.Time
+	switch r.Freq {
+	case Daily:
+		t := date(year, month, day+offset)
+		if len(r.ByDay) == 0 || r.hasWeekday(t.Weekday()) {
+			candidates = append(candidates, t)
+		}
+	case Weekly:
+		// Weeks start on Monday.
+		monday := day - (int(dtstart.Weekday())+daysInWeek-1)%daysInWeek + daysInWeek*offset
+		if len(r.ByDay) == 0 {
+			return []time.Time{date(year, month, day+daysInWeek*offset)}
+		}
+		for i := range daysInWeek {
+			t := date(year, month, monday+i)
+			if r.hasWeekday(t.Weekday()) {
+				candidates = append(candidates, t)
+			}
+		}
+	case Monthly:
+		first := time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		if len(r.ByDay) == 0 {
+			// Months without such a day are skipped.
+			if t := date(first.Year(), first.Month(), day); t.Day() == day {
+				candidates = append(candidates, t)
+			}
+			return candidates
+		}
+		candidates = r.expandByDay(date, first.Year(), first.Month(), first.Month())
+	case Yearly:
+		if len(r.ByDay) == 0 {
+			if t := date(year+offset, month, day); t.Day() == day {
+				candidates = append(candidates, t)
+			}
+			return candidates
+		}
+		candidates = r.expandByDay(date, year+offset, time.January, time.December)
+	}
+
+	return candidates
+}
+
+// expandByDay returns the days from the first day of fromMonth to the last day
+// of toMonth which match BYDAY.
+func (r *Rule) expandByDay(date func(int, time.Month, int) time.Time, year int, fromMonth, toMonth time.Month) []time.Time {
+	var days []time.Time
+	for d := date(year, fromMonth, 1); d.Year() == year && d.Month() <= toMonth; d = date(year, d.Month(), d.Day()+1) {
+		days = append(days, d)
+	}
+
+	var candidates []time.Time
+	for _, byDay := range r.ByDay {
+		var matched []time.Time
+		for _, d := range days {
+			if d.Weekday() == byDay.Weekday {
+				matched = append(matched, d)
+			}
+		}
+		switch {
+		case byDay.N == 0:
+			candidates = append(candidates, matched...)
+		case byDay.N > 0 && byDay.N <= len(matched):
+			candidates = append(candidates, matched[byDay.N-1])
+		case byDay.N < 0 && -byDay.N <= len(matched):
+			candidates = append(candidates, matched[len(matched)+byDay.N])
+		}
+	}
+	slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+
+	return slices.CompactFunc(candidates, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func (r *Rule) hasWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,219 @@
+package rrule
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{"freq=monthly;byday=-1fr;count=3", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3"},
+		{"FREQ=YEARLY;UNTIL=20300101T000000Z", "FREQ=YEARLY;UNTIL=20300101T000000Z"},
+		{"FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		{"FREQ=DAILY;UNTIL=20300101T090000", "FREQ=DAILY;UNTIL=20300101T090000"},
+		{"FREQ=DAILY;UNTIL=20300101", "FREQ=DAILY;UNTIL=20300101"},
+	}
+	for _, test := range tests {
+		rule, err := Parse(test.str)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.str, err)
+			continue
+		}
+		if rule.String() != test.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", test.str, rule.String(), test.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20300101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;BYMONTH=1",
+		"FREQ=DAILY;COUNT",
+	}
+	for _, str := range tests {
+		if _, err := Parse(str); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q): expected ErrInvalidRule, got %v", str, err)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	// Monday
+	dtstart := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		rule string
+		want []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", []time.Time{date(1, 6), date(1, 7), date(1, 8)}},
+		{"FREQ=DAILY;BYDAY=SA,SU;COUNT=3", []time.Time{date(1, 6), date(1, 11), date(1, 12)}},
+		{"FREQ=WEEKLY;INTERVAL=2;COUNT=3", []time.Time{date(1, 6), date(1, 20), date(2, 3)}},
+		{"FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4", []time.Time{date(1, 6), date(1, 10), date(1, 13), date(1, 17)}},
+		{"FREQ=WEEKLY;BYDAY=TU;UNTIL=20250115T000000Z", []time.Time{date(1, 6), date(1, 7), date(1, 14)}},
+		{"FREQ=MONTHLY;COUNT=3", []time.Time{date(1, 6), date(2, 6), date(3, 6)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", []time.Time{date(1, 6), date(1, 31), date(2, 28)}},
+		{"FREQ=YEARLY;COUNT=2", []time.Time{date(1, 6), time.Date(2026, time.January, 6, 9, 0, 0, 0, time.UTC)}},
+	}
+	for _, test := range tests {
+		rule, err := Parse(test.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", test.rule, err)
+		}
+		var have []time.Time
+		for occurrence := range rule.All(dtstart) {
+			have = append(have, occurrence)
+		}
+		if len(have) != len(test.want) {
+			t.Errorf("%s: have %v, want %v", test.rule, have, test.want)
+			continue
+		}
+		for i := range have {
+			if !have[i].Equal(test.want[i]) {
+				t.Errorf("%s: occurrence %d: have %v, want %v", test.rule, i, have[i], test.want[i])
+			}
+		}
+	}
+}
+
+func TestAll_SkipsMissingDays(t *testing.T) {
+	rule, err := Parse("FREQ=MONTHLY;COUNT=3")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	dtstart := time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC)
+	want := []time.Month{time.January, time.March, time.May}
+	i := 0
+	for occurrence := range rule.All(dtstart) {
+		if occurrence.Month() != want[i] || occurrence.Day() != 31 {
+			t.Errorf("occurrence %d: have %v, want %s 31", i, occurrence, want[i])
+		}
+		i++
+	}
+}
+
+func TestAll_KeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	rule, err := Parse("FREQ=WEEKLY;COUNT=3")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	dtstart := time.Date(2025, time.March, 23, 9, 0, 0, 0, loc)
+	for occurrence := range rule.All(dtstart) {
+		if occurrence.Hour() != 9 {
+			t.Errorf("occurrence %v: expected 09:00 local time", occurrence)
+		}
+	}
+}
+
+func TestAll_FloatingUntilInLocation(t *testing.T) {
+	tests := []struct {
+		zone  string
+		hour  int
+		rule  string
+		count int
+	}{
+		{"America/New_York", 9, "FREQ=DAILY;UNTIL=20250110T090000", 5},
+		{"America/New_York", 20, "FREQ=DAILY;UNTIL=20250110", 5},
+		{"Asia/Tokyo", 8, "FREQ=DAILY;UNTIL=20250110", 5},
+		{"Asia/Tokyo", 8, "FREQ=DAILY;UNTIL=20250109T220000Z", 4},
+	}
+	for _, test := range tests {
+		loc, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Skipf("load location: %v", err)
+		}
+		rule, err := Parse(test.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", test.rule, err)
+		}
+		dtstart := time.Date(2025, time.January, 6, test.hour, 0, 0, 0, loc)
+		count := 0
+		for range rule.All(dtstart) {
+			count++
+		}
+		if count != test.count {
+			t.Errorf("%s in %s: have %d occurrences, want %d", test.rule, test.zone, count, test.count)
+		}
+	}
+}
+
+func TestLast(t *testing.T) {
+	dtstart := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	rule, _ := Parse("FREQ=DAILY;COUNT=10")
+	last, ok := rule.Last(dtstart)
+	if !ok || !last.Equal(dtstart.AddDate(0, 0, 9)) {
+		t.Errorf("Last: have %v %v, want %v", last, ok, dtstart.AddDate(0, 0, 9))
+	}
+
+	rule, _ = Parse("FREQ=DAILY")
+	if _, ok := rule.Last(dtstart); ok {
+		t.Errorf("Last: infinite rule must not have last occurrence")
+	}
+}
+
+func TestFrom(t *testing.T) {
+	dtstart := time.Date(2020, time.January, 31, 9, 0, 0, 0, time.UTC)
+	rules := []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3;BYDAY=MO,TU",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO",
+		"FREQ=MONTHLY",
+		"FREQ=MONTHLY;INTERVAL=5;BYDAY=-1FR",
+		"FREQ=YEARLY;INTERVAL=2;BYDAY=1MO",
+		"FREQ=WEEKLY;COUNT=300",
+		"FREQ=DAILY;UNTIL=20260101T000000Z",
+	}
+	froms := []time.Time{
+		dtstart.Add(-time.Hour),
+		dtstart,
+		time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.December, 31, 9, 0, 0, 0, time.UTC),
+	}
+	for _, str := range rules {
+		rule, err := Parse(str)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", str, err)
+		}
+		for _, from := range froms {
+			var want []time.Time
+			for occurrence := range rule.All(dtstart) {
+				if len(want) == 5 {
+					break
+				}
+				if !occurrence.Before(from) {
+					want = append(want, occurrence)
+				}
+			}
+			var have []time.Time
+			for occurrence := range rule.From(dtstart, from) {
+				if len(have) == 5 {
+					break
+				}
+				have = append(have, occurrence)
+			}
+			if !slices.EqualFunc(have, want, time.Time.Equal) {
+				t.Errorf("%s from %v: have %v, want %v", str, from, have, want)
+			}
+		}
+	}
+}